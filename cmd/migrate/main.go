@@ -74,6 +74,157 @@ func main() {
 			INDEX idx_following_id (following_id),
 			INDEX idx_created_at (created_at)
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS tweet_revisions (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			tweet_id BIGINT NOT NULL,
+			content TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			INDEX idx_tweet_created (tweet_id, created_at DESC)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS likes (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			tweet_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_like (user_id, tweet_id),
+			INDEX idx_tweet_created (tweet_id, created_at DESC)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS tweet_stats (
+			tweet_id BIGINT PRIMARY KEY,
+			like_count BIGINT NOT NULL DEFAULT 0,
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS api_tokens (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			token_hash CHAR(64) NOT NULL UNIQUE,
+			scopes VARCHAR(255) NOT NULL,
+			last_used_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			INDEX idx_user_id (user_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS tweet_mentions (
+			tweet_id BIGINT NOT NULL,
+			user_id BIGINT NOT NULL,
+			start_offset INT NOT NULL,
+			end_offset INT NOT NULL,
+			PRIMARY KEY (tweet_id, start_offset),
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			INDEX idx_user_tweet (user_id, tweet_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS hashtags (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			tag VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL UNIQUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS tweet_hashtags (
+			tweet_id BIGINT NOT NULL,
+			hashtag_id BIGINT NOT NULL,
+			PRIMARY KEY (tweet_id, hashtag_id),
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			FOREIGN KEY (hashtag_id) REFERENCES hashtags(id) ON DELETE CASCADE,
+			INDEX idx_hashtag_tweet (hashtag_id, tweet_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS data_exports (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			file_name VARCHAR(255) NOT NULL DEFAULT '',
+			error VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP NULL DEFAULT NULL,
+			expires_at TIMESTAMP NULL DEFAULT NULL,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			INDEX idx_user_created (user_id, created_at),
+			INDEX idx_status_created (status, created_at),
+			INDEX idx_expires_at (expires_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS follow_requests (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			requester_id BIGINT NOT NULL,
+			target_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (target_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_follow_request (requester_id, target_id),
+			INDEX idx_target_created (target_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS blocks (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			blocker_id BIGINT NOT NULL,
+			blocked_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_block (blocker_id, blocked_id),
+			INDEX idx_blocked_blocker (blocked_id, blocker_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS muted_users (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			muted_user_id BIGINT NOT NULL,
+			expires_at TIMESTAMP NULL DEFAULT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (muted_user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_muted_user (user_id, muted_user_id),
+			INDEX idx_user_expires (user_id, expires_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS muted_words (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			phrase VARCHAR(100) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_muted_word (user_id, phrase)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS lists (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			owner_id BIGINT NOT NULL,
+			name VARCHAR(25) NOT NULL,
+			description VARCHAR(100) NOT NULL DEFAULT '',
+			private BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE,
+			INDEX idx_owner_created (owner_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS list_members (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			list_id BIGINT NOT NULL,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_list_member (list_id, user_id),
+			INDEX idx_member_list (user_id, list_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS list_subscriptions (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			list_id BIGINT NOT NULL,
+			user_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_list_subscription (list_id, user_id),
+			INDEX idx_subscriber_created (user_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
+		`CREATE TABLE IF NOT EXISTS bookmarks (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			user_id BIGINT NOT NULL,
+			tweet_id BIGINT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			UNIQUE KEY unique_bookmark (user_id, tweet_id),
+			INDEX idx_user_created (user_id, created_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci`,
 	}
 
 	for i, command := range commands {
@@ -84,6 +235,48 @@ func main() {
 		}
 	}
 
+	// Aplicar cambios de esquema sobre tablas existentes. Estos comandos no son idempotentes
+	// (MySQL no soporta ADD COLUMN IF NOT EXISTS), por lo que un error indica que ya se aplicaron.
+	log.Println("Applying schema changes...")
+	alterCommands := []string{
+		`ALTER TABLE tweets
+			ADD COLUMN in_reply_to_tweet_id BIGINT NULL AFTER content,
+			ADD COLUMN conversation_id BIGINT NULL AFTER in_reply_to_tweet_id,
+			ADD CONSTRAINT fk_tweets_in_reply_to FOREIGN KEY (in_reply_to_tweet_id) REFERENCES tweets(id) ON DELETE SET NULL,
+			ADD INDEX idx_in_reply_to (in_reply_to_tweet_id),
+			ADD INDEX idx_conversation_created (conversation_id, created_at)`,
+		`ALTER TABLE tweets
+			ADD COLUMN retweet_of_tweet_id BIGINT NULL AFTER conversation_id,
+			ADD COLUMN quoted_tweet_id BIGINT NULL AFTER retweet_of_tweet_id,
+			ADD CONSTRAINT fk_tweets_retweet_of FOREIGN KEY (retweet_of_tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+			ADD CONSTRAINT fk_tweets_quoted FOREIGN KEY (quoted_tweet_id) REFERENCES tweets(id) ON DELETE SET NULL,
+			ADD UNIQUE KEY unique_retweet (user_id, retweet_of_tweet_id),
+			ADD INDEX idx_quoted (quoted_tweet_id)`,
+		`ALTER TABLE users
+			ADD COLUMN password_hash VARCHAR(255) NOT NULL DEFAULT '' AFTER email`,
+		`ALTER TABLE tweets
+			ADD FULLTEXT INDEX ft_tweets_content (content)`,
+		`ALTER TABLE users
+			ADD COLUMN display_name VARCHAR(50) NOT NULL DEFAULT '' AFTER password_hash,
+			ADD COLUMN bio VARCHAR(160) NOT NULL DEFAULT '' AFTER display_name,
+			ADD COLUMN location VARCHAR(30) NOT NULL DEFAULT '' AFTER bio,
+			ADD COLUMN website VARCHAR(100) NOT NULL DEFAULT '' AFTER location,
+			ADD COLUMN avatar_url VARCHAR(255) NOT NULL DEFAULT '' AFTER website`,
+		`ALTER TABLE users
+			ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER avatar_url,
+			ADD INDEX idx_deleted_at (deleted_at)`,
+		`ALTER TABLE users
+			ADD COLUMN protected BOOLEAN NOT NULL DEFAULT FALSE AFTER avatar_url`,
+	}
+
+	for i, command := range alterCommands {
+		log.Printf("Applying schema change %d/%d", i+1, len(alterCommands))
+		_, err = db.Exec(command)
+		if err != nil {
+			log.Printf("Warning: Schema change not applied (already applied?): %v", err)
+		}
+	}
+
 	// Insertar datos de prueba
 	log.Println("Inserting test data...")
 	insertCommands := []string{
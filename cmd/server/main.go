@@ -5,14 +5,18 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"microx/internal/api"
 	"microx/internal/config"
 	"microx/internal/middleware"
+	"microx/internal/model"
 	"microx/internal/repository"
+	"microx/internal/repository/disk"
 	"microx/internal/repository/mysql"
 	"microx/internal/repository/redis"
 	"microx/internal/service"
+	"microx/internal/worker"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -40,22 +44,108 @@ func main() {
 	userRepo := mysql.NewUserRepository(dbConfig.MySQL)
 	tweetRepo := mysql.NewTweetRepository(dbConfig.MySQL)
 	followRepo := mysql.NewFollowRepository(dbConfig.MySQL)
-	timelineRepo := redis.NewTimelineRepository(dbConfig.Redis)
+	blockRepo := mysql.NewBlockRepository(dbConfig.MySQL)
+	muteRepo := mysql.NewMuteRepository(dbConfig.MySQL)
+	listRepo := mysql.NewListRepository(dbConfig.MySQL)
+	likeRepo := mysql.NewLikeRepository(dbConfig.MySQL)
+	bookmarkRepo := mysql.NewBookmarkRepository(dbConfig.MySQL)
+	searchRepo := mysql.NewSearchRepository(dbConfig.MySQL)
+	sessionRepo := redis.NewSessionRepository(dbConfig.Redis)
+	apiTokenRepo := mysql.NewAPITokenRepository(dbConfig.MySQL)
+	dataExportRepo := mysql.NewDataExportRepository(dbConfig.MySQL)
 
 	// Obtener configuración de la aplicación
 	maxTweetLength := getEnvAsInt("MAX_TWEET_LENGTH", 280)
+	tweetEditWindow := time.Duration(getEnvAsInt("TWEET_EDIT_WINDOW_MINUTES", 30)) * time.Minute
+	accessTokenTTL := time.Duration(getEnvAsInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute
+	refreshTokenTTL := time.Duration(getEnvAsInt("REFRESH_TOKEN_TTL_HOURS", 720)) * time.Hour
+	// Tiempo que se conserva cada tweet en la caché con la que se hidratan los timelines
+	tweetCacheTTL := time.Duration(getEnvAsInt("TWEET_CACHE_TTL_MINUTES", 60)) * time.Minute
+	// Los timelines cacheados guardan a lo sumo TIMELINE_MAX_LENGTH tweets; lo anterior se lee desde MySQL
+	timelineMaxLength := getEnvAsInt("TIMELINE_MAX_LENGTH", 800)
+	timelineCacheTTL := time.Duration(getEnvAsInt("TIMELINE_CACHE_TTL", 3600)) * time.Second
+	// Cuentas con más seguidores que este umbral no se distribuyen al escribir (0 lo desactiva)
+	fanoutThreshold := getEnvAsInt("FANOUT_FOLLOWER_THRESHOLD", 10000)
+	// Workers que distribuyen los tweets de forma asíncrona (0 distribuye en línea, dentro de la request)
+	fanoutWorkers := getEnvAsInt("FANOUT_WORKERS", 4)
+	// Tiempo durante el que se puede descargar una exportación de datos
+	exportTTL := time.Duration(getEnvAsInt("EXPORT_TTL_HOURS", 72)) * time.Hour
+	// Contrapresión y rate limit de cada conexión del gateway WebSocket
+	gatewayConfig := api.GatewayConfig{
+		SendBuffer:        getEnvAsInt("WS_SEND_BUFFER", 64),
+		MessagesPerSecond: getEnvAsInt("WS_MAX_MESSAGES_PER_SECOND", 5),
+		Burst:             getEnvAsInt("WS_MESSAGE_BURST", 10),
+	}
+
+	// El header X-User-ID solo se acepta fuera de producción y si se habilita explícitamente
+	authConfig := middleware.AuthConfig{
+		SessionRepo:    sessionRepo,
+		APITokenRepo:   apiTokenRepo,
+		AllowDevHeader: os.Getenv("ENV") != "production" && os.Getenv("AUTH_DEV_HEADER") == "true",
+	}
+	if authConfig.AllowDevHeader {
+		log.Println("⚠️ AUTH_DEV_HEADER enabled: X-User-ID header is accepted without a token")
+	}
+
+	// Cola de fan-out: solo se usa si hay workers que la procesen
+	var fanoutQueue repository.FanoutQueueRepository
+	if fanoutWorkers > 0 {
+		fanoutQueue = redis.NewFanoutQueueRepository(dbConfig.Redis)
+	}
+
+	// Archivos de las exportaciones de datos, en disco local
+	exportDir := os.Getenv("EXPORT_DIR")
+	if exportDir == "" {
+		exportDir = "./exports"
+	}
+	exportStorage, err := disk.NewExportStorage(exportDir)
+	if err != nil {
+		log.Fatal("Failed to initialize export storage:", err)
+	}
+
+	// Timelines cacheados, que solo guardan IDs, y caché de tweets con la que se hidratan
+	timelineRepo := redis.NewTimelineRepository(dbConfig.Redis, timelineMaxLength, timelineCacheTTL)
+	listTimelineRepo := redis.NewListTimelineRepository(dbConfig.Redis, timelineMaxLength, timelineCacheTTL)
+	tweetCache := redis.NewTweetCacheRepository(dbConfig.Redis, tweetCacheTTL)
+	eventRepo := redis.NewEventRepository(dbConfig.Redis)
+	trendRepo := redis.NewTrendRepository(dbConfig.Redis)
 
 	// Inicializar servicios
-	userService := service.NewUserService(userRepo)
-	tweetService := service.NewTweetService(tweetRepo, userRepo, timelineRepo, followRepo, maxTweetLength)
-	followService := service.NewFollowService(followRepo, userRepo, timelineRepo, tweetRepo)
-	timelineService := service.NewTimelineService(timelineRepo, tweetRepo, userRepo, followRepo)
+	fanoutService := service.NewFanoutService(fanoutQueue, tweetRepo, followRepo, listRepo, timelineRepo, listTimelineRepo, eventRepo, fanoutThreshold)
+	userService := service.NewUserService(userRepo, tweetRepo, tweetCache)
+	authService := service.NewAuthService(userRepo, sessionRepo, accessTokenTTL, refreshTokenTTL)
+	apiTokenService := service.NewAPITokenService(apiTokenRepo)
+	tweetService := service.NewTweetService(tweetRepo, userRepo, timelineRepo, followRepo, likeRepo, bookmarkRepo, tweetCache, eventRepo, trendRepo, blockRepo, fanoutService, maxTweetLength, tweetEditWindow)
+	followService := service.NewFollowService(followRepo, userRepo, timelineRepo, tweetRepo, eventRepo, blockRepo)
+	blockService := service.NewBlockService(blockRepo, followRepo, userRepo, timelineRepo, tweetRepo, bookmarkRepo)
+	muteService := service.NewMuteService(muteRepo, userRepo)
+	listService := service.NewListService(listRepo, listTimelineRepo, tweetRepo, userRepo, followRepo, likeRepo, bookmarkRepo, tweetCache, blockRepo, muteRepo)
+	timelineService := service.NewTimelineService(timelineRepo, tweetRepo, userRepo, followRepo, likeRepo, bookmarkRepo, tweetCache, eventRepo, blockRepo, muteRepo, fanoutThreshold)
+	likeService := service.NewLikeService(likeRepo, tweetRepo, userRepo, followRepo, blockRepo, eventRepo)
+	bookmarkService := service.NewBookmarkService(bookmarkRepo, tweetRepo, userRepo, followRepo, likeRepo, tweetCache, blockRepo)
+	hashtagService := service.NewHashtagService(tweetRepo, likeRepo, bookmarkRepo, trendRepo)
+	searchService := service.NewSearchService(searchRepo, userRepo, likeRepo, bookmarkRepo)
+	accountService := service.NewAccountService(userRepo, tweetRepo, followRepo, likeRepo, timelineRepo, tweetCache, dataExportRepo, exportStorage, exportTTL)
+	eventService := service.NewEventService(eventRepo, tweetRepo, followRepo, likeRepo, bookmarkRepo, tweetCache, blockRepo, muteRepo, fanoutThreshold)
 
 	// Inicializar handlers
-	userHandler := api.NewUserHandler(userService)
-	tweetHandler := api.NewTweetHandler(tweetService)
-	followHandler := api.NewFollowHandler(followService)
-	timelineHandler := api.NewTimelineHandler(timelineService)
+	handlers := routeHandlers{
+		user:     api.NewUserHandler(userService),
+		auth:     api.NewAuthHandler(authService),
+		apiToken: api.NewAPITokenHandler(apiTokenService),
+		tweet:    api.NewTweetHandler(tweetService),
+		follow:   api.NewFollowHandler(followService),
+		block:    api.NewBlockHandler(blockService),
+		mute:     api.NewMuteHandler(muteService),
+		list:     api.NewListHandler(listService),
+		timeline: api.NewTimelineHandler(timelineService),
+		like:     api.NewLikeHandler(likeService),
+		bookmark: api.NewBookmarkHandler(bookmarkService),
+		hashtag:  api.NewHashtagHandler(hashtagService),
+		search:   api.NewSearchHandler(searchService),
+		gateway:  api.NewGatewayHandler(eventService, gatewayConfig),
+		account:  api.NewAccountHandler(accountService),
+	}
 
 	// Pre-cargar todos los timelines al iniciar - Esto solo se hace para pruebas.
 	// En un entorno de producción, la reconstrucción o precarga del timeline se
@@ -66,11 +156,35 @@ func main() {
 		log.Printf("Warning: Error preloading timelines: %v", err)
 	}
 
+	// Iniciar el pool de workers de fan-out en segundo plano
+	if fanoutQueue != nil {
+		hostname, _ := os.Hostname()
+		fanoutWorker := worker.NewFanoutWorker(fanoutQueue, fanoutService, worker.FanoutWorkerConfig{
+			Concurrency:  fanoutWorkers,
+			MaxAttempts:  getEnvAsInt("FANOUT_MAX_ATTEMPTS", 5),
+			BaseBackoff:  time.Duration(getEnvAsInt("FANOUT_RETRY_BACKOFF_SECONDS", 2)) * time.Second,
+			ConsumerName: hostname,
+		})
+		go func() {
+			if err := fanoutWorker.Run(ctx); err != nil {
+				log.Printf("Warning: fan-out worker stopped: %v", err)
+			}
+		}()
+	}
+
+	// Iniciar la generación de exportaciones y el borrado de cuentas vencidas en segundo plano
+	accountWorker := worker.NewAccountWorker(accountService, worker.AccountWorkerConfig{
+		GracePeriod:        time.Duration(getEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour,
+		PurgeInterval:      time.Duration(getEnvAsInt("ACCOUNT_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+		ExportPollInterval: time.Duration(getEnvAsInt("EXPORT_POLL_SECONDS", 5)) * time.Second,
+	})
+	go accountWorker.Run(ctx)
+
 	// Crear router
 	r := gin.Default()
 
 	// Configurar rutas
-	setupRoutes(r, userHandler, tweetHandler, followHandler, userRepo, timelineHandler, dbConfig)
+	setupRoutes(r, handlers, userRepo, authConfig, dbConfig)
 
 	// Obtener puerto
 	port := os.Getenv("PORT")
@@ -85,52 +199,184 @@ func main() {
 	}
 }
 
-func setupRoutes(r *gin.Engine, userHandler *api.UserHandler, tweetHandler *api.TweetHandler, followHandler *api.FollowHandler, userRepo repository.UserRepository, timelineHandler *api.TimelineHandler, dbConfig *config.DatabaseConfig) {
+// routeHandlers agrupa los handlers HTTP que se registran en el router
+type routeHandlers struct {
+	user     *api.UserHandler
+	auth     *api.AuthHandler
+	apiToken *api.APITokenHandler
+	tweet    *api.TweetHandler
+	follow   *api.FollowHandler
+	block    *api.BlockHandler
+	mute     *api.MuteHandler
+	list     *api.ListHandler
+	timeline *api.TimelineHandler
+	like     *api.LikeHandler
+	bookmark *api.BookmarkHandler
+	hashtag  *api.HashtagHandler
+	search   *api.SearchHandler
+	gateway  *api.GatewayHandler
+	account  *api.AccountHandler
+}
+
+func setupRoutes(r *gin.Engine, h routeHandlers, userRepo repository.UserRepository, authConfig middleware.AuthConfig, dbConfig *config.DatabaseConfig) {
 	// Middleware de autenticación con validación de usuario (para rutas protegidas)
-	authWithValidationMiddleware := middleware.AuthWithUserValidationMiddleware(userRepo)
+	authWithValidationMiddleware := middleware.AuthWithUserValidationMiddleware(authConfig, userRepo)
+	// Middleware de autenticación opcional (para rutas públicas que personalizan la respuesta)
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(authConfig)
 
 	// Grupo de rutas API
 	api := r.Group("/api")
 	{
+		// Rutas de autenticación (registro, login y renovación son públicas)
+		auth := api.Group("/auth")
+		{
+			auth.POST("/register", h.auth.Register)
+			auth.POST("/login", h.auth.Login)
+			auth.POST("/refresh", h.auth.Refresh)
+			auth.POST("/logout", authWithValidationMiddleware, middleware.RequireSession(), h.auth.Logout)
+		}
+
+		// Rutas de tokens de API personales (solo con una sesión de usuario, no con otro token de API)
+		tokens := api.Group("/tokens")
+		tokens.Use(authWithValidationMiddleware, middleware.RequireSession())
+		{
+			tokens.POST("", h.apiToken.CreateToken)
+			tokens.GET("", h.apiToken.ListTokens)
+			tokens.DELETE("/:id", h.apiToken.RevokeToken)
+		}
+
 		// Rutas de usuarios (no requieren autenticación para lectura)
 		users := api.Group("/users")
 		{
-			users.POST("", userHandler.CreateUser)
-			users.GET("/:id", userHandler.GetUser)
-			users.GET("/:id/stats", userHandler.GetUserStats)
-			users.GET("/:id/tweets", tweetHandler.GetUserTweets)
+			users.POST("", h.user.CreateUser)
+			users.GET("", h.user.GetUsers)
+			users.GET("/by-username/:username", h.user.GetUserByUsername)
+			users.GET("/me", authWithValidationMiddleware, middleware.RequireSession(), h.user.GetMe)
+			users.PATCH("/me", authWithValidationMiddleware, middleware.RequireSession(), h.user.UpdateMe)
+			users.DELETE("/me", authWithValidationMiddleware, middleware.RequireSession(), h.account.DeleteMe)
+			users.POST("/me/export", authWithValidationMiddleware, middleware.RequireSession(), h.account.RequestExport)
+			users.GET("/me/exports/:id", authWithValidationMiddleware, middleware.RequireSession(), h.account.GetExport)
+			users.GET("/me/exports/:id/download", authWithValidationMiddleware, middleware.RequireSession(), h.account.DownloadExport)
+			users.GET("/:id", h.user.GetUser)
+			users.GET("/:id/stats", h.user.GetUserStats)
+			users.GET("/:id/tweets", optionalAuthMiddleware, h.tweet.GetUserTweets)
 		}
 
 		// Rutas de tweets (requieren autenticación con validación de usuario)
 		tweets := api.Group("/tweets")
 		tweets.Use(authWithValidationMiddleware)
+
+		// Lectura de tweets (tokens de API con scope tweet:read)
+		tweetsRead := tweets.Group("", middleware.RequireScopes(model.ScopeTweetRead))
+		{
+			tweetsRead.GET("/:id", h.tweet.GetTweet)
+			tweetsRead.GET("/:id/history", h.tweet.GetTweetHistory)
+			tweetsRead.GET("/:id/thread", h.tweet.GetThread)
+			tweetsRead.GET("/:id/likes", h.like.GetLikers)
+		}
+
+		// Escritura de tweets (tokens de API con scope tweet:write)
+		tweetsWrite := tweets.Group("", middleware.RequireScopes(model.ScopeTweetWrite))
 		{
-			tweets.POST("", tweetHandler.CreateTweet)
-			tweets.GET("/:id", tweetHandler.GetTweet)
+			tweetsWrite.POST("", h.tweet.CreateTweet)
+			tweetsWrite.PATCH("/:id", h.tweet.UpdateTweet)
+			tweetsWrite.DELETE("/:id", h.tweet.DeleteTweet)
+			tweetsWrite.POST("/:id/retweet", h.tweet.Retweet)
+			tweetsWrite.DELETE("/:id/retweet", h.tweet.Unretweet)
+			tweetsWrite.POST("/:id/quote", h.tweet.QuoteTweet)
+			tweetsWrite.POST("/:id/like", h.like.LikeTweet)
+			tweetsWrite.DELETE("/:id/like", h.like.UnlikeTweet)
+			tweetsWrite.POST("/:id/bookmark", h.bookmark.BookmarkTweet)
+			tweetsWrite.DELETE("/:id/bookmark", h.bookmark.RemoveBookmark)
 		}
 
-		// Rutas de follow (requieren autenticación con validación de usuario)
+		// Rutas de follow (requieren autenticación con validación de usuario y scope follow:write)
 		follows := api.Group("/follow")
-		follows.Use(authWithValidationMiddleware)
+		follows.Use(authWithValidationMiddleware, middleware.RequireScopes(model.ScopeFollowWrite))
+		{
+			follows.POST("/:user_id", h.follow.FollowUser)
+			follows.DELETE("/:user_id", h.follow.UnfollowUser)
+			follows.GET("/requests", h.follow.GetFollowRequests)
+			follows.POST("/requests/:user_id/approve", h.follow.ApproveFollowRequest)
+			follows.POST("/requests/:user_id/reject", h.follow.RejectFollowRequest)
+		}
+
+		// Rutas de bloqueos (requieren autenticación con validación de usuario y scope follow:write)
+		blocks := api.Group("/blocks")
+		blocks.Use(authWithValidationMiddleware, middleware.RequireScopes(model.ScopeFollowWrite))
+		{
+			blocks.POST("/:user_id", h.block.BlockUser)
+			blocks.DELETE("/:user_id", h.block.UnblockUser)
+		}
+
+		// Rutas de cuentas y palabras silenciadas (solo con una sesión de usuario)
+		mutes := api.Group("/mutes")
+		mutes.Use(authWithValidationMiddleware, middleware.RequireSession())
 		{
-			follows.POST("/:user_id", followHandler.FollowUser)
-			follows.DELETE("/:user_id", followHandler.UnfollowUser)
+			mutes.GET("/users", h.mute.GetMutedUsers)
+			mutes.POST("/users", h.mute.MuteUser)
+			mutes.DELETE("/users/:user_id", h.mute.UnmuteUser)
+			mutes.GET("/words", h.mute.GetMutedWords)
+			mutes.POST("/words", h.mute.MuteWord)
+			mutes.DELETE("/words/:id", h.mute.UnmuteWord)
 		}
 
-		// Rutas de usuarios para follows (no requieren autenticación para lectura)
+		// Rutas de listas (la lectura es pública; la autenticación opcional permite ver las listas
+		// privadas propias y filtrar el timeline de la lista para quien la lee)
+		lists := api.Group("/lists")
+		{
+			lists.GET("/subscriptions", authWithValidationMiddleware, middleware.RequireScopes(model.ScopeTimelineRead), h.list.GetSubscribedLists)
+			lists.GET("/:id", optionalAuthMiddleware, h.list.GetList)
+			lists.GET("/:id/members", optionalAuthMiddleware, h.list.GetMembers)
+			lists.GET("/:id/timeline", optionalAuthMiddleware, h.list.GetListTimeline)
+		}
+
+		// Escritura de listas, miembros y suscripciones (tokens de API con scope follow:write)
+		listsWrite := lists.Group("", authWithValidationMiddleware, middleware.RequireScopes(model.ScopeFollowWrite))
+		{
+			listsWrite.POST("", h.list.CreateList)
+			listsWrite.PATCH("/:id", h.list.UpdateList)
+			listsWrite.DELETE("/:id", h.list.DeleteList)
+			listsWrite.POST("/:id/members", h.list.AddMember)
+			listsWrite.DELETE("/:id/members/:user_id", h.list.RemoveMember)
+			listsWrite.POST("/:id/subscribers", h.list.Subscribe)
+			listsWrite.DELETE("/:id/subscribers", h.list.Unsubscribe)
+		}
+
+		// Rutas de usuarios para follows y listas (no requieren autenticación para lectura)
 		usersFollow := api.Group("/users")
 		{
-			usersFollow.GET("/:id/followers", followHandler.GetFollowers)
-			usersFollow.GET("/:id/following", followHandler.GetFollowing)
+			usersFollow.GET("/:id/followers", h.follow.GetFollowers)
+			usersFollow.GET("/:id/following", h.follow.GetFollowing)
+			usersFollow.GET("/:id/lists", optionalAuthMiddleware, h.list.GetUserLists)
 		}
 
 		// Rutas de timeline (requieren autenticación)
 		timeline := api.Group("/timeline")
-		timeline.Use(authWithValidationMiddleware)
+		timeline.Use(authWithValidationMiddleware, middleware.RequireScopes(model.ScopeTimelineRead))
 		{
-			timeline.GET("", timelineHandler.GetTimeline)
-			timeline.POST("/refresh", timelineHandler.RefreshTimeline)
+			timeline.GET("", h.timeline.GetTimeline)
+			timeline.GET("/mentions", h.timeline.GetMentions)
+			timeline.GET("/stream", h.timeline.StreamTimeline)
+			timeline.POST("/refresh", h.timeline.RefreshTimeline)
 		}
+
+		// Tweets guardados del usuario (privados; tokens de API con scope tweet:read)
+		api.GET("/bookmarks", authWithValidationMiddleware, middleware.RequireScopes(model.ScopeTweetRead), h.bookmark.GetBookmarks)
+
+		// Rutas de hashtags y tendencias (públicas; la autenticación opcional se usa para calcular liked_by_me)
+		api.GET("/hashtags/:tag/tweets", optionalAuthMiddleware, h.hashtag.GetHashtagTweets)
+		api.GET("/trends", h.hashtag.GetTrends)
+
+		// Rutas de búsqueda (públicas; en tweets la autenticación opcional se usa para calcular liked_by_me)
+		search := api.Group("/search")
+		{
+			search.GET("/tweets", optionalAuthMiddleware, h.search.SearchTweets)
+			search.GET("/users", h.search.SearchUsers)
+		}
+
+		// Gateway WebSocket con los eventos en tiempo real del usuario (requiere autenticación)
+		api.GET("/ws", authWithValidationMiddleware, middleware.RequireScopes(model.ScopeTimelineRead), h.gateway.Connect)
 	}
 
 	// Health check
@@ -238,6 +484,7 @@ func setupRoutes(r *gin.Engine, userHandler *api.UserHandler, tweetHandler *api.
 			"version": "1.0.0",
 			"endpoints": gin.H{
 				"health":   "/health",
+				"auth":     "/api/auth",
 				"tweets":   "/api/tweets",
 				"follows":  "/api/follow",
 				"timeline": "/api/timeline",
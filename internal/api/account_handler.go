@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	accountService service.AccountService
+}
+
+// NewAccountHandler crea una nueva instancia del handler de baja de cuentas y exportación de datos
+func NewAccountHandler(accountService service.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+// DeleteMe maneja la baja de la cuenta del usuario autenticado. La cuenta deja de ser visible de
+// inmediato y se borra definitivamente al vencer el período de gracia.
+func (h *AccountHandler) DeleteMe(c *gin.Context) {
+	err := h.accountService.DeleteAccount(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Account scheduled for deletion",
+	})
+}
+
+// RequestExport maneja el pedido de exportación de los datos del usuario autenticado
+func (h *AccountHandler) RequestExport(c *gin.Context) {
+	export, err := h.accountService.RequestExport(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrExportInProgress) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"export": export,
+	})
+}
+
+// GetExport maneja la consulta del estado de una exportación del usuario autenticado
+func (h *AccountHandler) GetExport(c *gin.Context) {
+	exportID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid export ID format",
+		})
+		return
+	}
+
+	export, err := h.accountService.GetExport(c.Request.Context(), middleware.GetUserID(c), exportID)
+	if err != nil {
+		c.JSON(exportErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"export": export,
+	})
+}
+
+// DownloadExport maneja la descarga del archivo de una exportación lista
+func (h *AccountHandler) DownloadExport(c *gin.Context) {
+	exportID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid export ID format",
+		})
+		return
+	}
+
+	path, fileName, err := h.accountService.GetExportFile(c.Request.Context(), middleware.GetUserID(c), exportID)
+	if err != nil {
+		c.JSON(exportErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.FileAttachment(path, fileName)
+}
+
+// exportErrorStatus traduce los errores de las exportaciones a códigos HTTP
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrExportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrExportNotReady):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
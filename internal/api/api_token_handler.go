@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/model"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APITokenHandler struct {
+	apiTokenService service.APITokenService
+}
+
+// NewAPITokenHandler crea una nueva instancia del handler de tokens de API
+func NewAPITokenHandler(apiTokenService service.APITokenService) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+	}
+}
+
+// CreateToken maneja la creación de un token de API. El token en claro solo se devuelve en esta respuesta.
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	var req model.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.apiTokenService.CreateToken(c.Request.Context(), userID, req.Name, req.Scopes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListTokens maneja el listado de los tokens de API del usuario autenticado
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	apiTokens, err := h.apiTokenService.ListTokens(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": apiTokens,
+		"count":  len(apiTokens),
+	})
+}
+
+// RevokeToken maneja la revocación de un token de API del usuario autenticado
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tokenIDStr := c.Param("id")
+	tokenID, err := strconv.ParseInt(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid token ID format",
+		})
+		return
+	}
+
+	err = h.apiTokenService.RevokeToken(c.Request.Context(), userID, tokenID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API token revoked successfully",
+	})
+}
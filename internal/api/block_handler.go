@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlockHandler struct {
+	blockService service.BlockService
+}
+
+// NewBlockHandler crea una nueva instancia del handler de bloqueos
+func NewBlockHandler(blockService service.BlockService) *BlockHandler {
+	return &BlockHandler{
+		blockService: blockService,
+	}
+}
+
+// BlockUser maneja el bloqueo de un usuario, que además elimina los follows entre ambos
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	blockedID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.blockService.BlockUser(c.Request.Context(), middleware.GetUserID(c), blockedID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrAlreadyBlocked) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully blocked user",
+	})
+}
+
+// UnblockUser maneja el desbloqueo de un usuario
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	blockedID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.blockService.UnblockUser(c.Request.Context(), middleware.GetUserID(c), blockedID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully unblocked user",
+	})
+}
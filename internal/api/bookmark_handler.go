@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkHandler struct {
+	bookmarkService service.BookmarkService
+}
+
+// NewBookmarkHandler crea una nueva instancia del handler de tweets guardados
+func NewBookmarkHandler(bookmarkService service.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkService: bookmarkService,
+	}
+}
+
+// BookmarkTweet maneja el guardado de un tweet
+func (h *BookmarkHandler) BookmarkTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.bookmarkService.BookmarkTweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tweet bookmarked successfully",
+	})
+}
+
+// RemoveBookmark maneja la eliminación de un tweet de los guardados
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.bookmarkService.RemoveBookmark(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		c.JSON(bookmarkErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bookmark removed successfully",
+	})
+}
+
+// GetBookmarks maneja la obtención de los tweets guardados del usuario autenticado, paginados por cursor
+func (h *BookmarkHandler) GetBookmarks(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	tweets, err := h.bookmarkService.GetBookmarks(c.Request.Context(), userID, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// bookmarkErrorStatus traduce los errores del servicio de guardados a códigos HTTP. Como en el
+// resto de las rutas de tweets, un tweet inexistente responde 404.
+func bookmarkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAlreadyBookmarked):
+		return http.StatusConflict
+	case isVisibilityError(err):
+		return http.StatusForbidden
+	default:
+		return http.StatusNotFound
+	}
+}
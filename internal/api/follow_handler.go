@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"microx/internal/middleware"
+	"microx/internal/model"
 	"microx/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -21,7 +23,8 @@ func NewFollowHandler(followService service.FollowService) *FollowHandler {
 	}
 }
 
-// FollowUser maneja la acción de seguir a un usuario
+// FollowUser maneja la acción de seguir a un usuario. Si la cuenta es protegida queda un pedido
+// pendiente de aprobación y se responde 202.
 func (h *FollowHandler) FollowUser(c *gin.Context) {
 	followerID := middleware.GetUserID(c)
 
@@ -34,16 +37,29 @@ func (h *FollowHandler) FollowUser(c *gin.Context) {
 		return
 	}
 
-	err = h.followService.FollowUser(c.Request.Context(), followerID, followingID)
+	status, err := h.followService.FollowUser(c.Request.Context(), followerID, followingID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		code := http.StatusBadRequest
+		if errors.Is(err, service.ErrBlocked) {
+			code = http.StatusForbidden
+		}
+		c.JSON(code, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
+	if status == model.FollowStatusPending {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Follow request sent",
+			"status":  status,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Successfully followed user",
+		"status":  status,
 	})
 }
 
@@ -85,22 +101,12 @@ func (h *FollowHandler) GetFollowers(c *gin.Context) {
 	}
 
 	// Obtener parámetros de paginación
-	limit := 20 // Default limit
-	offset := 0 // Default offset
-
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
-	}
-
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
 	}
 
-	followers, err := h.followService.GetFollowers(c.Request.Context(), userID, limit, offset)
+	followers, err := h.followService.GetFollowers(c.Request.Context(), userID, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -109,10 +115,11 @@ func (h *FollowHandler) GetFollowers(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"followers": followers,
-		"count":     len(followers),
-		"limit":     limit,
-		"offset":    offset,
+		"followers":    followers.Users,
+		"count":        len(followers.Users),
+		"limit":        page.Limit,
+		"next_cursor":  followers.NextCursor.Encode(),
+		"since_cursor": followers.SinceCursor.Encode(),
 	})
 }
 
@@ -128,22 +135,36 @@ func (h *FollowHandler) GetFollowing(c *gin.Context) {
 	}
 
 	// Obtener parámetros de paginación
-	limit := 20 // Default limit
-	offset := 0 // Default offset
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
 
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	following, err := h.followService.GetFollowing(c.Request.Context(), userID, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
 
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	c.JSON(http.StatusOK, gin.H{
+		"following":    following.Users,
+		"count":        len(following.Users),
+		"limit":        page.Limit,
+		"next_cursor":  following.NextCursor.Encode(),
+		"since_cursor": following.SinceCursor.Encode(),
+	})
+}
+
+// GetFollowRequests maneja la obtención de los pedidos de follow pendientes del usuario autenticado
+func (h *FollowHandler) GetFollowRequests(c *gin.Context) {
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
 	}
 
-	following, err := h.followService.GetFollowing(c.Request.Context(), userID, limit, offset)
+	requests, err := h.followService.GetFollowRequests(c.Request.Context(), middleware.GetUserID(c), page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -152,9 +173,56 @@ func (h *FollowHandler) GetFollowing(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"following": following,
-		"count":     len(following),
-		"limit":     limit,
-		"offset":    offset,
+		"requests":     requests.Users,
+		"count":        len(requests.Users),
+		"limit":        page.Limit,
+		"next_cursor":  requests.NextCursor.Encode(),
+		"since_cursor": requests.SinceCursor.Encode(),
+	})
+}
+
+// ApproveFollowRequest maneja la aprobación de un pedido de follow
+func (h *FollowHandler) ApproveFollowRequest(c *gin.Context) {
+	requesterID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.followService.ApproveFollowRequest(c.Request.Context(), middleware.GetUserID(c), requesterID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Follow request approved",
+	})
+}
+
+// RejectFollowRequest maneja el rechazo de un pedido de follow
+func (h *FollowHandler) RejectFollowRequest(c *gin.Context) {
+	requesterID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.followService.RejectFollowRequest(c.Request.Context(), middleware.GetUserID(c), requesterID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Follow request rejected",
 	})
 }
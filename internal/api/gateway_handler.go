@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"microx/internal/middleware"
+	"microx/internal/model"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// gatewayWriteTimeout es cuánto puede tardar un mensaje en escribirse antes de dar la conexión por muerta
+const gatewayWriteTimeout = 10 * time.Second
+
+// gatewayMaxMessageBytes limita el tamaño de los comandos que envía el cliente
+const gatewayMaxMessageBytes = 4096
+
+// gatewayMaxRateViolations es cuántos comandos por encima del límite se toleran antes de cerrar la conexión
+const gatewayMaxRateViolations = 10
+
+// Acciones que puede enviar el cliente por el WebSocket
+const (
+	gatewayActionSubscribe   = "subscribe"
+	gatewayActionUnsubscribe = "unsubscribe"
+	gatewayActionPing        = "ping"
+)
+
+// Tipos de mensajes que envía el servidor por el WebSocket
+const (
+	gatewayMessageSubscribed   = "subscribed"
+	gatewayMessageUnsubscribed = "unsubscribed"
+	gatewayMessageEvent        = "event"
+	gatewayMessageDropped      = "dropped"
+	gatewayMessagePong         = "pong"
+	gatewayMessageHeartbeat    = "heartbeat"
+	gatewayMessageError        = "error"
+)
+
+// GatewayConfig controla la contrapresión y el rate limit de cada conexión WebSocket
+type GatewayConfig struct {
+	// SendBuffer es cuántos mensajes pueden quedar pendientes de envío; si el cliente no los
+	// consume a tiempo, los eventos siguientes se descartan y se le avisa cuántos perdió
+	SendBuffer int
+	// MessagesPerSecond y Burst limitan los comandos que puede enviar el cliente (0 no limita)
+	MessagesPerSecond int
+	Burst             int
+}
+
+// gatewayCommand es un comando enviado por el cliente
+type gatewayCommand struct {
+	Action  string `json:"action"`
+	Channel string `json:"channel"`
+}
+
+// gatewayMessage es un mensaje enviado al cliente
+type gatewayMessage struct {
+	Type    string              `json:"type"`
+	Channel string              `json:"channel,omitempty"`
+	Event   *model.EventMessage `json:"event,omitempty"`
+	Count   int                 `json:"count,omitempty"`
+	Error   string              `json:"error,omitempty"`
+}
+
+type GatewayHandler struct {
+	eventService service.EventService
+	config       GatewayConfig
+}
+
+// NewGatewayHandler crea una nueva instancia del handler del gateway WebSocket
+func NewGatewayHandler(eventService service.EventService, config GatewayConfig) *GatewayHandler {
+	if config.SendBuffer <= 0 {
+		config.SendBuffer = 64
+	}
+	if config.Burst < config.MessagesPerSecond {
+		config.Burst = config.MessagesPerSecond
+	}
+
+	return &GatewayHandler{
+		eventService: eventService,
+		config:       config,
+	}
+}
+
+// Connect abre un WebSocket que multiplexa los canales de eventos del usuario (timeline, menciones,
+// notificaciones y follows). El cliente elige qué canales recibe con comandos subscribe/unsubscribe.
+func (h *GatewayHandler) Connect(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	// Suscribirse antes del upgrade para poder responder el error como HTTP
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := h.eventService.Subscribe(ctx, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrStreamUnavailable) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// websocket.Server sin Handshake no exige el header Origin, que los clientes móviles no envían;
+	// la autenticación ya la resolvió el middleware
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		ws.MaxPayloadBytes = gatewayMaxMessageBytes
+		conn := &gatewayConn{
+			ws:       ws,
+			send:     make(chan *gatewayMessage, h.config.SendBuffer),
+			channels: make(map[string]bool),
+			limiter:  newTokenBucket(h.config.MessagesPerSecond, h.config.Burst),
+		}
+		conn.run(ctx, cancel, events)
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// gatewayConn es una conexión WebSocket abierta
+type gatewayConn struct {
+	ws      *websocket.Conn
+	send    chan *gatewayMessage
+	limiter *tokenBucket
+
+	mu       sync.Mutex
+	channels map[string]bool
+	// dropped cuenta los eventos descartados desde el último aviso al cliente
+	dropped int
+}
+
+// run atiende la conexión hasta que el cliente la cierra o se corta la suscripción a eventos
+func (c *gatewayConn) run(ctx context.Context, cancel context.CancelFunc, events <-chan *model.EventMessage) {
+	defer c.ws.Close()
+
+	go c.writeLoop(ctx, cancel)
+	go c.dispatch(ctx, cancel, events)
+
+	// Cerrar el socket desbloquea al lector cuando la conexión termina por otro motivo
+	go func() {
+		<-ctx.Done()
+		c.ws.Close()
+	}()
+
+	c.readLoop(ctx)
+	cancel()
+}
+
+// readLoop procesa los comandos del cliente
+func (c *gatewayConn) readLoop(ctx context.Context) {
+	violations := 0
+	for {
+		var command gatewayCommand
+		err := websocket.JSON.Receive(c.ws, &command)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		invalid := errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+		if err != nil && !invalid {
+			return // Conexión cerrada o mensaje demasiado grande
+		}
+
+		// Los mensajes mal formados también cuentan para el rate limit
+		if !c.limiter.Allow() {
+			violations++
+			if violations >= gatewayMaxRateViolations {
+				return
+			}
+			c.reply(ctx, &gatewayMessage{Type: gatewayMessageError, Error: "rate limit exceeded"})
+			continue
+		}
+
+		if invalid {
+			c.reply(ctx, &gatewayMessage{Type: gatewayMessageError, Error: "invalid message"})
+			continue
+		}
+
+		c.handle(ctx, &command)
+	}
+}
+
+// handle ejecuta un comando del cliente
+func (c *gatewayConn) handle(ctx context.Context, command *gatewayCommand) {
+	switch command.Action {
+	case gatewayActionPing:
+		c.reply(ctx, &gatewayMessage{Type: gatewayMessagePong})
+	case gatewayActionSubscribe, gatewayActionUnsubscribe:
+		if !model.IsValidEventChannel(command.Channel) {
+			c.reply(ctx, &gatewayMessage{Type: gatewayMessageError, Channel: command.Channel, Error: "unknown channel"})
+			return
+		}
+
+		subscribe := command.Action == gatewayActionSubscribe
+		c.mu.Lock()
+		if subscribe {
+			c.channels[command.Channel] = true
+		} else {
+			delete(c.channels, command.Channel)
+		}
+		c.mu.Unlock()
+
+		messageType := gatewayMessageSubscribed
+		if !subscribe {
+			messageType = gatewayMessageUnsubscribed
+		}
+		c.reply(ctx, &gatewayMessage{Type: messageType, Channel: command.Channel})
+	default:
+		c.reply(ctx, &gatewayMessage{Type: gatewayMessageError, Error: "unknown action"})
+	}
+}
+
+// reply encola la respuesta a un comando. Como los comandos tienen rate limit, se espera a que
+// haya lugar en el buffer en vez de descartarla.
+func (c *gatewayConn) reply(ctx context.Context, message *gatewayMessage) {
+	select {
+	case c.send <- message:
+	case <-ctx.Done():
+	}
+}
+
+// dispatch encola los eventos de los canales suscritos. Si el cliente no consume a tiempo y el
+// buffer está lleno, el evento se descarta en vez de bloquear la suscripción.
+func (c *gatewayConn) dispatch(ctx context.Context, cancel context.CancelFunc, events <-chan *model.EventMessage) {
+	defer cancel() // Sin suscripción no hay nada más que enviar
+
+	for event := range events {
+		c.mu.Lock()
+		subscribed := c.channels[event.Channel]
+		c.mu.Unlock()
+		if !subscribed {
+			continue
+		}
+
+		select {
+		case c.send <- &gatewayMessage{Type: gatewayMessageEvent, Channel: event.Channel, Event: event}:
+		case <-ctx.Done():
+			return
+		default:
+			c.mu.Lock()
+			c.dropped++
+			c.mu.Unlock()
+		}
+	}
+}
+
+// writeLoop escribe los mensajes encolados, avisando antes de cada uno si se descartaron eventos
+func (c *gatewayConn) writeLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var message *gatewayMessage
+		select {
+		case <-ctx.Done():
+			return
+		case message = <-c.send:
+		case <-heartbeat.C:
+			message = &gatewayMessage{Type: gatewayMessageHeartbeat}
+		}
+
+		c.mu.Lock()
+		dropped := c.dropped
+		c.dropped = 0
+		c.mu.Unlock()
+
+		if dropped > 0 {
+			if !c.write(&gatewayMessage{Type: gatewayMessageDropped, Count: dropped}) {
+				return
+			}
+		}
+		if !c.write(message) {
+			return
+		}
+	}
+}
+
+// write envía un mensaje al cliente; devuelve false si la conexión ya no sirve
+func (c *gatewayConn) write(message *gatewayMessage) bool {
+	c.ws.SetWriteDeadline(time.Now().Add(gatewayWriteTimeout))
+	return websocket.JSON.Send(c.ws, message) == nil
+}
+
+// tokenBucket limita los comandos por segundo de una conexión. Solo lo usa el lector de la
+// conexión, así que no necesita sincronización.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perSecond, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(perSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow consume un token si hay disponible
+func (b *tokenBucket) Allow() bool {
+	if b.rate <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/model"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultTrendsLimit = 10
+	maxTrendsLimit     = 50
+)
+
+type HashtagHandler struct {
+	hashtagService service.HashtagService
+}
+
+// NewHashtagHandler crea una nueva instancia del handler de hashtags
+func NewHashtagHandler(hashtagService service.HashtagService) *HashtagHandler {
+	return &HashtagHandler{
+		hashtagService: hashtagService,
+	}
+}
+
+// GetHashtagTweets maneja la obtención de los tweets que usan un hashtag
+func (h *HashtagHandler) GetHashtagTweets(c *gin.Context) {
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	// El usuario autenticado es opcional en esta ruta; se usa para calcular liked_by_me
+	viewerID := middleware.GetUserID(c)
+
+	tweets, err := h.hashtagService.GetHashtagTweets(c.Request.Context(), viewerID, c.Param("tag"), page)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidHashtag) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// GetTrends maneja la obtención de los hashtags en tendencia de una ventana (por defecto, la última hora)
+func (h *HashtagHandler) GetTrends(c *gin.Context) {
+	window := c.DefaultQuery("window", model.TrendWindowHour.Name)
+
+	limit := defaultTrendsLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxTrendsLimit {
+			limit = l
+		}
+	}
+
+	trends, err := h.hashtagService.GetTrends(c.Request.Context(), window, limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidTrendWindow) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"window": window,
+		"trends": trends,
+		"count":  len(trends),
+	})
+}
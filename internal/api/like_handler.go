@@ -0,0 +1,130 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LikeHandler struct {
+	likeService service.LikeService
+}
+
+// NewLikeHandler crea una nueva instancia del handler de likes
+func NewLikeHandler(likeService service.LikeService) *LikeHandler {
+	return &LikeHandler{
+		likeService: likeService,
+	}
+}
+
+// LikeTweet maneja la acción de dar like a un tweet
+func (h *LikeHandler) LikeTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.likeService.LikeTweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrAlreadyLiked) {
+			status = http.StatusConflict
+		} else if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tweet liked successfully",
+	})
+}
+
+// UnlikeTweet maneja la acción de quitar el like de un tweet
+func (h *LikeHandler) UnlikeTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.likeService.UnlikeTweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tweet unliked successfully",
+	})
+}
+
+// GetLikers maneja la obtención de los usuarios que dieron like a un tweet
+func (h *LikeHandler) GetLikers(c *gin.Context) {
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	// Obtener parámetros de paginación
+	limit := 20 // Default limit
+	offset := 0 // Default offset
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	viewerID := middleware.GetUserID(c)
+
+	users, err := h.likeService.GetLikers(c.Request.Context(), viewerID, tweetID, limit, offset)
+	if err != nil {
+		status := http.StatusNotFound
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":  users,
+		"count":  len(users),
+		"limit":  limit,
+		"offset": offset,
+	})
+}
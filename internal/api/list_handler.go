@@ -0,0 +1,338 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/model"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListHandler struct {
+	listService service.ListService
+}
+
+// NewListHandler crea una nueva instancia del handler de listas
+func NewListHandler(listService service.ListService) *ListHandler {
+	return &ListHandler{
+		listService: listService,
+	}
+}
+
+// CreateList maneja la creación de una lista del usuario autenticado
+func (h *ListHandler) CreateList(c *gin.Context) {
+	var req model.CreateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	list, err := h.listService.CreateList(c.Request.Context(), middleware.GetUserID(c), &req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"list": list,
+	})
+}
+
+// GetList maneja la obtención de una lista. La autenticación es opcional: las listas privadas solo
+// las ve su dueño.
+func (h *ListHandler) GetList(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.listService.GetList(c.Request.Context(), middleware.GetUserID(c), listID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"list": list,
+	})
+}
+
+// UpdateList maneja la edición del nombre, la descripción o la visibilidad de una lista
+func (h *ListHandler) UpdateList(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	list, err := h.listService.UpdateList(c.Request.Context(), middleware.GetUserID(c), listID, &req)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"list": list,
+	})
+}
+
+// DeleteList maneja la eliminación de una lista
+func (h *ListHandler) DeleteList(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	err := h.listService.DeleteList(c.Request.Context(), middleware.GetUserID(c), listID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "List deleted successfully",
+	})
+}
+
+// GetUserLists maneja la obtención de las listas de un usuario; las privadas solo las ve su dueño
+func (h *ListHandler) GetUserLists(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	lists, err := h.listService.GetUserLists(c.Request.Context(), middleware.GetUserID(c), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"lists": lists,
+		"count": len(lists),
+	})
+}
+
+// GetSubscribedLists maneja la obtención de las listas a las que está suscripto el usuario autenticado
+func (h *ListHandler) GetSubscribedLists(c *gin.Context) {
+	lists, err := h.listService.GetSubscribedLists(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"lists": lists,
+		"count": len(lists),
+	})
+}
+
+// AddMember maneja el agregado de una cuenta a una lista
+func (h *ListHandler) AddMember(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	var req model.ListMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	err := h.listService.AddMember(c.Request.Context(), middleware.GetUserID(c), listID, req.UserID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member added successfully",
+	})
+}
+
+// RemoveMember maneja la eliminación de una cuenta de una lista
+func (h *ListHandler) RemoveMember(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.listService.RemoveMember(c.Request.Context(), middleware.GetUserID(c), listID, userID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Member removed successfully",
+	})
+}
+
+// GetMembers maneja la obtención de los miembros de una lista, paginada por cursor
+func (h *ListHandler) GetMembers(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.listService.GetMembers(c.Request.Context(), middleware.GetUserID(c), listID, page)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"members":      members.Users,
+		"count":        len(members.Users),
+		"limit":        page.Limit,
+		"next_cursor":  members.NextCursor.Encode(),
+		"since_cursor": members.SinceCursor.Encode(),
+	})
+}
+
+// Subscribe maneja la suscripción del usuario autenticado a una lista pública
+func (h *ListHandler) Subscribe(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	err := h.listService.Subscribe(c.Request.Context(), middleware.GetUserID(c), listID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Subscribed to list successfully",
+	})
+}
+
+// Unsubscribe maneja la baja de la suscripción del usuario autenticado a una lista
+func (h *ListHandler) Unsubscribe(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	err := h.listService.Unsubscribe(c.Request.Context(), middleware.GetUserID(c), listID)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unsubscribed from list successfully",
+	})
+}
+
+// GetListTimeline maneja la obtención del timeline de una lista, paginado por cursor
+func (h *ListHandler) GetListTimeline(c *gin.Context) {
+	listID, ok := parseListID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	tweets, err := h.listService.GetListTimeline(c.Request.Context(), middleware.GetUserID(c), listID, page)
+	if err != nil {
+		c.JSON(listErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// parseListID obtiene el ID de la lista de la ruta. Si es inválido responde 400 y devuelve false.
+func parseListID(c *gin.Context) (int64, bool) {
+	listID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid list ID format",
+		})
+		return 0, false
+	}
+	return listID, true
+}
+
+// listErrorStatus traduce los errores del servicio de listas a códigos HTTP
+func listErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidList):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrNotListOwner), errors.Is(err, service.ErrBlocked):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrListNotFound), errors.Is(err, service.ErrListMemberNotFound), errors.Is(err, service.ErrNotSubscribed):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyListMember), errors.Is(err, service.ErrAlreadySubscribed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/model"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MuteHandler struct {
+	muteService service.MuteService
+}
+
+// NewMuteHandler crea una nueva instancia del handler de cuentas y palabras silenciadas
+func NewMuteHandler(muteService service.MuteService) *MuteHandler {
+	return &MuteHandler{
+		muteService: muteService,
+	}
+}
+
+// GetMutedUsers maneja la obtención de las cuentas silenciadas por el usuario autenticado
+func (h *MuteHandler) GetMutedUsers(c *gin.Context) {
+	mutes, err := h.muteService.GetMutedUsers(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"muted_users": mutes,
+		"count":       len(mutes),
+	})
+}
+
+// MuteUser maneja el silenciamiento de una cuenta, opcionalmente hasta expires_at
+func (h *MuteHandler) MuteUser(c *gin.Context) {
+	var req model.MuteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	mute, err := h.muteService.MuteUser(c.Request.Context(), middleware.GetUserID(c), req.UserID, req.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"muted_user": mute,
+	})
+}
+
+// UnmuteUser maneja el fin del silenciamiento de una cuenta
+func (h *MuteHandler) UnmuteUser(c *gin.Context) {
+	mutedUserID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID format",
+		})
+		return
+	}
+
+	err = h.muteService.UnmuteUser(c.Request.Context(), middleware.GetUserID(c), mutedUserID)
+	if err != nil {
+		c.JSON(muteErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully unmuted user",
+	})
+}
+
+// GetMutedWords maneja la obtención de las palabras silenciadas por el usuario autenticado
+func (h *MuteHandler) GetMutedWords(c *gin.Context) {
+	words, err := h.muteService.GetMutedWords(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"muted_words": words,
+		"count":       len(words),
+	})
+}
+
+// MuteWord maneja el silenciamiento de una palabra, frase o hashtag
+func (h *MuteHandler) MuteWord(c *gin.Context) {
+	var req model.MuteWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	word, err := h.muteService.MuteWord(c.Request.Context(), middleware.GetUserID(c), req.Phrase)
+	if err != nil {
+		c.JSON(muteErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"muted_word": word,
+	})
+}
+
+// UnmuteWord maneja la eliminación de una palabra silenciada
+func (h *MuteHandler) UnmuteWord(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid muted word ID format",
+		})
+		return
+	}
+
+	err = h.muteService.UnmuteWord(c.Request.Context(), middleware.GetUserID(c), wordID)
+	if err != nil {
+		c.JSON(muteErrorStatus(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully unmuted word",
+	})
+}
+
+// muteErrorStatus traduce los errores de los silenciados a códigos HTTP
+func muteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidMute):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrAlreadyMuted):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrMuteNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"microx/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parsePageQuery obtiene los parámetros de paginación por cursor (limit, cursor y since).
+// Si algún cursor es inválido responde 400 y devuelve false.
+func parsePageQuery(c *gin.Context) (model.PageQuery, bool) {
+	page := model.PageQuery{Limit: defaultPageLimit}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxPageLimit {
+			page.Limit = l
+		}
+	}
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := model.DecodeCursor(cursorStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid cursor",
+			})
+			return page, false
+		}
+		page.Before = cursor
+	}
+
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		since, err := model.DecodeCursor(sinceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid since cursor",
+			})
+			return page, false
+		}
+		page.Since = since
+	}
+
+	return page, true
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"microx/internal/middleware"
+	"microx/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultUserSearchLimit = 10
+	maxUserSearchLimit     = 50
+)
+
+type SearchHandler struct {
+	searchService service.SearchService
+}
+
+// NewSearchHandler crea una nueva instancia del handler de búsqueda
+func NewSearchHandler(searchService service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchTweets maneja la búsqueda de tweets por contenido y filtros
+func (h *SearchHandler) SearchTweets(c *gin.Context) {
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	// El usuario autenticado es opcional en esta ruta; se usa para calcular liked_by_me
+	viewerID := middleware.GetUserID(c)
+
+	q := c.Query("q")
+	tweets, err := h.searchService.SearchTweets(c.Request.Context(), viewerID, q, page)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":        q,
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// SearchUsers maneja la búsqueda de usuarios por prefijo del username, para autocompletar
+func (h *SearchHandler) SearchUsers(c *gin.Context) {
+	limit := defaultUserSearchLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxUserSearchLimit {
+			limit = l
+		}
+	}
+
+	q := c.Query("q")
+	users, err := h.searchService.SearchUsers(c.Request.Context(), q, limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidSearch) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query": q,
+		"users": users,
+		"count": len(users),
+	})
+}
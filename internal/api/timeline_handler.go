@@ -1,15 +1,26 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"time"
 
 	"microx/internal/middleware"
+	"microx/internal/model"
 	"microx/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
+// streamHeartbeatInterval es cada cuánto se envía un comentario SSE para que proxies y clientes
+// no den por muerta una conexión sin tweets nuevos
+const streamHeartbeatInterval = 15 * time.Second
+
+// streamRetryInterval es cuánto espera el cliente antes de reconectar si se corta el stream
+const streamRetryInterval = 3 * time.Second
+
 type TimelineHandler struct {
 	timelineService service.TimelineService
 }
@@ -26,22 +37,38 @@ func (h *TimelineHandler) GetTimeline(c *gin.Context) {
 	userID := middleware.GetUserID(c)
 
 	// Obtener parámetros de paginación
-	limit := 20 // Default limit
-	offset := 0 // Default offset
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
 
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	tweets, err := h.timelineService.GetTimeline(c.Request.Context(), userID, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
 	}
 
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	c.JSON(http.StatusOK, gin.H{
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// GetMentions maneja la obtención de los tweets que mencionan al usuario
+func (h *TimelineHandler) GetMentions(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
 	}
 
-	tweets, err := h.timelineService.GetTimeline(c.Request.Context(), userID, limit, offset)
+	tweets, err := h.timelineService.GetMentions(c.Request.Context(), userID, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -50,13 +77,79 @@ func (h *TimelineHandler) GetTimeline(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"tweets": tweets,
-		"count":  len(tweets),
-		"limit":  limit,
-		"offset": offset,
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
 	})
 }
 
+// StreamTimeline envía los tweets nuevos del timeline como Server-Sent Events. El id de cada
+// evento es un cursor: al reconectar, el cliente lo manda en Last-Event-ID y recibe lo que se perdió.
+func (h *TimelineHandler) StreamTimeline(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	var since *model.Cursor
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		cursor, err := model.DecodeCursor(lastEventID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid Last-Event-ID",
+			})
+			return
+		}
+		since = cursor
+	}
+
+	// El stream termina cuando el cliente se desconecta y se cancela el contexto de la request
+	ctx := c.Request.Context()
+	tweets, err := h.timelineService.StreamTimeline(ctx, userID, since)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrStreamUnavailable) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desactivar el buffering de nginx
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetryInterval.Milliseconds())
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case tweet, ok := <-tweets:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(tweet)
+			if err != nil {
+				continue
+			}
+			cursor := model.NewCursor(tweet.CreatedAt, tweet.ID)
+			fmt.Fprintf(c.Writer, "id: %s\nevent: tweet\ndata: %s\n\n", cursor.Encode(), data)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
 // RefreshTimeline maneja la actualización del timeline desde la base de datos
 func (h *TimelineHandler) RefreshTimeline(c *gin.Context) {
 	userID := middleware.GetUserID(c)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -34,9 +35,19 @@ func (h *TweetHandler) CreateTweet(c *gin.Context) {
 		return
 	}
 
-	tweet, err := h.tweetService.CreateTweet(c.Request.Context(), userID, req.Content)
+	var tweet *model.TweetResponse
+	var err error
+	if req.InReplyToTweetID != nil {
+		tweet, err = h.tweetService.ReplyToTweet(c.Request.Context(), userID, *req.InReplyToTweetID, req.Content)
+	} else {
+		tweet, err = h.tweetService.CreateTweet(c.Request.Context(), userID, req.Content)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -59,9 +70,15 @@ func (h *TweetHandler) GetTweet(c *gin.Context) {
 		return
 	}
 
-	tweet, err := h.tweetService.GetTweet(c.Request.Context(), tweetID)
+	viewerID := middleware.GetUserID(c)
+
+	tweet, err := h.tweetService.GetTweet(c.Request.Context(), viewerID, tweetID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+		status := http.StatusNotFound
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -83,6 +100,151 @@ func (h *TweetHandler) GetUserTweets(c *gin.Context) {
 		return
 	}
 
+	// Obtener parámetros de paginación
+	page, ok := parsePageQuery(c)
+	if !ok {
+		return
+	}
+
+	// El usuario autenticado es opcional en esta ruta; se usa para calcular liked_by_me
+	viewerID := middleware.GetUserID(c)
+
+	tweets, err := h.tweetService.GetUserTweets(c.Request.Context(), viewerID, userID, page)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tweets":       tweets.Tweets,
+		"count":        len(tweets.Tweets),
+		"limit":        page.Limit,
+		"next_cursor":  tweets.NextCursor.Encode(),
+		"since_cursor": tweets.SinceCursor.Encode(),
+	})
+}
+
+// DeleteTweet maneja la eliminación de un tweet por parte de su autor
+func (h *TweetHandler) DeleteTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.tweetService.DeleteTweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrNotTweetAuthor):
+			status = http.StatusForbidden
+		case errors.Is(err, service.ErrTweetNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tweet deleted successfully",
+	})
+}
+
+// UpdateTweet maneja la edición de un tweet por parte de su autor
+func (h *TweetHandler) UpdateTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	var req model.UpdateTweetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	tweet, err := h.tweetService.UpdateTweet(c.Request.Context(), userID, tweetID, req.Content)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrNotTweetAuthor) || errors.Is(err, service.ErrEditWindowExpired) || isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tweet updated successfully",
+		"tweet":   tweet,
+	})
+}
+
+// GetTweetHistory maneja la obtención de las versiones anteriores de un tweet
+func (h *TweetHandler) GetTweetHistory(c *gin.Context) {
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	viewerID := middleware.GetUserID(c)
+
+	revisions, err := h.tweetService.GetTweetHistory(c.Request.Context(), viewerID, tweetID)
+	if err != nil {
+		status := http.StatusNotFound
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tweet_id":  tweetID,
+		"revisions": revisions,
+		"count":     len(revisions),
+	})
+}
+
+// GetThread maneja la obtención de una conversación: el tweet raíz y sus respuestas
+func (h *TweetHandler) GetThread(c *gin.Context) {
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
 	// Obtener parámetros de paginación
 	limit := 20 // Default limit
 	offset := 0 // Default offset
@@ -99,18 +261,128 @@ func (h *TweetHandler) GetUserTweets(c *gin.Context) {
 		}
 	}
 
-	tweets, err := h.tweetService.GetUserTweets(c.Request.Context(), userID, limit, offset)
+	viewerID := middleware.GetUserID(c)
+
+	thread, err := h.tweetService.GetThread(c.Request.Context(), viewerID, tweetID, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusNotFound
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"tweets": tweets,
-		"count":  len(tweets),
-		"limit":  limit,
-		"offset": offset,
+		"root":    thread.Root,
+		"replies": thread.Replies,
+		"count":   len(thread.Replies),
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// Retweet maneja el retweet de un tweet
+func (h *TweetHandler) Retweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	tweet, err := h.tweetService.Retweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrAlreadyRetweeted) {
+			status = http.StatusConflict
+		} else if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tweet retweeted successfully",
+		"tweet":   tweet,
 	})
 }
+
+// Unretweet maneja la acción de deshacer un retweet
+func (h *TweetHandler) Unretweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	err = h.tweetService.Unretweet(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Retweet removed successfully",
+	})
+}
+
+// QuoteTweet maneja la creación de un tweet que cita a otro
+func (h *TweetHandler) QuoteTweet(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	tweetIDStr := c.Param("id")
+	tweetID, err := strconv.ParseInt(tweetIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid tweet ID format",
+		})
+		return
+	}
+
+	var req model.QuoteTweetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	tweet, err := h.tweetService.QuoteTweet(c.Request.Context(), userID, tweetID, req.Content)
+	if err != nil {
+		status := http.StatusBadRequest
+		if isVisibilityError(err) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tweet quoted successfully",
+		"tweet":   tweet,
+	})
+}
+
+// isVisibilityError indica si err se debe a una cuenta protegida o a un bloqueo entre los usuarios
+func isVisibilityError(err error) bool {
+	return errors.Is(err, service.ErrProtectedAccount) || errors.Is(err, service.ErrBlocked)
+}
@@ -1,14 +1,21 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"microx/internal/middleware"
+	"microx/internal/model"
 	"microx/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
+// maxUserLookupIDs es la cantidad máxima de usuarios que se pueden pedir en una sola consulta
+const maxUserLookupIDs = 100
+
 type UserHandler struct {
 	userService service.UserService
 }
@@ -45,7 +52,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"user": user})
+	c.JSON(http.StatusCreated, gin.H{"user": model.NewAccount(user)})
 }
 
 // GetUser maneja la obtención de un usuario específico
@@ -72,6 +79,107 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	})
 }
 
+// GetMe maneja la obtención de la cuenta del usuario autenticado, incluido su email
+func (h *UserHandler) GetMe(c *gin.Context) {
+	user, err := h.userService.GetUser(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": model.NewAccount(user),
+	})
+}
+
+// UpdateMe maneja la edición del perfil del usuario autenticado
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var req model.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(c.Request.Context(), middleware.GetUserID(c), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidProfile) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": model.NewAccount(user),
+	})
+}
+
+// GetUserByUsername maneja la obtención de un usuario por su username
+func (h *UserHandler) GetUserByUsername(c *gin.Context) {
+	user, err := h.userService.GetUserByUsername(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// GetUsers maneja la obtención de varios usuarios por ID (?ids=1,2,3), en el orden pedido
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	idsStr := c.Query("ids")
+	if idsStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "ids is required",
+		})
+		return
+	}
+
+	parts := strings.Split(idsStr, ",")
+	if len(parts) > maxUserLookupIDs {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Too many user IDs (max " + strconv.Itoa(maxUserLookupIDs) + ")",
+		})
+		return
+	}
+
+	userIDs := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		userID, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || userID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user ID format",
+			})
+			return
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	users, err := h.userService.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": users,
+		"count": len(users),
+	})
+}
+
 // GetUserStats maneja la obtención de estadísticas de un usuario
 func (h *UserHandler) GetUserStats(c *gin.Context) {
 	userIDStr := c.Param("id")
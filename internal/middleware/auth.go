@@ -1,44 +1,75 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"microx/internal/model"
 	"microx/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	UserIDHeader = "X-User-ID"
-	UserIDKey    = "user_id"
+	AuthorizationHeader = "Authorization"
+	UserIDHeader        = "X-User-ID"
+	UserIDKey           = "user_id"
+	AccessTokenKey      = "access_token"
+	APITokenKey         = "api_token"
 )
 
-// AuthMiddleware extrae el ID de usuario del header X-User-ID (validación básica)
-func AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		userIDStr := c.GetHeader(UserIDHeader)
+// AuthConfig contiene las dependencias y opciones de los middlewares de autenticación
+type AuthConfig struct {
+	SessionRepo  repository.SessionRepository
+	APITokenRepo repository.APITokenRepository
+	// AllowDevHeader habilita la autenticación por header X-User-ID. Solo para desarrollo.
+	AllowDevHeader bool
+}
 
-		if userIDStr == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "X-User-ID header is required",
+// authError describe por qué no se pudo autenticar una request
+type authError struct {
+	status  int
+	message string
+}
+
+// AuthMiddleware valida el access token del header Authorization y guarda el usuario en el contexto
+func AuthMiddleware(cfg AuthConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, authErr := authenticate(c, cfg)
+		if authErr != nil {
+			c.JSON(authErr.status, gin.H{
+				"error": authErr.message,
 			})
 			c.Abort()
 			return
 		}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid user ID format",
+		// Guardar el userID en el contexto para uso posterior
+		c.Set(UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// AuthWithUserValidationMiddleware valida el access token y que el usuario existe en la BD
+func AuthWithUserValidationMiddleware(cfg AuthConfig, userRepo repository.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, authErr := authenticate(c, cfg)
+		if authErr != nil {
+			c.JSON(authErr.status, gin.H{
+				"error": authErr.message,
 			})
 			c.Abort()
 			return
 		}
 
-		if userID <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "User ID must be positive",
+		// Validar que el usuario existe en la base de datos
+		_, err := userRepo.GetByID(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not found",
 			})
 			c.Abort()
 			return
@@ -50,50 +81,117 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
-// AuthWithUserValidationMiddleware extrae el ID de usuario y valida que existe en la BD
-func AuthWithUserValidationMiddleware(userRepo repository.UserRepository) gin.HandlerFunc {
+// OptionalAuthMiddleware autentica la request si trae credenciales válidas.
+// Permite requests anónimas en rutas públicas que personalizan la respuesta cuando hay usuario.
+func OptionalAuthMiddleware(cfg AuthConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userIDStr := c.GetHeader(UserIDHeader)
+		userID, authErr := authenticate(c, cfg)
+		if authErr == nil {
+			c.Set(UserIDKey, userID)
+		}
+		c.Next()
+	}
+}
 
-		if userIDStr == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "X-User-ID header is required",
-			})
-			c.Abort()
+// RequireScopes exige que los tokens de API tengan todos los scopes dados.
+// Las sesiones de usuario no tienen scopes y acceden a todas las rutas. Debe ejecutarse
+// después de un middleware de autenticación.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiToken := GetAPIToken(c)
+		if apiToken == nil {
+			c.Next()
 			return
 		}
 
-		userID, err := strconv.ParseInt(userIDStr, 10, 64)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid user ID format",
-			})
-			c.Abort()
-			return
+		for _, scope := range scopes {
+			if !apiToken.HasScope(scope) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": fmt.Sprintf("API token is missing required scope %q", scope),
+				})
+				c.Abort()
+				return
+			}
 		}
 
-		if userID <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "User ID must be positive",
+		c.Next()
+	}
+}
+
+// RequireSession rechaza las requests autenticadas con un token de API.
+// Se usa en rutas de gestión de la cuenta que no deben quedar al alcance de bots.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if GetAPIToken(c) != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "This endpoint cannot be used with an API token",
 			})
 			c.Abort()
 			return
 		}
 
-		// Validar que el usuario existe en la base de datos
-		_, err = userRepo.GetByID(c.Request.Context(), userID)
+		c.Next()
+	}
+}
+
+// authenticate resuelve el usuario de la request a partir del header Authorization
+// (Bearer <access_token> o Bearer <token de API>) o, si está habilitado, del header X-User-ID
+func authenticate(c *gin.Context, cfg AuthConfig) (int64, *authError) {
+	authHeader := c.GetHeader(AuthorizationHeader)
+	if authHeader != "" {
+		token, found := strings.CutPrefix(authHeader, "Bearer ")
+		if !found || token == "" {
+			return 0, &authError{http.StatusUnauthorized, "Authorization header must use the Bearer scheme"}
+		}
+
+		if strings.HasPrefix(token, model.APITokenPrefix) && cfg.APITokenRepo != nil {
+			return authenticateAPIToken(c, cfg, token)
+		}
+
+		userID, err := cfg.SessionRepo.GetUserIDByAccessToken(c.Request.Context(), token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "User not found",
-			})
-			c.Abort()
-			return
+			return 0, &authError{http.StatusUnauthorized, "Invalid or expired token"}
 		}
 
-		// Guardar el userID en el contexto para uso posterior
-		c.Set(UserIDKey, userID)
-		c.Next()
+		c.Set(AccessTokenKey, token)
+		return userID, nil
+	}
+
+	if !cfg.AllowDevHeader {
+		return 0, &authError{http.StatusUnauthorized, "Authorization header is required"}
+	}
+
+	userIDStr := c.GetHeader(UserIDHeader)
+	if userIDStr == "" {
+		return 0, &authError{http.StatusUnauthorized, "Authorization or X-User-ID header is required"}
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		return 0, &authError{http.StatusBadRequest, "Invalid user ID format"}
+	}
+
+	if userID <= 0 {
+		return 0, &authError{http.StatusBadRequest, "User ID must be positive"}
+	}
+
+	return userID, nil
+}
+
+// authenticateAPIToken valida un token de API personal y registra su último uso
+func authenticateAPIToken(c *gin.Context, cfg AuthConfig, token string) (int64, *authError) {
+	apiToken, err := cfg.APITokenRepo.GetByToken(c.Request.Context(), token)
+	if err != nil {
+		return 0, &authError{http.StatusUnauthorized, "Invalid API token"}
 	}
+
+	// No fallar la request si no se puede registrar el uso
+	if err := cfg.APITokenRepo.UpdateLastUsed(c.Request.Context(), apiToken.ID, time.Now()); err != nil {
+		fmt.Printf("Warning: error updating api token %d last use: %v\n", apiToken.ID, err)
+	}
+
+	c.Set(APITokenKey, apiToken)
+	return apiToken.UserID, nil
 }
 
 // GetUserID obtiene el ID de usuario del contexto
@@ -104,3 +202,17 @@ func GetUserID(c *gin.Context) int64 {
 	}
 	return userID.(int64)
 }
+
+// GetAccessToken obtiene el access token con el que se autenticó la request
+func GetAccessToken(c *gin.Context) string {
+	return c.GetString(AccessTokenKey)
+}
+
+// GetAPIToken obtiene el token de API con el que se autenticó la request, o nil si no se usó uno
+func GetAPIToken(c *gin.Context) *model.APIToken {
+	apiToken, exists := c.Get(APITokenKey)
+	if !exists {
+		return nil
+	}
+	return apiToken.(*model.APIToken)
+}
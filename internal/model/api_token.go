@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// APITokenPrefix identifica a los tokens de API personales frente a los access tokens de sesión
+const APITokenPrefix = "mxp_"
+
+// Scopes que puede recibir un token de API personal
+const (
+	ScopeTweetRead    = "tweet:read"
+	ScopeTweetWrite   = "tweet:write"
+	ScopeTimelineRead = "timeline:read"
+	ScopeFollowWrite  = "follow:write"
+)
+
+// APITokenScopes lista los scopes válidos para los tokens de API
+var APITokenScopes = []string{
+	ScopeTweetRead,
+	ScopeTweetWrite,
+	ScopeTimelineRead,
+	ScopeFollowWrite,
+}
+
+// APIToken representa un token de API personal de un usuario.
+// Solo se guarda el hash del token; el valor en claro se muestra una única vez al crearlo.
+type APIToken struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// HasScope indica si el token tiene el scope dado
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPITokenRequest representa la solicitud para crear un token de API
+type CreateAPITokenRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+// CreateAPITokenResponse contiene el token recién creado junto con su valor en claro
+type CreateAPITokenResponse struct {
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"api_token"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// Block representa el bloqueo de un usuario por otro
+type Block struct {
+	ID        int64     `json:"id"`
+	BlockerID int64     `json:"blocker_id"`
+	BlockedID int64     `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
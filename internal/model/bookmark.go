@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// Bookmark representa un tweet guardado por un usuario. Solo lo ve quien lo guardó.
+type Bookmark struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	TweetID   int64     `json:"tweet_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+// Mention es una mención @username dentro del contenido de un tweet. Start y End son posiciones
+// en caracteres (runas Unicode) del contenido; End es exclusiva e incluye la @ desde Start.
+type Mention struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
+
+// Hashtag es un #hashtag dentro del contenido de un tweet. Tag es la etiqueta tal como se escribió,
+// sin el #; Start y End se cuentan igual que en Mention.
+type Hashtag struct {
+	Tag   string `json:"tag"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// TweetEntities agrupa las entidades reconocidas en el contenido de un tweet
+type TweetEntities struct {
+	Mentions []*Mention `json:"mentions"`
+	Hashtags []*Hashtag `json:"hashtags"`
+}
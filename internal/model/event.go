@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// Canales de eventos en tiempo real a los que se puede suscribir un cliente
+const (
+	EventChannelTimeline      = "timeline"
+	EventChannelMentions      = "mentions"
+	EventChannelNotifications = "notifications"
+	EventChannelFollows       = "follows"
+)
+
+// EventChannels son todos los canales de eventos válidos
+var EventChannels = []string{
+	EventChannelTimeline,
+	EventChannelMentions,
+	EventChannelNotifications,
+	EventChannelFollows,
+}
+
+// Tipos de eventos en tiempo real
+const (
+	EventTypeTweet    = "tweet"
+	EventTypeMention  = "mention"
+	EventTypeLike     = "like"
+	EventTypeRetweet  = "retweet"
+	EventTypeReply    = "reply"
+	EventTypeQuote    = "quote"
+	EventTypeFollow   = "follow"
+	EventTypeUnfollow = "unfollow"
+	// EventTypeFollowRequest avisa a una cuenta protegida que alguien pidió seguirla
+	EventTypeFollowRequest = "follow_request"
+	// EventTypeFollowAccepted avisa que una cuenta protegida aprobó el pedido de follow
+	EventTypeFollowAccepted = "follow_accepted"
+)
+
+// Event avisa en tiempo real algo que le ocurrió a un usuario: un tweet que llegó a su timeline,
+// un like a uno de sus tweets, un nuevo seguidor, etc. Solo lleva IDs; el tweet se hidrata al
+// enviarlo a cada cliente.
+type Event struct {
+	Channel string `json:"channel"`
+	Type    string `json:"type"`
+	// ActorID es el usuario que originó el evento (el autor del tweet, quien dio like, quien siguió)
+	ActorID   int64     `json:"actor_id,omitempty"`
+	TweetID   int64     `json:"tweet_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventMessage es un evento listo para enviar a un cliente, con el tweet ya hidratado
+type EventMessage struct {
+	Channel   string         `json:"channel"`
+	Type      string         `json:"type"`
+	ActorID   int64          `json:"actor_id,omitempty"`
+	Tweet     *TweetResponse `json:"tweet,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// IsValidEventChannel indica si channel es un canal de eventos válido
+func IsValidEventChannel(channel string) bool {
+	for _, valid := range EventChannels {
+		if channel == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// Estados de una exportación de datos
+const (
+	DataExportPending    = "pending"
+	DataExportProcessing = "processing"
+	DataExportReady      = "ready"
+	DataExportFailed     = "failed"
+)
+
+// DataExport representa un pedido de exportación de los datos de un usuario. El archivo se genera
+// en segundo plano y se puede descargar mientras el pedido esté listo y no haya expirado.
+type DataExport struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Status      string     `json:"status"`
+	FileName    string     `json:"-"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// FanoutJob representa la distribución pendiente de un tweet a los timelines de los seguidores de su autor
+type FanoutJob struct {
+	// ID es el identificador del mensaje en la cola. Lo asigna la cola al leer el job.
+	ID         string    `json:"-"`
+	TweetID    int64     `json:"tweet_id"`
+	AuthorID   int64     `json:"author_id"`
+	Attempts   int       `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
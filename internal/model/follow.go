@@ -12,6 +12,14 @@ type Follow struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// Estados en los que queda una relación al seguir a un usuario
+const (
+	// FollowStatusFollowing indica que el follow se creó
+	FollowStatusFollowing = "following"
+	// FollowStatusPending indica que la cuenta es protegida y el follow espera su aprobación
+	FollowStatusPending = "pending"
+)
+
 // FollowRequest representa la solicitud para seguir a un usuario
 type FollowRequest struct {
 	UserID int64 `json:"user_id" binding:"required"`
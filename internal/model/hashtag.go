@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// TrendWindow define una ventana deslizante de tendencias. Los usos de cada hashtag se cuentan en
+// buckets de duración Bucket; al calcular la ventana, cada bucket pesa la mitad cada HalfLife de
+// antigüedad, de modo que lo reciente domina sin que lo anterior desaparezca de golpe.
+type TrendWindow struct {
+	Name     string
+	Length   time.Duration
+	Bucket   time.Duration
+	HalfLife time.Duration
+}
+
+// Ventanas de tendencias disponibles
+var (
+	TrendWindowHour = TrendWindow{Name: "hour", Length: time.Hour, Bucket: 5 * time.Minute, HalfLife: 15 * time.Minute}
+	TrendWindowDay  = TrendWindow{Name: "day", Length: 24 * time.Hour, Bucket: time.Hour, HalfLife: 6 * time.Hour}
+)
+
+// TrendWindows son todas las ventanas en las que se registran los usos de hashtags
+var TrendWindows = []TrendWindow{TrendWindowHour, TrendWindowDay}
+
+// GetTrendWindow busca una ventana de tendencias por nombre
+func GetTrendWindow(name string) (TrendWindow, bool) {
+	for _, window := range TrendWindows {
+		if window.Name == name {
+			return window, true
+		}
+	}
+	return TrendWindow{}, false
+}
+
+// Trend es un hashtag en tendencia. Score es el puntaje con decaimiento temporal con el que se
+// ordena; TweetCount es la cantidad de tweets que lo usaron dentro de la ventana.
+type Trend struct {
+	Hashtag    string  `json:"hashtag"`
+	Score      float64 `json:"score"`
+	TweetCount int64   `json:"tweet_count"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// Like representa el "me gusta" de un usuario sobre un tweet
+type Like struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	TweetID   int64     `json:"tweet_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
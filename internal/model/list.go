@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+)
+
+// Límites de las listas
+const (
+	MaxListNameLength        = 25
+	MaxListDescriptionLength = 100
+	// MaxListsPerUser es la cantidad máxima de listas que puede crear un usuario
+	MaxListsPerUser = 1000
+	// MaxListMembers es la cantidad máxima de cuentas de una lista
+	MaxListMembers = 5000
+)
+
+// List representa una lista de cuentas definida por un usuario. Las listas privadas solo las ve su dueño.
+type List struct {
+	ID              int64     `json:"id"`
+	OwnerID         int64     `json:"owner_id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Private         bool      `json:"private"`
+	MemberCount     int64     `json:"member_count"`
+	SubscriberCount int64     `json:"subscriber_count"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CreateListRequest representa la solicitud para crear una lista
+type CreateListRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+}
+
+// UpdateListRequest representa la solicitud para editar una lista. Los campos omitidos no se modifican.
+type UpdateListRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Private     *bool   `json:"private"`
+}
+
+// ListMemberRequest representa la solicitud para agregar una cuenta a una lista
+type ListMemberRequest struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// MaxMutedPhraseLength es el largo máximo, en caracteres, de una palabra o frase silenciada
+const MaxMutedPhraseLength = 100
+
+// MutedUser representa una cuenta silenciada por un usuario. Sin ExpiresAt, el silencio no vence.
+type MutedUser struct {
+	UserID      int64      `json:"-"`
+	MutedUserID int64      `json:"muted_user_id"`
+	User        *User      `json:"user,omitempty"` // Perfil público de la cuenta silenciada
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// MutedWord representa una palabra, frase o hashtag silenciado por un usuario
+type MutedWord struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"-"`
+	Phrase    string    `json:"phrase"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MuteUserRequest representa la solicitud para silenciar una cuenta
+type MuteUserRequest struct {
+	UserID    int64      `json:"user_id" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// MuteWordRequest representa la solicitud para silenciar una palabra, frase o hashtag
+type MuteWordRequest struct {
+	Phrase string `json:"phrase" binding:"required"`
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor identifica una posición en una lista ordenada por (created_at, id) descendente.
+// Se trabaja con precisión de segundos, la misma con la que MySQL guarda los TIMESTAMP.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// NewCursor crea un cursor para el elemento con la fecha e ID dados
+func NewCursor(createdAt time.Time, id int64) *Cursor {
+	return &Cursor{CreatedAt: time.Unix(createdAt.Unix(), 0), ID: id}
+}
+
+// Encode serializa el cursor en un string opaco para los clientes
+func (c *Cursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+	raw := fmt.Sprintf("%d:%d", c.CreatedAt.Unix(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor interpreta un cursor generado con Encode
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	secondsStr, idStr, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	seconds, err := strconv.ParseInt(secondsStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &Cursor{CreatedAt: time.Unix(seconds, 0), ID: id}, nil
+}
+
+// Before indica si el cursor está después que other en el orden descendente, es decir, si es más antiguo
+func (c *Cursor) Before(other *Cursor) bool {
+	if c.CreatedAt.Unix() != other.CreatedAt.Unix() {
+		return c.CreatedAt.Unix() < other.CreatedAt.Unix()
+	}
+	return c.ID < other.ID
+}
+
+// PageQuery describe una página de una lista ordenada de la más reciente a la más antigua
+type PageQuery struct {
+	Limit int
+	// Before limita la página a elementos más antiguos que el cursor (página siguiente)
+	Before *Cursor
+	// Since limita la página a elementos más recientes que el cursor (elementos nuevos)
+	Since *Cursor
+}
+
+// Contains indica si un elemento con el cursor dado cae dentro de los límites de la página
+func (p PageQuery) Contains(c *Cursor) bool {
+	if p.Before != nil && !c.Before(p.Before) {
+		return false
+	}
+	if p.Since != nil && !p.Since.Before(c) {
+		return false
+	}
+	return true
+}
+
+// TweetPage es una página de tweets con los cursores para continuar la paginación
+type TweetPage struct {
+	Tweets []*TweetResponse
+	// NextCursor apunta al último elemento de la página; es nil si no hay más elementos
+	NextCursor *Cursor
+	// SinceCursor apunta al elemento más reciente, para pedir luego solo los nuevos
+	SinceCursor *Cursor
+}
+
+// UserPage es una página de usuarios con los cursores para continuar la paginación
+type UserPage struct {
+	Users       []*User
+	NextCursor  *Cursor
+	SinceCursor *Cursor
+}
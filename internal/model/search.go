@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// SearchQuery es una búsqueda de tweets ya interpretada. Terms y Phrases se buscan en el contenido
+// y solo contienen letras, números y guiones bajos; el resto son filtros que deben cumplirse todos.
+type SearchQuery struct {
+	Terms    []string
+	Phrases  []string
+	From     string     // username del autor (from:username)
+	Hashtags []string   // hashtags normalizados
+	Since    *time.Time // inclusive (since:AAAA-MM-DD)
+	Until    *time.Time // exclusive (until:AAAA-MM-DD)
+}
@@ -4,31 +4,87 @@ import (
 	"time"
 )
 
-// Tweet representa un tweet en el sistema
+// Tweet representa un tweet en el sistema. Mentions son los usuarios mencionados en el contenido
+// que existían al publicarlo o editarlo. Hashtags son las etiquetas normalizadas que se indexan al
+// guardarlo; no se leen de vuelta, porque las entidades se calculan a partir del contenido.
 type Tweet struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               int64      `json:"id"`
+	UserID           int64      `json:"user_id"`
+	Content          string     `json:"content"`
+	InReplyToTweetID *int64     `json:"in_reply_to_tweet_id,omitempty"`
+	ConversationID   *int64     `json:"conversation_id,omitempty"`
+	RetweetOfTweetID *int64     `json:"retweet_of_tweet_id,omitempty"`
+	QuotedTweetID    *int64     `json:"quoted_tweet_id,omitempty"`
+	Mentions         []*Mention `json:"mentions,omitempty"`
+	Hashtags         []string   `json:"-"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // TweetWithUser contiene un tweet con información del usuario
 type TweetWithUser struct {
 	Tweet
-	Username string `json:"username"`
+	Username       string         `json:"username"`
+	DisplayName    string         `json:"display_name"`
+	AvatarURL      string         `json:"avatar_url"`
+	RetweetedTweet *TweetWithUser `json:"retweeted_tweet,omitempty"`
+	QuotedTweet    *TweetWithUser `json:"quoted_tweet,omitempty"`
+}
+
+// OriginalID devuelve el ID del tweet original si es un retweet, o su propio ID en caso contrario.
+// Se usa para no mostrar el mismo tweet más de una vez en un timeline.
+func (t *TweetWithUser) OriginalID() int64 {
+	if t.RetweetOfTweetID != nil {
+		return *t.RetweetOfTweetID
+	}
+	return t.ID
 }
 
 // CreateTweetRequest representa la solicitud para crear un tweet
 type CreateTweetRequest struct {
+	Content          string `json:"content" binding:"required,max=280"`
+	InReplyToTweetID *int64 `json:"in_reply_to_tweet_id"`
+}
+
+// UpdateTweetRequest representa la solicitud para editar un tweet
+type UpdateTweetRequest struct {
 	Content string `json:"content" binding:"required,max=280"`
 }
 
-// TweetResponse representa la respuesta de un tweet
-type TweetResponse struct {
+// QuoteTweetRequest representa la solicitud para citar un tweet
+type QuoteTweetRequest struct {
+	Content string `json:"content" binding:"required,max=280"`
+}
+
+// TweetRevision representa una versión anterior del contenido de un tweet
+type TweetRevision struct {
 	ID        int64     `json:"id"`
+	TweetID   int64     `json:"tweet_id"`
 	Content   string    `json:"content"`
-	UserID    int64     `json:"user_id"`
-	Username  string    `json:"username"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// TweetResponse representa la respuesta de un tweet
+type TweetResponse struct {
+	ID               int64          `json:"id"`
+	Content          string         `json:"content"`
+	UserID           int64          `json:"user_id"`
+	Username         string         `json:"username"`
+	User             *UserSummary   `json:"user"`
+	InReplyToTweetID *int64         `json:"in_reply_to_tweet_id,omitempty"`
+	ConversationID   *int64         `json:"conversation_id,omitempty"`
+	RetweetedTweet   *TweetResponse `json:"retweeted_tweet,omitempty"`
+	QuotedTweet      *TweetResponse `json:"quoted_tweet,omitempty"`
+	Entities         TweetEntities  `json:"entities"`
+	LikeCount        int64          `json:"like_count"`
+	LikedByMe        bool           `json:"liked_by_me"`
+	BookmarkedByMe   bool           `json:"bookmarked_by_me"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// ThreadResponse representa una conversación: el tweet raíz y sus respuestas en orden cronológico
+type ThreadResponse struct {
+	Root    *TweetResponse   `json:"root"`
+	Replies []*TweetResponse `json:"replies"`
+}
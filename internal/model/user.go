@@ -4,13 +4,62 @@ import (
 	"time"
 )
 
-// User representa un usuario en el sistema
+// Límites de los campos del perfil, en caracteres
+const (
+	MaxDisplayNameLength = 50
+	MaxBioLength         = 160
+	MaxLocationLength    = 30
+	MaxWebsiteLength     = 100
+	MaxAvatarURLLength   = 255
+)
+
+// User representa un usuario en el sistema. Su JSON es el perfil público: el email y el hash de
+// la contraseña nunca se serializan (el propio usuario ve su email a través de Account).
+// Los tweets de una cuenta protegida solo los ven el dueño y sus seguidores aprobados.
 type User struct {
-	ID        int64     `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           int64     `json:"id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"-"`
+	PasswordHash string    `json:"-"`
+	DisplayName  string    `json:"display_name"`
+	Bio          string    `json:"bio"`
+	Location     string    `json:"location"`
+	Website      string    `json:"website"`
+	AvatarURL    string    `json:"avatar_url"`
+	Protected    bool      `json:"protected"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Account es la vista que el usuario tiene de su propia cuenta: el perfil público más los datos
+// privados
+type Account struct {
+	*User
+	Email string `json:"email"`
+}
+
+// NewAccount crea la vista de la cuenta de un usuario
+func NewAccount(user *User) *Account {
+	return &Account{User: user, Email: user.Email}
+}
+
+// UserSummary es el perfil resumido del autor que se incluye en cada tweet
+type UserSummary struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// UpdateProfileRequest representa la solicitud para editar el perfil. Los campos omitidos no se
+// modifican y un string vacío borra el valor. Protected protege o desprotege la cuenta.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	Location    *string `json:"location"`
+	Website     *string `json:"website"`
+	AvatarURL   *string `json:"avatar_url"`
+	Protected   *bool   `json:"protected"`
 }
 
 // UserStats contiene estadísticas del usuario
@@ -0,0 +1,65 @@
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type exportStorage struct {
+	dir string
+}
+
+// NewExportStorage crea el almacenamiento de exportaciones en el directorio dado, creándolo si no
+// existe. Los archivos contienen datos personales, así que solo los puede leer el proceso.
+func NewExportStorage(dir string) (*exportStorage, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating export directory: %w", err)
+	}
+	return &exportStorage{dir: dir}, nil
+}
+
+// Path devuelve la ruta del archivo. Solo se usa el nombre base, para que name no pueda salir del
+// directorio.
+func (s *exportStorage) Path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
+
+// Create escribe en un archivo temporal que se renombra al cerrarlo, de modo que nunca se sirva
+// un archivo a medio escribir
+func (s *exportStorage) Create(name string) (io.WriteCloser, error) {
+	file, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating export file: %w", err)
+	}
+	return &pendingFile{File: file, path: s.Path(name)}, nil
+}
+
+func (s *exportStorage) Delete(name string) error {
+	err := os.Remove(s.Path(name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting export file: %w", err)
+	}
+	return nil
+}
+
+// pendingFile es un archivo temporal que se publica con su nombre definitivo al cerrarse
+type pendingFile struct {
+	*os.File
+	path string
+}
+
+// Close cierra el archivo y lo mueve a su ruta definitiva; si algo falla, lo descarta
+func (f *pendingFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		os.Remove(f.File.Name())
+		return fmt.Errorf("error closing export file: %w", err)
+	}
+	if err := os.Rename(f.File.Name(), f.path); err != nil {
+		os.Remove(f.File.Name())
+		return fmt.Errorf("error saving export file: %w", err)
+	}
+	return nil
+}
@@ -2,13 +2,36 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"io"
 	"microx/internal/model"
+	"time"
 )
 
+// ErrNotFound indica que el registro buscado no existe
+var ErrNotFound = errors.New("not found")
+
 // UserRepository define las operaciones para usuarios
 type UserRepository interface {
 	GetByID(ctx context.Context, id int64) (*model.User, error)
+	GetByUsername(ctx context.Context, username string) (*model.User, error)
+	// GetByUsernames obtiene los usuarios con los usernames dados; los que no existen se omiten
+	GetByUsernames(ctx context.Context, usernames []string) ([]*model.User, error)
+	// GetByIDs obtiene varios usuarios en una sola consulta; los que no existen se omiten
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.User, error)
+	// SearchByUsernamePrefix busca usuarios por prefijo del username: primero la coincidencia
+	// exacta y después el resto, de más a menos seguidores
+	SearchByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]*model.User, error)
 	Create(ctx context.Context, user *model.User) error
+	// UpdateProfile guarda los campos editables del perfil. Si la cuenta no es protegida, descarta
+	// sus pedidos de follow pendientes.
+	UpdateProfile(ctx context.Context, user *model.User) error
+	// SoftDelete da de baja la cuenta; las cuentas dadas de baja no aparecen en ninguna consulta
+	SoftDelete(ctx context.Context, userID int64, deletedAt time.Time) error
+	// GetDeletedBefore obtiene las cuentas dadas de baja antes de la fecha dada
+	GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	// Delete borra definitivamente una cuenta dada de baja, con todos sus datos
+	Delete(ctx context.Context, userID int64) error
 	GetStats(ctx context.Context, userID int64) (*model.UserStats, error)
 	GetAllUsers(ctx context.Context) ([]*model.User, error)
 }
@@ -17,8 +40,38 @@ type UserRepository interface {
 type TweetRepository interface {
 	Create(ctx context.Context, tweet *model.Tweet) error
 	GetByID(ctx context.Context, id int64) (*model.TweetWithUser, error)
-	GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error)
-	GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error)
+	// GetByIDs obtiene varios tweets en una sola consulta; los que no existen se omiten
+	GetByIDs(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error)
+	GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	GetByUserIDs(ctx context.Context, userIDs []int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	// GetListTimeline obtiene una página de los tweets de los miembros de la lista, sin filtrar por
+	// lector: la página se comparte entre todos los que leen la lista
+	GetListTimeline(ctx context.Context, listID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	// GetMentions obtiene una página de los tweets que mencionan al usuario, de cualquier autor
+	// cuyos tweets pueda ver
+	GetMentions(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	// GetByHashtag obtiene una página de los tweets que usan el hashtag normalizado dado, sin los
+	// de cuentas protegidas que viewerID no sigue
+	GetByHashtag(ctx context.Context, viewerID int64, tag string, page model.PageQuery) ([]*model.TweetWithUser, error)
+	// GetIDsByUserID obtiene los IDs de todos los tweets del usuario, aunque la cuenta esté dada de baja
+	GetIDsByUserID(ctx context.Context, userID int64) ([]int64, error)
+	Delete(ctx context.Context, id int64) error
+	Update(ctx context.Context, tweet *model.Tweet) error
+	GetRevisions(ctx context.Context, tweetID int64) ([]*model.TweetRevision, error)
+	// GetReplies obtiene las respuestas de una conversación, sin las de cuentas protegidas que
+	// viewerID no sigue ni las de cuentas que lo bloquearon
+	GetReplies(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error)
+	GetRetweet(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error)
+	GetRetweets(ctx context.Context, tweetID int64) ([]*model.TweetWithUser, error)
+}
+
+// SearchRepository define la búsqueda de tweets. Recibe la consulta ya interpretada, para que el
+// índice (FULLTEXT de MySQL o uno embebido) se pueda reemplazar sin cambiar el servicio.
+type SearchRepository interface {
+	// SearchTweets obtiene una página de los tweets que cumplen la búsqueda, del más reciente al más
+	// antiguo, sin los de cuentas protegidas que viewerID no sigue ni los de cuentas que lo bloquearon
+	SearchTweets(ctx context.Context, viewerID int64, query *model.SearchQuery, page model.PageQuery) ([]*model.TweetWithUser, error)
 }
 
 // FollowRepository define las operaciones para follows
@@ -26,15 +79,194 @@ type FollowRepository interface {
 	Create(ctx context.Context, follow *model.Follow) error
 	Delete(ctx context.Context, followerID, followingID int64) error
 	Exists(ctx context.Context, followerID, followingID int64) (bool, error)
-	GetFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error)
-	GetFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error)
+	GetFollowers(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	GetFollowing(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	CountFollowers(ctx context.Context, userID int64) (int64, error)
+	GetFollowingIDsOverThreshold(ctx context.Context, userID int64, minFollowers int64) ([]int64, error)
+	// GetFollowedAmong devuelve los usuarios de userIDs que followerID sigue
+	GetFollowedAmong(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error)
+	// CreateRequest registra un pedido de follow pendiente hacia una cuenta protegida
+	CreateRequest(ctx context.Context, requesterID, targetID int64) error
+	RequestExists(ctx context.Context, requesterID, targetID int64) (bool, error)
+	DeleteRequest(ctx context.Context, requesterID, targetID int64) error
+	// GetRequests obtiene una página de los usuarios con pedidos pendientes hacia la cuenta
+	GetRequests(ctx context.Context, targetID int64, page model.PageQuery) (*model.UserPage, error)
+	// ApproveRequest reemplaza el pedido pendiente por un follow, de forma atómica
+	ApproveRequest(ctx context.Context, requesterID, targetID int64) error
+}
+
+// BlockRepository define las operaciones para bloqueos entre usuarios
+type BlockRepository interface {
+	// Create registra el bloqueo y elimina los follows y pedidos de follow entre ambos usuarios.
+	// Devuelve false si el bloqueo ya existía.
+	Create(ctx context.Context, block *model.Block) (bool, error)
+	Delete(ctx context.Context, blockerID, blockedID int64) error
+	Exists(ctx context.Context, blockerID, blockedID int64) (bool, error)
+	// GetBlockedBetween devuelve los usuarios de otherIDs con un bloqueo con userID, en cualquier dirección
+	GetBlockedBetween(ctx context.Context, userID int64, otherIDs []int64) ([]int64, error)
+	// GetBlockerIDs devuelve los usuarios que bloquearon a blockedID
+	GetBlockerIDs(ctx context.Context, blockedID int64) ([]int64, error)
+}
+
+// MuteRepository define las operaciones para cuentas y palabras silenciadas
+type MuteRepository interface {
+	// MuteUser silencia la cuenta o, si ya lo estaba, reemplaza el vencimiento
+	MuteUser(ctx context.Context, mute *model.MutedUser) error
+	UnmuteUser(ctx context.Context, userID, mutedUserID int64) error
+	// GetMutedUsers obtiene las cuentas silenciadas cuyo silencio no venció antes de now
+	GetMutedUsers(ctx context.Context, userID int64, now time.Time) ([]*model.MutedUser, error)
+	// AddWord silencia la palabra; devuelve false si ya estaba silenciada
+	AddWord(ctx context.Context, word *model.MutedWord) (bool, error)
+	DeleteWord(ctx context.Context, userID, wordID int64) error
+	GetWords(ctx context.Context, userID int64) ([]*model.MutedWord, error)
+}
+
+// ListRepository define las operaciones para listas, sus miembros y sus suscriptores
+type ListRepository interface {
+	Create(ctx context.Context, list *model.List) error
+	// GetByID obtiene la lista con la cantidad de miembros y suscriptores
+	GetByID(ctx context.Context, id int64) (*model.List, error)
+	Update(ctx context.Context, list *model.List) error
+	Delete(ctx context.Context, id int64) error
+	// GetByOwner obtiene las listas del usuario; las privadas solo si includePrivate es true
+	GetByOwner(ctx context.Context, ownerID int64, includePrivate bool) ([]*model.List, error)
+	// GetSubscribed obtiene las listas públicas a las que el usuario está suscripto
+	GetSubscribed(ctx context.Context, userID int64) ([]*model.List, error)
+	// AddMember agrega la cuenta a la lista; devuelve false si ya era miembro
+	AddMember(ctx context.Context, listID, userID int64) (bool, error)
+	RemoveMember(ctx context.Context, listID, userID int64) error
+	GetMembers(ctx context.Context, listID int64, page model.PageQuery) (*model.UserPage, error)
+	// GetProtectedMemberIDs obtiene los miembros de la lista con la cuenta protegida
+	GetProtectedMemberIDs(ctx context.Context, listID int64) ([]int64, error)
+	// GetListIDsByMember obtiene las listas de las que la cuenta es miembro, para el fan-out
+	GetListIDsByMember(ctx context.Context, userID int64) ([]int64, error)
+	// Subscribe suscribe al usuario a la lista; devuelve false si ya estaba suscripto
+	Subscribe(ctx context.Context, listID, userID int64) (bool, error)
+	Unsubscribe(ctx context.Context, listID, userID int64) error
+}
+
+// LikeRepository define las operaciones para likes
+type LikeRepository interface {
+	Create(ctx context.Context, like *model.Like) (bool, error)
+	Delete(ctx context.Context, userID, tweetID int64) error
+	GetLikers(ctx context.Context, tweetID int64, limit, offset int) ([]*model.User, error)
+	GetLikeCounts(ctx context.Context, tweetIDs []int64) (map[int64]int64, error)
+	GetLikedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error)
+	// GetByUserID obtiene una página de los likes que dio el usuario
+	GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Like, error)
+}
+
+// BookmarkRepository define las operaciones para tweets guardados
+type BookmarkRepository interface {
+	// Create guarda el tweet para el usuario; devuelve false si ya estaba guardado
+	Create(ctx context.Context, bookmark *model.Bookmark) (bool, error)
+	Delete(ctx context.Context, userID, tweetID int64) error
+	// DeleteByAuthor elimina los guardados del usuario sobre tweets de authorID
+	DeleteByAuthor(ctx context.Context, userID, authorID int64) error
+	// GetByUserID obtiene una página de los guardados del usuario
+	GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Bookmark, error)
+	GetBookmarkedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error)
+}
+
+// SessionRepository define las operaciones para sesiones de autenticación
+type SessionRepository interface {
+	Create(ctx context.Context, userID int64, tokens *model.AuthTokens, accessTTL, refreshTTL time.Duration) error
+	GetUserIDByAccessToken(ctx context.Context, accessToken string) (int64, error)
+	RevokeByAccessToken(ctx context.Context, accessToken string) error
+	// ConsumeRefreshToken revoca de forma atómica la sesión de un refresh token vigente y devuelve su
+	// usuario, para que cada refresh token se pueda usar una sola vez. Devuelve ErrNotFound si el
+	// token no existe o ya se usó.
+	ConsumeRefreshToken(ctx context.Context, refreshToken string) (int64, error)
+}
+
+// APITokenRepository define las operaciones para tokens de API personales
+type APITokenRepository interface {
+	Create(ctx context.Context, apiToken *model.APIToken, token string) error
+	GetByToken(ctx context.Context, token string) (*model.APIToken, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*model.APIToken, error)
+	Delete(ctx context.Context, userID, tokenID int64) error
+	UpdateLastUsed(ctx context.Context, tokenID int64, usedAt time.Time) error
+}
+
+// DataExportRepository define las operaciones para los pedidos de exportación de datos
+type DataExportRepository interface {
+	Create(ctx context.Context, export *model.DataExport) error
+	GetByID(ctx context.Context, id int64) (*model.DataExport, error)
+	// GetByUserID obtiene todas las exportaciones del usuario, de la más reciente a la más antigua
+	GetByUserID(ctx context.Context, userID int64) ([]*model.DataExport, error)
+	// ClaimPending toma la exportación pendiente más antigua y la marca en proceso; devuelve nil si
+	// no hay ninguna. Varias instancias pueden reclamar a la vez sin tomar la misma.
+	ClaimPending(ctx context.Context) (*model.DataExport, error)
+	MarkReady(ctx context.Context, id int64, fileName string, completedAt, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, reason string, completedAt time.Time) error
+	// GetExpired obtiene hasta limit exportaciones cuyo archivo expiró antes de now
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]*model.DataExport, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// ExportStorage guarda los archivos de las exportaciones de datos
+type ExportStorage interface {
+	// Create abre un archivo nuevo para escribir; solo queda disponible al cerrarlo sin errores
+	Create(name string) (io.WriteCloser, error)
+	// Path devuelve la ruta local del archivo, para servirlo
+	Path(name string) string
+	// Delete elimina el archivo; no es un error que no exista
+	Delete(name string) error
 }
 
 // TimelineRepository define las operaciones específicas para timeline
 type TimelineRepository interface {
 	AddToTimeline(ctx context.Context, userID int64, tweet *model.TweetWithUser) error
-	GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error)
+	// GetTimeline devuelve la posición (created_at, id) de cada tweet de la página; el contenido
+	// se obtiene aparte desde TweetCacheRepository
+	GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error)
 	RemoveFromTimeline(ctx context.Context, userID int64, tweetID int64) error
+	// RemoveTweetsFromTimelines elimina los tweets dados de los timelines de todos los usuarios dados
+	RemoveTweetsFromTimelines(ctx context.Context, userIDs []int64, tweetIDs []int64) error
 	InvalidateTimeline(ctx context.Context, userID int64) error
 	AddToMultipleTimelines(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error
 }
+
+// EventRepository define el canal de eventos en tiempo real de los usuarios
+type EventRepository interface {
+	// PublishToUsers envía el evento a los usuarios dados
+	PublishToUsers(ctx context.Context, userIDs []int64, event *model.Event) error
+	// PublishToAuthor avisa de un tweet que no se distribuye al escribir a quienes siguen al autor
+	PublishToAuthor(ctx context.Context, authorID int64, event *model.Event) error
+	// Subscribe recibe los eventos del usuario y de los autores dados hasta que se cancele ctx
+	Subscribe(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error)
+}
+
+// TweetCacheRepository define la caché de tweets por ID con la que se hidratan los timelines
+type TweetCacheRepository interface {
+	// GetMany devuelve los tweets cacheados indexados por ID; los que no están se omiten
+	GetMany(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error)
+	SetMany(ctx context.Context, tweets []*model.TweetWithUser) error
+	Delete(ctx context.Context, ids ...int64) error
+}
+
+// TrendRepository cuenta los usos de hashtags por ventanas de tiempo para calcular tendencias
+type TrendRepository interface {
+	// Record suma un uso de cada hashtag, en el instante dado, en todas las ventanas
+	Record(ctx context.Context, hashtags []string, at time.Time) error
+	// GetTrending devuelve los hashtags con mayor puntaje de la ventana que termina en now
+	GetTrending(ctx context.Context, window model.TrendWindow, now time.Time, limit int) ([]*model.Trend, error)
+}
+
+// FanoutQueueRepository define una cola durable de jobs de fan-out con reintentos diferidos
+// y registro de los jobs descartados (dead-letter)
+type FanoutQueueRepository interface {
+	// EnsureGroup crea la cola y el grupo de consumidores si no existen
+	EnsureGroup(ctx context.Context) error
+	Enqueue(ctx context.Context, job *model.FanoutJob) error
+	// Consume lee jobs nuevos para el consumidor, esperando hasta block si no hay ninguno
+	Consume(ctx context.Context, consumer string, count int, block time.Duration) ([]*model.FanoutJob, error)
+	// ClaimStale reasigna al consumidor los jobs leídos por otro que no los confirmó en minIdle
+	ClaimStale(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]*model.FanoutJob, error)
+	Ack(ctx context.Context, job *model.FanoutJob) error
+	// Retry confirma el job y lo vuelve a encolar cuando pase el delay
+	Retry(ctx context.Context, job *model.FanoutJob, delay time.Duration) error
+	// PromoteDelayed encola los reintentos cuyo delay ya venció
+	PromoteDelayed(ctx context.Context, now time.Time) (int, error)
+	DeadLetter(ctx context.Context, job *model.FanoutJob, reason string) error
+}
@@ -0,0 +1,167 @@
+package mysql
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"microx/internal/model"
+	"strings"
+	"time"
+)
+
+type apiTokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository crea una nueva instancia del repositorio de tokens de API
+func NewAPITokenRepository(db *sql.DB) *apiTokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+// hashToken obtiene el hash de un token. En la base solo se guardan hashes, nunca los tokens en claro.
+func (r *apiTokenRepository) hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create guarda un token de API a partir de su valor en claro
+func (r *apiTokenRepository) Create(ctx context.Context, apiToken *model.APIToken, token string) error {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, scopes, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	apiToken.CreatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		apiToken.UserID,
+		apiToken.Name,
+		r.hashToken(token),
+		strings.Join(apiToken.Scopes, ","),
+		apiToken.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating api token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error getting last insert id: %w", err)
+	}
+
+	apiToken.ID = id
+	return nil
+}
+
+// GetByToken obtiene un token de API a partir de su valor en claro
+func (r *apiTokenRepository) GetByToken(ctx context.Context, token string) (*model.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, scopes, last_used_at, created_at
+		FROM api_tokens
+		WHERE token_hash = ?
+	`
+
+	apiToken, err := scanAPIToken(r.db.QueryRowContext(ctx, query, r.hashToken(token)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("api token not found")
+		}
+		return nil, fmt.Errorf("error getting api token: %w", err)
+	}
+
+	return apiToken, nil
+}
+
+// GetByUserID obtiene los tokens de API de un usuario, del más reciente al más antiguo
+func (r *apiTokenRepository) GetByUserID(ctx context.Context, userID int64) ([]*model.APIToken, error) {
+	query := `
+		SELECT id, user_id, name, scopes, last_used_at, created_at
+		FROM api_tokens
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var apiTokens []*model.APIToken
+	for rows.Next() {
+		apiToken, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning api token: %w", err)
+		}
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api tokens: %w", err)
+	}
+
+	return apiTokens, nil
+}
+
+// Delete revoca un token de API del usuario
+func (r *apiTokenRepository) Delete(ctx context.Context, userID, tokenID int64) error {
+	query := `DELETE FROM api_tokens WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, tokenID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting api token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("api token not found")
+	}
+
+	return nil
+}
+
+// UpdateLastUsed registra el último uso de un token de API
+func (r *apiTokenRepository) UpdateLastUsed(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	query := `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, usedAt, tokenID)
+	if err != nil {
+		return fmt.Errorf("error updating api token last use: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIToken lee una fila de api_tokens
+func scanAPIToken(row rowScanner) (*model.APIToken, error) {
+	apiToken := &model.APIToken{}
+	var scopes string
+	var lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&apiToken.ID,
+		&apiToken.UserID,
+		&apiToken.Name,
+		&scopes,
+		&lastUsedAt,
+		&apiToken.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		apiToken.Scopes = strings.Split(scopes, ",")
+	}
+	if lastUsedAt.Valid {
+		apiToken.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return apiToken, nil
+}
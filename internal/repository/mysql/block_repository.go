@@ -0,0 +1,156 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"time"
+)
+
+type blockRepository struct {
+	db *sql.DB
+}
+
+// NewBlockRepository crea una nueva instancia del repositorio de bloqueos
+func NewBlockRepository(db *sql.DB) *blockRepository {
+	return &blockRepository{db: db}
+}
+
+// Create registra el bloqueo y, en la misma transacción, elimina los follows y los pedidos de
+// follow entre ambos usuarios, en las dos direcciones. Devuelve false si el bloqueo ya existía.
+func (r *blockRepository) Create(ctx context.Context, block *model.Block) (bool, error) {
+	block.CreatedAt = time.Now().Truncate(time.Second)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// INSERT IGNORE apoyado en unique_block evita bloqueos duplicados ante requests concurrentes
+	result, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO blocks (blocker_id, blocked_id, created_at)
+		VALUES (?, ?, ?)
+	`, block.BlockerID, block.BlockedID, block.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("error creating block: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("error getting last insert id: %w", err)
+	}
+	block.ID = id
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM follows
+		WHERE (follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)
+	`, block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting follows: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM follow_requests
+		WHERE (requester_id = ? AND target_id = ?) OR (requester_id = ? AND target_id = ?)
+	`, block.BlockerID, block.BlockedID, block.BlockedID, block.BlockerID)
+	if err != nil {
+		return false, fmt.Errorf("error deleting follow requests: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *blockRepository) Delete(ctx context.Context, blockerID, blockedID int64) error {
+	query := `DELETE FROM blocks WHERE blocker_id = ? AND blocked_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("error deleting block: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("block not found")
+	}
+
+	return nil
+}
+
+func (r *blockRepository) Exists(ctx context.Context, blockerID, blockedID int64) (bool, error) {
+	query := `SELECT COUNT(*) FROM blocks WHERE blocker_id = ? AND blocked_id = ?`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, blockerID, blockedID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking block existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// GetBlockedBetween devuelve los usuarios de otherIDs que bloquearon a userID o a los que userID
+// bloqueó
+func (r *blockRepository) GetBlockedBetween(ctx context.Context, userID int64, otherIDs []int64) ([]int64, error) {
+	if len(otherIDs) == 0 {
+		return nil, nil
+	}
+
+	in := placeholders(len(otherIDs))
+	query := `
+		SELECT blocker_id FROM blocks WHERE blocked_id = ? AND blocker_id IN (` + in + `)
+		UNION
+		SELECT blocked_id FROM blocks WHERE blocker_id = ? AND blocked_id IN (` + in + `)
+	`
+
+	args := append([]any{userID}, int64Args(otherIDs)...)
+	args = append(append(args, userID), int64Args(otherIDs)...)
+
+	return r.queryUserIDs(ctx, query, args...)
+}
+
+// GetBlockerIDs devuelve los usuarios que bloquearon a blockedID
+func (r *blockRepository) GetBlockerIDs(ctx context.Context, blockedID int64) ([]int64, error) {
+	return r.queryUserIDs(ctx, `SELECT blocker_id FROM blocks WHERE blocked_id = ?`, blockedID)
+}
+
+func (r *blockRepository) queryUserIDs(ctx context.Context, query string, args ...any) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("error scanning blocked user: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blocked users: %w", err)
+	}
+
+	return userIDs, nil
+}
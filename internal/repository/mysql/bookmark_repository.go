@@ -0,0 +1,153 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+type bookmarkRepository struct {
+	db *sql.DB
+}
+
+// NewBookmarkRepository crea una nueva instancia del repositorio de tweets guardados
+func NewBookmarkRepository(db *sql.DB) *bookmarkRepository {
+	return &bookmarkRepository{db: db}
+}
+
+// Create guarda un tweet para el usuario. Devuelve false si ya lo tenía guardado.
+func (r *bookmarkRepository) Create(ctx context.Context, bookmark *model.Bookmark) (bool, error) {
+	bookmark.CreatedAt = time.Now().Truncate(time.Second)
+
+	// INSERT IGNORE apoyado en unique_bookmark evita guardados duplicados ante requests concurrentes
+	result, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO bookmarks (user_id, tweet_id, created_at)
+		VALUES (?, ?, ?)
+	`, bookmark.UserID, bookmark.TweetID, bookmark.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("error creating bookmark: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("error getting last insert id: %w", err)
+	}
+	bookmark.ID = id
+
+	return true, nil
+}
+
+func (r *bookmarkRepository) Delete(ctx context.Context, userID, tweetID int64) error {
+	query := `DELETE FROM bookmarks WHERE user_id = ? AND tweet_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("error deleting bookmark: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("bookmark %w: %d", repository.ErrNotFound, tweetID)
+	}
+
+	return nil
+}
+
+// DeleteByAuthor elimina los guardados del usuario sobre tweets de authorID
+func (r *bookmarkRepository) DeleteByAuthor(ctx context.Context, userID, authorID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE b FROM bookmarks b
+		JOIN tweets t ON t.id = b.tweet_id
+		WHERE b.user_id = ? AND t.user_id = ?
+	`, userID, authorID)
+	if err != nil {
+		return fmt.Errorf("error deleting bookmarks by author: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID obtiene una página de los guardados del usuario, del más reciente al más antiguo
+func (r *bookmarkRepository) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Bookmark, error) {
+	conditions, args := cursorConditions("b", page)
+	query := `
+		SELECT b.id, b.user_id, b.tweet_id, b.created_at
+		FROM bookmarks b
+		WHERE b.user_id = ?` + conditions + `
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{userID}, args...), page.Limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	var bookmarks []*model.Bookmark
+	for rows.Next() {
+		bookmark := &model.Bookmark{}
+		if err := rows.Scan(&bookmark.ID, &bookmark.UserID, &bookmark.TweetID, &bookmark.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning bookmark: %w", err)
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookmarks: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+// GetBookmarkedTweetIDs indica cuáles de los tweets dados guardó el usuario
+func (r *bookmarkRepository) GetBookmarkedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+	bookmarked := make(map[int64]bool, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return bookmarked, nil
+	}
+
+	query := `
+		SELECT tweet_id
+		FROM bookmarks
+		WHERE user_id = ? AND tweet_id IN (` + placeholders(len(tweetIDs)) + `)
+	`
+
+	args := append([]any{userID}, int64Args(tweetIDs)...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bookmarked tweets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID int64
+		if err := rows.Scan(&tweetID); err != nil {
+			return nil, fmt.Errorf("error scanning bookmarked tweet: %w", err)
+		}
+		bookmarked[tweetID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookmarked tweets: %w", err)
+	}
+
+	return bookmarked, nil
+}
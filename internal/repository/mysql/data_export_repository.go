@@ -0,0 +1,229 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+// dataExportColumns son las columnas que se seleccionan para construir un DataExport.
+// Deben mantenerse en sincronía con scanDataExport.
+const dataExportColumns = `id, user_id, status, file_name, error, created_at, completed_at, expires_at`
+
+// scanDataExport escanea una fila seleccionada con dataExportColumns
+func scanDataExport(row rowScanner) (*model.DataExport, error) {
+	export := &model.DataExport{}
+	var completedAt, expiresAt sql.NullTime
+
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.FileName,
+		&export.Error,
+		&export.CreatedAt,
+		&completedAt,
+		&expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = &expiresAt.Time
+	}
+
+	return export, nil
+}
+
+type dataExportRepository struct {
+	db *sql.DB
+}
+
+// NewDataExportRepository crea una nueva instancia del repositorio de exportaciones de datos
+func NewDataExportRepository(db *sql.DB) *dataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+// Create registra un pedido de exportación pendiente
+func (r *dataExportRepository) Create(ctx context.Context, export *model.DataExport) error {
+	export.Status = model.DataExportPending
+	export.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO data_exports (user_id, status, created_at)
+		VALUES (?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, export.UserID, export.Status, export.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating data export: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error getting last insert id: %w", err)
+	}
+
+	export.ID = id
+	return nil
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id int64) (*model.DataExport, error) {
+	query := `SELECT ` + dataExportColumns + ` FROM data_exports WHERE id = ?`
+
+	export, err := scanDataExport(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("data export %w: %d", repository.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("error getting data export: %w", err)
+	}
+
+	return export, nil
+}
+
+// GetByUserID obtiene las exportaciones de un usuario, de la más reciente a la más antigua
+func (r *dataExportRepository) GetByUserID(ctx context.Context, userID int64) ([]*model.DataExport, error) {
+	query := `
+		SELECT ` + dataExportColumns + `
+		FROM data_exports
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	exports, err := r.queryDataExports(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+// ClaimPending toma la exportación pendiente más antigua con SELECT ... FOR UPDATE SKIP LOCKED,
+// de modo que cada instancia del servidor reclame una distinta
+func (r *dataExportRepository) ClaimPending(ctx context.Context) (*model.DataExport, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT ` + dataExportColumns + `
+		FROM data_exports
+		WHERE status = ?
+		ORDER BY created_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	export, err := scanDataExport(tx.QueryRowContext(ctx, query, model.DataExportPending))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming data export: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE data_exports SET status = ? WHERE id = ?`, model.DataExportProcessing, export.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error claiming data export: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	export.Status = model.DataExportProcessing
+	return export, nil
+}
+
+// MarkReady registra el archivo generado y hasta cuándo se puede descargar
+func (r *dataExportRepository) MarkReady(ctx context.Context, id int64, fileName string, completedAt, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = ?, file_name = ?, completed_at = ?, expires_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, model.DataExportReady, fileName, completedAt, expiresAt, id)
+	if err != nil {
+		return fmt.Errorf("error updating data export: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed registra que la exportación no se pudo generar
+func (r *dataExportRepository) MarkFailed(ctx context.Context, id int64, reason string, completedAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = ?, error = ?, completed_at = ?
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, model.DataExportFailed, reason, completedAt, id)
+	if err != nil {
+		return fmt.Errorf("error updating data export: %w", err)
+	}
+
+	return nil
+}
+
+// GetExpired obtiene exportaciones cuyo archivo ya expiró
+func (r *dataExportRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]*model.DataExport, error) {
+	query := `
+		SELECT ` + dataExportColumns + `
+		FROM data_exports
+		WHERE expires_at IS NOT NULL AND expires_at < ?
+		ORDER BY expires_at
+		LIMIT ?
+	`
+
+	exports, err := r.queryDataExports(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting expired data exports: %w", err)
+	}
+
+	return exports, nil
+}
+
+func (r *dataExportRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM data_exports WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting data export: %w", err)
+	}
+
+	return nil
+}
+
+// queryDataExports ejecuta una consulta que selecciona dataExportColumns y escanea sus filas
+func (r *dataExportRepository) queryDataExports(ctx context.Context, query string, args ...any) ([]*model.DataExport, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []*model.DataExport
+	for rows.Next() {
+		export, err := scanDataExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning data export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data exports: %w", err)
+	}
+
+	return exports, nil
+}
@@ -18,7 +18,9 @@ func NewFollowRepository(db *sql.DB) *followRepository {
 }
 
 func (r *followRepository) Create(ctx context.Context, follow *model.Follow) error {
-	now := time.Now()
+	// MySQL guarda los TIMESTAMP con precisión de segundos; se trunca para que el valor en
+	// memoria (y los cursores derivados de él) coincida con el persistido
+	now := time.Now().Truncate(time.Second)
 	follow.CreatedAt = now
 
 	query := `
@@ -89,80 +91,231 @@ func (r *followRepository) Exists(ctx context.Context, followerID, followingID i
 	return count > 0, nil
 }
 
-func (r *followRepository) GetFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+// GetFollowers obtiene una página de los seguidores de un usuario, ordenados por fecha de follow descendente
+func (r *followRepository) GetFollowers(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+	conditions, args := cursorConditions("f", page)
 	query := `
-		SELECT u.id, u.username, u.email, u.created_at, u.updated_at
+		SELECT ` + userColumns + `, f.created_at, f.id
 		FROM users u
 		JOIN follows f ON u.id = f.follower_id
-		WHERE f.following_id = ?
-		ORDER BY f.created_at DESC
-		LIMIT ? OFFSET ?
+		WHERE f.following_id = ? AND u.deleted_at IS NULL` + conditions + `
+		ORDER BY f.created_at DESC, f.id DESC
+		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	args = append(append([]any{userID}, args...), page.Limit)
+	users, err := queryUserPage(ctx, r.db, page.Limit, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error getting followers: %w", err)
 	}
+
+	return users, nil
+}
+
+// GetFollowing obtiene una página de los usuarios seguidos por un usuario, ordenados por fecha de follow descendente
+func (r *followRepository) GetFollowing(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+	conditions, args := cursorConditions("f", page)
+	query := `
+		SELECT ` + userColumns + `, f.created_at, f.id
+		FROM users u
+		JOIN follows f ON u.id = f.following_id
+		WHERE f.follower_id = ? AND u.deleted_at IS NULL` + conditions + `
+		ORDER BY f.created_at DESC, f.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{userID}, args...), page.Limit)
+	users, err := queryUserPage(ctx, r.db, page.Limit, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting following: %w", err)
+	}
+
+	return users, nil
+}
+
+// CountFollowers obtiene la cantidad de seguidores de un usuario
+func (r *followRepository) CountFollowers(ctx context.Context, userID int64) (int64, error) {
+	query := `SELECT COUNT(*) FROM follows WHERE following_id = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting followers: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetFollowingIDsOverThreshold obtiene los IDs de los usuarios seguidos que tienen más de
+// minFollowers seguidores
+func (r *followRepository) GetFollowingIDsOverThreshold(ctx context.Context, userID int64, minFollowers int64) ([]int64, error) {
+	query := `
+		SELECT f.following_id
+		FROM follows f
+		WHERE f.follower_id = ?
+		  AND (SELECT COUNT(*) FROM follows c WHERE c.following_id = f.following_id) > ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, minFollowers)
+	if err != nil {
+		return nil, fmt.Errorf("error getting high-follower accounts: %w", err)
+	}
 	defer rows.Close()
 
-	var followers []*model.User
+	var ids []int64
 	for rows.Next() {
-		user := &model.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Username,
-			&user.Email,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning follower: %w", err)
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning following id: %w", err)
 		}
-		followers = append(followers, user)
+		ids = append(ids, id)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating followers: %w", err)
+		return nil, fmt.Errorf("error iterating following ids: %w", err)
 	}
 
-	return followers, nil
+	return ids, nil
 }
 
-func (r *followRepository) GetFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+// GetFollowedAmong devuelve los usuarios de userIDs que followerID sigue
+func (r *followRepository) GetFollowedAmong(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
 	query := `
-		SELECT u.id, u.username, u.email, u.created_at, u.updated_at
-		FROM users u
-		JOIN follows f ON u.id = f.following_id
-		WHERE f.follower_id = ?
-		ORDER BY f.created_at DESC
-		LIMIT ? OFFSET ?
+		SELECT following_id
+		FROM follows
+		WHERE follower_id = ? AND following_id IN (` + placeholders(len(userIDs)) + `)
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, append([]any{followerID}, int64Args(userIDs)...)...)
 	if err != nil {
-		return nil, fmt.Errorf("error getting following: %w", err)
+		return nil, fmt.Errorf("error getting followed users: %w", err)
 	}
 	defer rows.Close()
 
-	var following []*model.User
+	var ids []int64
 	for rows.Next() {
-		user := &model.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Username,
-			&user.Email,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning following: %w", err)
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning followed user id: %w", err)
 		}
-		following = append(following, user)
+		ids = append(ids, id)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating following: %w", err)
+		return nil, fmt.Errorf("error iterating followed user ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CreateRequest registra un pedido de follow pendiente hacia una cuenta protegida
+func (r *followRepository) CreateRequest(ctx context.Context, requesterID, targetID int64) error {
+	query := `
+		INSERT INTO follow_requests (requester_id, target_id, created_at)
+		VALUES (?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, requesterID, targetID, time.Now().Truncate(time.Second))
+	if err != nil {
+		return fmt.Errorf("error creating follow request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *followRepository) RequestExists(ctx context.Context, requesterID, targetID int64) (bool, error) {
+	query := `SELECT COUNT(*) FROM follow_requests WHERE requester_id = ? AND target_id = ?`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, requesterID, targetID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking follow request existence: %w", err)
 	}
 
-	return following, nil
+	return count > 0, nil
+}
+
+// DeleteRequest elimina un pedido de follow pendiente, ya sea porque se rechazó o se canceló
+func (r *followRepository) DeleteRequest(ctx context.Context, requesterID, targetID int64) error {
+	query := `DELETE FROM follow_requests WHERE requester_id = ? AND target_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error deleting follow request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("follow request not found")
+	}
+
+	return nil
+}
+
+// GetRequests obtiene una página de los usuarios con pedidos de follow pendientes hacia la cuenta,
+// del pedido más reciente al más antiguo
+func (r *followRepository) GetRequests(ctx context.Context, targetID int64, page model.PageQuery) (*model.UserPage, error) {
+	conditions, args := cursorConditions("fr", page)
+	query := `
+		SELECT ` + userColumns + `, fr.created_at, fr.id
+		FROM users u
+		JOIN follow_requests fr ON u.id = fr.requester_id
+		WHERE fr.target_id = ? AND u.deleted_at IS NULL` + conditions + `
+		ORDER BY fr.created_at DESC, fr.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{targetID}, args...), page.Limit)
+	users, err := queryUserPage(ctx, r.db, page.Limit, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting follow requests: %w", err)
+	}
+
+	return users, nil
+}
+
+// ApproveRequest convierte el pedido pendiente en un follow en una sola transacción. Si el follow
+// ya existía (la cuenta se desprotegió y el usuario la siguió) solo se elimina el pedido.
+func (r *followRepository) ApproveRequest(ctx context.Context, requesterID, targetID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM follow_requests WHERE requester_id = ? AND target_id = ?`, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error deleting follow request: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("follow request not found")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT IGNORE INTO follows (follower_id, following_id, created_at)
+		VALUES (?, ?, ?)
+	`, requesterID, targetID, time.Now().Truncate(time.Second))
+	if err != nil {
+		return fmt.Errorf("error creating follow: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
 }
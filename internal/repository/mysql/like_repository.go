@@ -0,0 +1,261 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"strings"
+	"time"
+)
+
+type likeRepository struct {
+	db *sql.DB
+}
+
+// NewLikeRepository crea una nueva instancia del repositorio de likes
+func NewLikeRepository(db *sql.DB) *likeRepository {
+	return &likeRepository{db: db}
+}
+
+// Create registra un like y actualiza el contador del tweet en la misma transacción.
+// Devuelve false si el usuario ya había dado like al tweet.
+func (r *likeRepository) Create(ctx context.Context, like *model.Like) (bool, error) {
+	like.CreatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// INSERT IGNORE apoyado en unique_like evita likes duplicados ante requests concurrentes
+	result, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO likes (user_id, tweet_id, created_at)
+		VALUES (?, ?, ?)
+	`, like.UserID, like.TweetID, like.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("error creating like: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("error getting last insert id: %w", err)
+	}
+	like.ID = id
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tweet_stats (tweet_id, like_count)
+		VALUES (?, 1)
+		ON DUPLICATE KEY UPDATE like_count = like_count + 1
+	`, like.TweetID)
+	if err != nil {
+		return false, fmt.Errorf("error incrementing like count: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("error committing like: %w", err)
+	}
+
+	return true, nil
+}
+
+// Delete elimina un like y decrementa el contador del tweet en la misma transacción
+func (r *likeRepository) Delete(ctx context.Context, userID, tweetID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM likes
+		WHERE user_id = ? AND tweet_id = ?
+	`, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("error deleting like: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("like not found")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tweet_stats
+		SET like_count = like_count - 1
+		WHERE tweet_id = ? AND like_count > 0
+	`, tweetID)
+	if err != nil {
+		return fmt.Errorf("error decrementing like count: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing unlike: %w", err)
+	}
+
+	return nil
+}
+
+// GetLikers obtiene los usuarios que dieron like a un tweet, del más reciente al más antiguo
+func (r *likeRepository) GetLikers(ctx context.Context, tweetID int64, limit, offset int) ([]*model.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users u
+		JOIN likes l ON u.id = l.user_id
+		WHERE l.tweet_id = ? AND u.deleted_at IS NULL
+		ORDER BY l.created_at DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tweetID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting likers: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*model.User
+	for rows.Next() {
+		user := &model.User{}
+		if err := scanUser(rows, user); err != nil {
+			return nil, fmt.Errorf("error scanning liker: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating likers: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetLikeCounts obtiene la cantidad de likes de varios tweets en una sola consulta
+func (r *likeRepository) GetLikeCounts(ctx context.Context, tweetIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT tweet_id, like_count
+		FROM tweet_stats
+		WHERE tweet_id IN (` + placeholders(len(tweetIDs)) + `)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, int64Args(tweetIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting like counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID, count int64
+		if err := rows.Scan(&tweetID, &count); err != nil {
+			return nil, fmt.Errorf("error scanning like count: %w", err)
+		}
+		counts[tweetID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating like counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetLikedTweetIDs indica cuáles de los tweets dados recibieron like del usuario
+func (r *likeRepository) GetLikedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+	liked := make(map[int64]bool, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return liked, nil
+	}
+
+	query := `
+		SELECT tweet_id
+		FROM likes
+		WHERE user_id = ? AND tweet_id IN (` + placeholders(len(tweetIDs)) + `)
+	`
+
+	args := append([]any{userID}, int64Args(tweetIDs)...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting liked tweets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID int64
+		if err := rows.Scan(&tweetID); err != nil {
+			return nil, fmt.Errorf("error scanning liked tweet: %w", err)
+		}
+		liked[tweetID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating liked tweets: %w", err)
+	}
+
+	return liked, nil
+}
+
+// GetByUserID obtiene una página de los likes que dio un usuario, del más reciente al más antiguo
+func (r *likeRepository) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Like, error) {
+	conditions, args := cursorConditions("l", page)
+	query := `
+		SELECT l.id, l.user_id, l.tweet_id, l.created_at
+		FROM likes l
+		WHERE l.user_id = ?` + conditions + `
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{userID}, args...), page.Limit)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user likes: %w", err)
+	}
+	defer rows.Close()
+
+	var likes []*model.Like
+	for rows.Next() {
+		like := &model.Like{}
+		if err := rows.Scan(&like.ID, &like.UserID, &like.TweetID, &like.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning like: %w", err)
+		}
+		likes = append(likes, like)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating likes: %w", err)
+	}
+
+	return likes, nil
+}
+
+// placeholders genera la lista "?, ?, ..." para una cláusula IN con n valores
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// int64Args convierte una lista de IDs en argumentos para una consulta
+func int64Args(ids []int64) []any {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
@@ -0,0 +1,299 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+// listColumns son las columnas de una lista con la cantidad de miembros y suscriptores, para
+// seleccionar desde lists con el alias l y escanear con scanList
+const listColumns = `l.id, l.owner_id, l.name, l.description, l.private, l.created_at, l.updated_at,
+		(SELECT COUNT(*) FROM list_members lm WHERE lm.list_id = l.id),
+		(SELECT COUNT(*) FROM list_subscriptions ls WHERE ls.list_id = l.id)`
+
+// scanList escanea una fila seleccionada con listColumns
+func scanList(row rowScanner) (*model.List, error) {
+	list := &model.List{}
+	err := row.Scan(
+		&list.ID,
+		&list.OwnerID,
+		&list.Name,
+		&list.Description,
+		&list.Private,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.MemberCount,
+		&list.SubscriberCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+type listRepository struct {
+	db *sql.DB
+}
+
+// NewListRepository crea una nueva instancia del repositorio de listas
+func NewListRepository(db *sql.DB) *listRepository {
+	return &listRepository{db: db}
+}
+
+func (r *listRepository) Create(ctx context.Context, list *model.List) error {
+	now := time.Now().Truncate(time.Second)
+	list.CreatedAt = now
+	list.UpdatedAt = now
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO lists (owner_id, name, description, private, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, list.OwnerID, list.Name, list.Description, list.Private, list.CreatedAt, list.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating list: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error getting last insert id: %w", err)
+	}
+	list.ID = id
+
+	return nil
+}
+
+func (r *listRepository) GetByID(ctx context.Context, id int64) (*model.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists l WHERE l.id = ?`
+
+	list, err := scanList(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("list %w: %d", repository.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("error getting list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Update guarda el nombre, la descripción y la visibilidad de la lista
+func (r *listRepository) Update(ctx context.Context, list *model.List) error {
+	list.UpdatedAt = time.Now().Truncate(time.Second)
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE lists SET name = ?, description = ?, private = ?, updated_at = ?
+		WHERE id = ?
+	`, list.Name, list.Description, list.Private, list.UpdatedAt, list.ID)
+	if err != nil {
+		return fmt.Errorf("error updating list: %w", err)
+	}
+
+	return nil
+}
+
+// Delete elimina la lista; sus miembros y suscripciones se eliminan en cascada
+func (r *listRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM lists WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("list %w: %d", repository.ErrNotFound, id)
+	}
+
+	return nil
+}
+
+// GetByOwner obtiene las listas del usuario, de la más reciente a la más antigua
+func (r *listRepository) GetByOwner(ctx context.Context, ownerID int64, includePrivate bool) ([]*model.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists l WHERE l.owner_id = ?`
+	if !includePrivate {
+		query += ` AND l.private = FALSE`
+	}
+	query += ` ORDER BY l.created_at DESC, l.id DESC`
+
+	return r.queryLists(ctx, query, ownerID)
+}
+
+// GetSubscribed obtiene las listas públicas a las que el usuario está suscripto, de la suscripción
+// más reciente a la más antigua. Las que su dueño volvió privadas se omiten.
+func (r *listRepository) GetSubscribed(ctx context.Context, userID int64) ([]*model.List, error) {
+	query := `
+		SELECT ` + listColumns + `
+		FROM lists l
+		JOIN list_subscriptions s ON s.list_id = l.id
+		WHERE s.user_id = ? AND l.private = FALSE
+		ORDER BY s.created_at DESC, s.id DESC
+	`
+
+	return r.queryLists(ctx, query, userID)
+}
+
+func (r *listRepository) queryLists(ctx context.Context, query string, args ...any) ([]*model.List, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting lists: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []*model.List
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// AddMember agrega la cuenta a la lista. Devuelve false si ya era miembro.
+func (r *listRepository) AddMember(ctx context.Context, listID, userID int64) (bool, error) {
+	// INSERT IGNORE apoyado en unique_list_member evita miembros duplicados ante requests concurrentes
+	result, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO list_members (list_id, user_id, created_at)
+		VALUES (?, ?, ?)
+	`, listID, userID, time.Now().Truncate(time.Second))
+	if err != nil {
+		return false, fmt.Errorf("error adding list member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *listRepository) RemoveMember(ctx context.Context, listID, userID int64) error {
+	query := `DELETE FROM list_members WHERE list_id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, listID, userID)
+	if err != nil {
+		return fmt.Errorf("error removing list member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("list member %w: %d", repository.ErrNotFound, userID)
+	}
+
+	return nil
+}
+
+// GetMembers obtiene una página de los miembros de la lista, del agregado más recientemente al más antiguo
+func (r *listRepository) GetMembers(ctx context.Context, listID int64, page model.PageQuery) (*model.UserPage, error) {
+	conditions, args := cursorConditions("m", page)
+	query := `
+		SELECT ` + userColumns + `, m.created_at, m.id
+		FROM users u
+		JOIN list_members m ON u.id = m.user_id
+		WHERE m.list_id = ? AND u.deleted_at IS NULL` + conditions + `
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{listID}, args...), page.Limit)
+	users, err := queryUserPage(ctx, r.db, page.Limit, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list members: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *listRepository) GetProtectedMemberIDs(ctx context.Context, listID int64) ([]int64, error) {
+	query := `
+		SELECT m.user_id
+		FROM list_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.list_id = ? AND u.protected = TRUE
+	`
+	return r.queryIDs(ctx, query, listID)
+}
+
+func (r *listRepository) GetListIDsByMember(ctx context.Context, userID int64) ([]int64, error) {
+	return r.queryIDs(ctx, `SELECT list_id FROM list_members WHERE user_id = ?`, userID)
+}
+
+func (r *listRepository) queryIDs(ctx context.Context, query string, args ...any) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning list membership: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating list memberships: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Subscribe suscribe al usuario a la lista. Devuelve false si ya estaba suscripto.
+func (r *listRepository) Subscribe(ctx context.Context, listID, userID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO list_subscriptions (list_id, user_id, created_at)
+		VALUES (?, ?, ?)
+	`, listID, userID, time.Now().Truncate(time.Second))
+	if err != nil {
+		return false, fmt.Errorf("error subscribing to list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *listRepository) Unsubscribe(ctx context.Context, listID, userID int64) error {
+	query := `DELETE FROM list_subscriptions WHERE list_id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, listID, userID)
+	if err != nil {
+		return fmt.Errorf("error unsubscribing from list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("list subscription %w: %d", repository.ErrNotFound, listID)
+	}
+
+	return nil
+}
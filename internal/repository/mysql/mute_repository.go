@@ -0,0 +1,177 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+type muteRepository struct {
+	db *sql.DB
+}
+
+// NewMuteRepository crea una nueva instancia del repositorio de cuentas y palabras silenciadas
+func NewMuteRepository(db *sql.DB) *muteRepository {
+	return &muteRepository{db: db}
+}
+
+// MuteUser silencia la cuenta. Si ya estaba silenciada, reemplaza el vencimiento.
+func (r *muteRepository) MuteUser(ctx context.Context, mute *model.MutedUser) error {
+	mute.CreatedAt = time.Now().Truncate(time.Second)
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO muted_users (user_id, muted_user_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE expires_at = VALUES(expires_at)
+	`, mute.UserID, mute.MutedUserID, mute.ExpiresAt, mute.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error muting user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *muteRepository) UnmuteUser(ctx context.Context, userID, mutedUserID int64) error {
+	query := `DELETE FROM muted_users WHERE user_id = ? AND muted_user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, userID, mutedUserID)
+	if err != nil {
+		return fmt.Errorf("error unmuting user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("muted user %w: %d", repository.ErrNotFound, mutedUserID)
+	}
+
+	return nil
+}
+
+// GetMutedUsers obtiene las cuentas que el usuario tiene silenciadas y cuyo silencio no venció
+// antes de now, con su perfil público, de la más reciente a la más antigua
+func (r *muteRepository) GetMutedUsers(ctx context.Context, userID int64, now time.Time) ([]*model.MutedUser, error) {
+	query := `
+		SELECT ` + userColumns + `, m.expires_at, m.created_at
+		FROM muted_users m
+		JOIN users u ON u.id = m.muted_user_id AND u.deleted_at IS NULL
+		WHERE m.user_id = ? AND (m.expires_at IS NULL OR m.expires_at > ?)
+		ORDER BY m.created_at DESC, m.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("error getting muted users: %w", err)
+	}
+	defer rows.Close()
+
+	var mutes []*model.MutedUser
+	for rows.Next() {
+		user := &model.User{}
+		mute := &model.MutedUser{UserID: userID, User: user}
+		var expiresAt sql.NullTime
+		if err := scanUser(rows, user, &expiresAt, &mute.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning muted user: %w", err)
+		}
+		mute.MutedUserID = user.ID
+		if expiresAt.Valid {
+			mute.ExpiresAt = &expiresAt.Time
+		}
+		mutes = append(mutes, mute)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating muted users: %w", err)
+	}
+
+	return mutes, nil
+}
+
+// AddWord silencia la palabra. Devuelve false si el usuario ya la tenía silenciada.
+func (r *muteRepository) AddWord(ctx context.Context, word *model.MutedWord) (bool, error) {
+	word.CreatedAt = time.Now().Truncate(time.Second)
+
+	// INSERT IGNORE apoyado en unique_muted_word evita palabras duplicadas ante requests concurrentes
+	result, err := r.db.ExecContext(ctx, `
+		INSERT IGNORE INTO muted_words (user_id, phrase, created_at)
+		VALUES (?, ?, ?)
+	`, word.UserID, word.Phrase, word.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("error muting word: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("error getting last insert id: %w", err)
+	}
+	word.ID = id
+
+	return true, nil
+}
+
+// DeleteWord elimina una palabra silenciada del usuario
+func (r *muteRepository) DeleteWord(ctx context.Context, userID, wordID int64) error {
+	query := `DELETE FROM muted_words WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, wordID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting muted word: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("muted word %w: %d", repository.ErrNotFound, wordID)
+	}
+
+	return nil
+}
+
+// GetWords obtiene las palabras silenciadas del usuario, de la más reciente a la más antigua
+func (r *muteRepository) GetWords(ctx context.Context, userID int64) ([]*model.MutedWord, error) {
+	query := `
+		SELECT id, user_id, phrase, created_at
+		FROM muted_words
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting muted words: %w", err)
+	}
+	defer rows.Close()
+
+	var words []*model.MutedWord
+	for rows.Next() {
+		word := &model.MutedWord{}
+		if err := rows.Scan(&word.ID, &word.UserID, &word.Phrase, &word.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning muted word: %w", err)
+		}
+		words = append(words, word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating muted words: %w", err)
+	}
+
+	return words, nil
+}
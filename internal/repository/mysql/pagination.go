@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"time"
+)
+
+// cursorConditions genera las condiciones SQL que limitan una consulta a la página dada,
+// comparando (created_at, id) de la tabla con el alias indicado. Permite paginar por
+// keyset en lugar de usar OFFSET, que se degrada con páginas profundas.
+func cursorConditions(alias string, page model.PageQuery) (string, []any) {
+	var conditions string
+	var args []any
+
+	if page.Before != nil {
+		createdAt := time.Unix(page.Before.CreatedAt.Unix(), 0)
+		conditions += ` AND (` + alias + `.created_at < ? OR (` + alias + `.created_at = ? AND ` + alias + `.id < ?))`
+		args = append(args, createdAt, createdAt, page.Before.ID)
+	}
+
+	if page.Since != nil {
+		createdAt := time.Unix(page.Since.CreatedAt.Unix(), 0)
+		conditions += ` AND (` + alias + `.created_at > ? OR (` + alias + `.created_at = ? AND ` + alias + `.id > ?))`
+		args = append(args, createdAt, createdAt, page.Since.ID)
+	}
+
+	return conditions, args
+}
+
+// queryUserPage ejecuta una consulta de usuarios que además selecciona created_at e id de la relación
+// (follow, pedido o miembro de una lista), con los que arma los cursores de la página
+func queryUserPage(ctx context.Context, db *sql.DB, limit int, query string, args ...any) (*model.UserPage, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &model.UserPage{}
+	var cursor *model.Cursor
+	for rows.Next() {
+		user := &model.User{}
+		var relatedAt time.Time
+		var relationID int64
+		if err := scanUser(rows, user, &relatedAt, &relationID); err != nil {
+			return nil, fmt.Errorf("error scanning user: %w", err)
+		}
+
+		cursor = model.NewCursor(relatedAt, relationID)
+		if page.SinceCursor == nil {
+			page.SinceCursor = cursor
+		}
+		page.Users = append(page.Users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	// Una página completa indica que puede haber más elementos
+	if len(page.Users) == limit {
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"strings"
+	"unicode/utf8"
+)
+
+type searchRepository struct {
+	tweets *tweetRepository
+}
+
+// NewSearchRepository crea una nueva instancia del repositorio de búsqueda sobre el índice
+// FULLTEXT de tweets.content
+func NewSearchRepository(db *sql.DB) *searchRepository {
+	return &searchRepository{tweets: NewTweetRepository(db)}
+}
+
+// SearchTweets busca en el contenido con MATCH ... AGAINST en modo booleano, exigiendo cada palabra
+// indexable y frase, y aplica el resto de los filtros como condiciones. Los retweets no tienen contenido
+// propio, así que se excluyen, al igual que los tweets de cuentas protegidas que viewerID no sigue.
+func (r *searchRepository) SearchTweets(ctx context.Context, viewerID int64, query *model.SearchQuery, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	conditions := []string{"t.retweet_of_tweet_id IS NULL"}
+	var args []any
+
+	if against := booleanSearchExpression(query); against != "" {
+		conditions = append(conditions, "MATCH(t.content) AGAINST(? IN BOOLEAN MODE)")
+		args = append(args, against)
+	}
+	if query.From != "" {
+		conditions = append(conditions, "u.username = ?")
+		args = append(args, query.From)
+	}
+	for _, tag := range query.Hashtags {
+		conditions = append(conditions, `t.id IN (
+			SELECT th.tweet_id
+			FROM tweet_hashtags th
+			JOIN hashtags h ON h.id = th.hashtag_id
+			WHERE h.tag = ?
+		)`)
+		args = append(args, tag)
+	}
+	if query.Since != nil {
+		conditions = append(conditions, "t.created_at >= ?")
+		args = append(args, *query.Since)
+	}
+	if query.Until != nil {
+		conditions = append(conditions, "t.created_at < ?")
+		args = append(args, *query.Until)
+	}
+
+	visibility, visibilityArgs := visibleTo(viewerID)
+	args = append(args, visibilityArgs...)
+
+	cursor, cursorArgs := cursorConditions("t", page)
+	sqlQuery := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE ` + strings.Join(conditions, " AND ") + visibility + cursor + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append(args, cursorArgs...), page.Limit)
+	tweets, err := r.tweets.queryTweetsWithUser(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error searching tweets: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// ftMinTokenSize es el innodb_ft_min_token_size por defecto: las palabras más cortas no se indexan
+const ftMinTokenSize = 3
+
+// ftStopwords es la lista de stopwords por defecto de InnoDB (INNODB_FT_DEFAULT_STOPWORD), que
+// tampoco se indexan
+var ftStopwords = map[string]bool{
+	"a": true, "about": true, "an": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "com": true, "de": true, "en": true, "for": true, "from": true, "how": true,
+	"i": true, "in": true, "is": true, "it": true, "la": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "this": true, "to": true, "was": true, "what": true, "when": true,
+	"where": true, "who": true, "will": true, "with": true, "und": true, "www": true,
+}
+
+// booleanSearchExpression arma la expresión de MATCH ... AGAINST en modo booleano: cada palabra
+// indexable y cada frase (entre comillas) son obligatorias. Las palabras que el índice no guarda
+// quedan opcionales, porque exigirlas haría que la búsqueda no encuentre nada.
+func booleanSearchExpression(query *model.SearchQuery) string {
+	parts := make([]string, 0, len(query.Terms)+len(query.Phrases))
+	for _, term := range query.Terms {
+		if !indexedWord(term) {
+			parts = append(parts, term)
+			continue
+		}
+		parts = append(parts, "+"+term)
+	}
+	for _, phrase := range query.Phrases {
+		parts = append(parts, `+"`+phrase+`"`)
+	}
+	return strings.Join(parts, " ")
+}
+
+// indexedWord indica si el índice FULLTEXT guarda la palabra
+func indexedWord(word string) bool {
+	return utf8.RuneCountInString(word) >= ftMinTokenSize && !ftStopwords[strings.ToLower(word)]
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"microx/internal/model"
+	"testing"
+)
+
+func TestBooleanSearchExpression(t *testing.T) {
+	tests := map[string]struct {
+		query    *model.SearchQuery
+		expected string
+	}{
+		"exige las palabras y frases": {
+			query:    &model.SearchQuery{Terms: []string{"moto", "roja"}, Phrases: []string{"hola mundo"}},
+			expected: `+moto +roja +"hola mundo"`,
+		},
+		"no exige las palabras cortas": {
+			query:    &model.SearchQuery{Terms: []string{"mi", "moto"}},
+			expected: "mi +moto",
+		},
+		"no exige las stopwords": {
+			query:    &model.SearchQuery{Terms: []string{"The", "moto", "de", "axel"}},
+			expected: "The +moto de +axel",
+		},
+		"cuenta caracteres y no bytes": {
+			query:    &model.SearchQuery{Terms: []string{"ñu", "año"}},
+			expected: "ñu +año",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := booleanSearchExpression(tt.query); got != tt.expected {
+				t.Errorf("esperaba %q, obtuve %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,9 +5,116 @@ import (
 	"database/sql"
 	"fmt"
 	"microx/internal/model"
+	"microx/internal/repository"
+	"strings"
 	"time"
 )
 
+// tweetWithUserColumns son las columnas que se seleccionan para construir un TweetWithUser
+// (alias t para tweets y u para users, o/ou para el tweet retuiteado o citado y su autor).
+// Deben mantenerse en sincronía con scanTweetWithUser.
+const tweetWithUserColumns = `t.id, t.user_id, t.content, t.in_reply_to_tweet_id, t.conversation_id,
+		t.retweet_of_tweet_id, t.quoted_tweet_id, t.created_at, t.updated_at,
+		u.username, u.display_name, u.avatar_url,
+		o.id, o.user_id, o.content, o.created_at, o.updated_at,
+		ou.username, ou.display_name, ou.avatar_url`
+
+// tweetWithUserFrom es la cláusula FROM que acompaña a tweetWithUserColumns. Excluye los tweets de
+// las cuentas dadas de baja, y no embebe el tweet retuiteado o citado si su autor se dio de baja.
+const tweetWithUserFrom = `FROM tweets t
+		JOIN users u ON t.user_id = u.id AND u.deleted_at IS NULL
+		LEFT JOIN (tweets o
+			JOIN users ou ON o.user_id = ou.id AND ou.deleted_at IS NULL
+		) ON o.id = COALESCE(t.retweet_of_tweet_id, t.quoted_tweet_id)`
+
+// visibleTo genera la condición que excluye los tweets de cuentas protegidas (alias u) que viewerID
+// no sigue y los de cuentas que bloquearon a viewerID, incluidos los retweets y citas de sus tweets
+// (alias o). Sin usuario autenticado (viewerID 0) solo quedan los de cuentas públicas.
+func visibleTo(viewerID int64) (string, []any) {
+	condition := ` AND (u.protected = FALSE OR t.user_id = ? OR EXISTS (
+			SELECT 1 FROM follows vf WHERE vf.follower_id = ? AND vf.following_id = t.user_id
+		)) AND NOT EXISTS (
+			SELECT 1 FROM blocks vb WHERE vb.blocked_id = ? AND vb.blocker_id IN (t.user_id, COALESCE(o.user_id, t.user_id))
+		)`
+	return condition, []any{viewerID, viewerID, viewerID}
+}
+
+// rowScanner abstrae *sql.Row y *sql.Rows para reutilizar el escaneo de filas
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTweetWithUser escanea una fila seleccionada con tweetWithUserColumns
+func scanTweetWithUser(row rowScanner) (*model.TweetWithUser, error) {
+	tweet := &model.TweetWithUser{}
+	var inReplyTo, conversationID, retweetOf, quoted sql.NullInt64
+	var refID, refUserID sql.NullInt64
+	var refContent, refUsername, refDisplayName, refAvatarURL sql.NullString
+	var refCreatedAt, refUpdatedAt sql.NullTime
+
+	err := row.Scan(
+		&tweet.ID,
+		&tweet.UserID,
+		&tweet.Content,
+		&inReplyTo,
+		&conversationID,
+		&retweetOf,
+		&quoted,
+		&tweet.CreatedAt,
+		&tweet.UpdatedAt,
+		&tweet.Username,
+		&tweet.DisplayName,
+		&tweet.AvatarURL,
+		&refID,
+		&refUserID,
+		&refContent,
+		&refCreatedAt,
+		&refUpdatedAt,
+		&refUsername,
+		&refDisplayName,
+		&refAvatarURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if inReplyTo.Valid {
+		tweet.InReplyToTweetID = &inReplyTo.Int64
+	}
+	if conversationID.Valid {
+		tweet.ConversationID = &conversationID.Int64
+	}
+	if retweetOf.Valid {
+		tweet.RetweetOfTweetID = &retweetOf.Int64
+	}
+	if quoted.Valid {
+		tweet.QuotedTweetID = &quoted.Int64
+	}
+
+	// Embeber el tweet referenciado con la información de su autor
+	if refID.Valid {
+		referenced := &model.TweetWithUser{
+			Tweet: model.Tweet{
+				ID:        refID.Int64,
+				UserID:    refUserID.Int64,
+				Content:   refContent.String,
+				CreatedAt: refCreatedAt.Time,
+				UpdatedAt: refUpdatedAt.Time,
+			},
+			Username:    refUsername.String,
+			DisplayName: refDisplayName.String,
+			AvatarURL:   refAvatarURL.String,
+		}
+		if retweetOf.Valid {
+			tweet.RetweetedTweet = referenced
+		} else {
+			tweet.QuotedTweet = referenced
+		}
+	}
+
+	return tweet, nil
+}
+
 type tweetRepository struct {
 	db *sql.DB
 }
@@ -17,19 +124,33 @@ func NewTweetRepository(db *sql.DB) *tweetRepository {
 	return &tweetRepository{db: db}
 }
 
+// Create guarda el tweet, sus menciones y sus hashtags en la misma transacción
 func (r *tweetRepository) Create(ctx context.Context, tweet *model.Tweet) error {
-	now := time.Now()
+	// MySQL guarda los TIMESTAMP con precisión de segundos; se trunca para que el valor en
+	// memoria (y los cursores derivados de él) coincida con el persistido
+	now := time.Now().Truncate(time.Second)
 	tweet.CreatedAt = now
 	tweet.UpdatedAt = now
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO tweets (user_id, content, created_at, updated_at)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO tweets (user_id, content, in_reply_to_tweet_id, conversation_id,
+			retweet_of_tweet_id, quoted_tweet_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		tweet.UserID,
 		tweet.Content,
+		tweet.InReplyToTweetID,
+		tweet.ConversationID,
+		tweet.RetweetOfTweetID,
+		tweet.QuotedTweetID,
 		tweet.CreatedAt,
 		tweet.UpdatedAt,
 	)
@@ -47,64 +168,246 @@ func (r *tweetRepository) Create(ctx context.Context, tweet *model.Tweet) error
 	fmt.Println("ID creado del tweet: ", id)
 
 	tweet.ID = id
+
+	if err = insertMentions(ctx, tx, tweet.ID, tweet.Mentions); err != nil {
+		return err
+	}
+
+	if err = insertHashtags(ctx, tx, tweet.ID, tweet.Hashtags); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tweet: %w", err)
+	}
+
 	return nil
 }
 
 func (r *tweetRepository) GetByID(ctx context.Context, id int64) (*model.TweetWithUser, error) {
 	query := `
-		SELECT t.id, t.user_id, t.content, t.created_at, t.updated_at, u.username
-		FROM tweets t
-		JOIN users u ON t.user_id = u.id
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
 		WHERE t.id = ?
 	`
 
-	tweet := &model.TweetWithUser{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&tweet.ID,
-		&tweet.UserID,
-		&tweet.Content,
-		&tweet.CreatedAt,
-		&tweet.UpdatedAt,
-		&tweet.Username,
-	)
+	tweet, err := scanTweetWithUser(r.db.QueryRowContext(ctx, query, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("tweet not found: %d", id)
+			return nil, fmt.Errorf("tweet %w: %d", repository.ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("error getting tweet: %w", err)
 	}
 
+	if err = r.attachMentions(ctx, []*model.TweetWithUser{tweet}); err != nil {
+		return nil, err
+	}
+
 	return tweet, nil
 }
 
-func (r *tweetRepository) GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+// GetByIDs obtiene varios tweets por ID en una sola consulta, sin un orden particular
+func (r *tweetRepository) GetByIDs(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.id IN (` + placeholders(len(ids)) + `)
+	`
+
+	tweets, err := r.queryTweetsWithUser(ctx, query, int64Args(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweets by ids: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetByUserID obtiene una página de los tweets de un usuario, del más reciente al más antiguo
+func (r *tweetRepository) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	conditions, args := cursorConditions("t", page)
 	query := `
-		SELECT t.id, t.user_id, t.content, t.created_at, t.updated_at, u.username
-		FROM tweets t
-		JOIN users u ON t.user_id = u.id
-		WHERE t.user_id = ?
-		ORDER BY t.created_at DESC
-		LIMIT ? OFFSET ?
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.user_id = ?` + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	args = append(append([]any{userID}, args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error getting user tweets: %w", err)
 	}
+
+	return tweets, nil
+}
+
+// GetByUserIDs obtiene una página de los tweets de varios usuarios, del más reciente al más antiguo
+func (r *tweetRepository) GetByUserIDs(ctx context.Context, userIDs []int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	conditions, args := cursorConditions("t", page)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.user_id IN (` + placeholders(len(userIDs)) + `)` + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append(int64Args(userIDs), args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users tweets: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetTimeline obtiene una página de los tweets de los usuarios seguidos, del más reciente al más antiguo
+func (r *tweetRepository) GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	conditions, args := cursorConditions("t", page)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.user_id IN (
+			SELECT following_id 
+			FROM follows 
+			WHERE follower_id = ?
+		)` + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{userID}, args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting timeline: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetListTimeline obtiene una página de los tweets de los miembros de una lista, del más reciente al
+// más antiguo
+func (r *tweetRepository) GetListTimeline(ctx context.Context, listID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	conditions, args := cursorConditions("t", page)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.user_id IN (
+			SELECT user_id
+			FROM list_members
+			WHERE list_id = ?
+		)` + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append([]any{listID}, args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list timeline: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetMentions obtiene una página de los tweets que mencionan al usuario, del más reciente al más antiguo
+func (r *tweetRepository) GetMentions(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	visibility, visibilityArgs := visibleTo(userID)
+	conditions, args := cursorConditions("t", page)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.id IN (
+			SELECT tweet_id
+			FROM tweet_mentions
+			WHERE user_id = ?
+		)` + visibility + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append(append([]any{userID}, visibilityArgs...), args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mentions: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetByHashtag obtiene una página de los tweets que usan un hashtag, del más reciente al más
+// antiguo, que viewerID puede ver
+func (r *tweetRepository) GetByHashtag(ctx context.Context, viewerID int64, tag string, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	visibility, visibilityArgs := visibleTo(viewerID)
+	conditions, args := cursorConditions("t", page)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.id IN (
+			SELECT th.tweet_id
+			FROM tweet_hashtags th
+			JOIN hashtags h ON h.id = th.hashtag_id
+			WHERE h.tag = ?
+		)` + visibility + conditions + `
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT ?
+	`
+
+	args = append(append(append([]any{tag}, visibilityArgs...), args...), page.Limit)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hashtag tweets: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// GetIDsByUserID obtiene los IDs de todos los tweets y retweets de un usuario, incluso si la
+// cuenta está dada de baja
+func (r *tweetRepository) GetIDsByUserID(ctx context.Context, userID int64) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM tweets WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning tweet id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tweet ids: %w", err)
+	}
+
+	return ids, nil
+}
+
+// queryTweetsWithUser ejecuta una consulta que selecciona tweetWithUserColumns y escanea sus filas
+func (r *tweetRepository) queryTweetsWithUser(ctx context.Context, query string, args ...any) ([]*model.TweetWithUser, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var tweets []*model.TweetWithUser
 	for rows.Next() {
-		tweet := &model.TweetWithUser{}
-		err := rows.Scan(
-			&tweet.ID,
-			&tweet.UserID,
-			&tweet.Content,
-			&tweet.CreatedAt,
-			&tweet.UpdatedAt,
-			&tweet.Username,
-		)
+		tweet, err := scanTweetWithUser(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning tweet: %w", err)
 		}
@@ -115,49 +418,313 @@ func (r *tweetRepository) GetByUserID(ctx context.Context, userID int64, limit,
 		return nil, fmt.Errorf("error iterating tweets: %w", err)
 	}
 
+	if err = r.attachMentions(ctx, tweets); err != nil {
+		return nil, err
+	}
+
 	return tweets, nil
 }
 
-func (r *tweetRepository) GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+// attachMentions completa las menciones de los tweets, y de los tweets que retuitean o citan,
+// con una única consulta
+func (r *tweetRepository) attachMentions(ctx context.Context, tweets []*model.TweetWithUser) error {
+	byID := make(map[int64][]*model.TweetWithUser)
+	var ids []int64
+	add := func(tweet *model.TweetWithUser) {
+		if tweet == nil {
+			return
+		}
+		if _, ok := byID[tweet.ID]; !ok {
+			ids = append(ids, tweet.ID)
+		}
+		byID[tweet.ID] = append(byID[tweet.ID], tweet)
+	}
+	for _, tweet := range tweets {
+		add(tweet)
+		add(tweet.RetweetedTweet)
+		add(tweet.QuotedTweet)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
 	query := `
-		SELECT t.id, t.user_id, t.content, t.created_at, t.updated_at, u.username
-		FROM tweets t
-		JOIN users u ON t.user_id = u.id
-		WHERE t.user_id IN (
-			SELECT following_id 
-			FROM follows 
-			WHERE follower_id = ?
-		)
-		ORDER BY t.created_at DESC
-		LIMIT ? OFFSET ?
+		SELECT m.tweet_id, m.user_id, u.username, m.start_offset, m.end_offset
+		FROM tweet_mentions m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.tweet_id IN (` + placeholders(len(ids)) + `)
+		ORDER BY m.tweet_id, m.start_offset
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, int64Args(ids)...)
 	if err != nil {
-		return nil, fmt.Errorf("error getting timeline: %w", err)
+		return fmt.Errorf("error getting mentions: %w", err)
 	}
 	defer rows.Close()
 
-	var tweets []*model.TweetWithUser
 	for rows.Next() {
-		tweet := &model.TweetWithUser{}
+		var tweetID int64
+		mention := &model.Mention{}
+		err := rows.Scan(&tweetID, &mention.UserID, &mention.Username, &mention.Start, &mention.End)
+		if err != nil {
+			return fmt.Errorf("error scanning mention: %w", err)
+		}
+		for _, tweet := range byID[tweetID] {
+			tweet.Mentions = append(tweet.Mentions, mention)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating mentions: %w", err)
+	}
+
+	return nil
+}
+
+// insertMentions guarda las menciones de un tweet dentro de la transacción dada
+func insertMentions(ctx context.Context, tx *sql.Tx, tweetID int64, mentions []*model.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(mentions)*4)
+	for _, mention := range mentions {
+		args = append(args, tweetID, mention.UserID, mention.Start, mention.End)
+	}
+
+	values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?), ", len(mentions)), ", ")
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO tweet_mentions (tweet_id, user_id, start_offset, end_offset)
+		VALUES `+values, args...)
+	if err != nil {
+		return fmt.Errorf("error creating mentions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tweetRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM tweets
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("error deleting tweet: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tweet %w: %d", repository.ErrNotFound, id)
+	}
+
+	return nil
+}
+
+// Update actualiza el contenido, las menciones y los hashtags de un tweet guardando la versión anterior en tweet_revisions
+func (r *tweetRepository) Update(ctx context.Context, tweet *model.Tweet) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Bloquear la fila para que ediciones concurrentes no pierdan revisiones
+	var previousContent string
+	var previousUpdatedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT content, updated_at
+		FROM tweets
+		WHERE id = ?
+		FOR UPDATE
+	`, tweet.ID).Scan(&previousContent, &previousUpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("tweet not found: %d", tweet.ID)
+		}
+		return fmt.Errorf("error getting tweet for update: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO tweet_revisions (tweet_id, content, created_at)
+		VALUES (?, ?, ?)
+	`, tweet.ID, previousContent, previousUpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating tweet revision: %w", err)
+	}
+
+	tweet.UpdatedAt = time.Now()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tweets
+		SET content = ?, updated_at = ?
+		WHERE id = ?
+	`, tweet.Content, tweet.UpdatedAt, tweet.ID)
+	if err != nil {
+		return fmt.Errorf("error updating tweet: %w", err)
+	}
+
+	// Las menciones y los hashtags se recalculan con el nuevo contenido
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tweet_mentions
+		WHERE tweet_id = ?
+	`, tweet.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting mentions: %w", err)
+	}
+
+	if err = insertMentions(ctx, tx, tweet.ID, tweet.Mentions); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tweet_hashtags
+		WHERE tweet_id = ?
+	`, tweet.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting hashtags: %w", err)
+	}
+
+	if err = insertHashtags(ctx, tx, tweet.ID, tweet.Hashtags); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing tweet update: %w", err)
+	}
+
+	return nil
+}
+
+// GetRevisions obtiene las versiones anteriores de un tweet, de la más reciente a la más antigua
+func (r *tweetRepository) GetRevisions(ctx context.Context, tweetID int64) ([]*model.TweetRevision, error) {
+	query := `
+		SELECT id, tweet_id, content, created_at
+		FROM tweet_revisions
+		WHERE tweet_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*model.TweetRevision
+	for rows.Next() {
+		revision := &model.TweetRevision{}
 		err := rows.Scan(
-			&tweet.ID,
-			&tweet.UserID,
-			&tweet.Content,
-			&tweet.CreatedAt,
-			&tweet.UpdatedAt,
-			&tweet.Username,
+			&revision.ID,
+			&revision.TweetID,
+			&revision.Content,
+			&revision.CreatedAt,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning tweet: %w", err)
+			return nil, fmt.Errorf("error scanning tweet revision: %w", err)
 		}
-		tweets = append(tweets, tweet)
+		revisions = append(revisions, revision)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating timeline: %w", err)
+		return nil, fmt.Errorf("error iterating tweet revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetReplies obtiene las respuestas de una conversación que viewerID puede ver, ordenadas cronológicamente
+func (r *tweetRepository) GetReplies(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+	visibility, visibilityArgs := visibleTo(viewerID)
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.conversation_id = ?` + visibility + `
+		ORDER BY t.created_at ASC, t.id ASC
+		LIMIT ? OFFSET ?
+	`
+
+	args := append(append([]any{conversationID}, visibilityArgs...), limit, offset)
+	tweets, err := r.queryTweetsWithUser(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting replies: %w", err)
 	}
 
 	return tweets, nil
 }
+
+// GetRetweet obtiene el retweet que un usuario hizo de un tweet
+func (r *tweetRepository) GetRetweet(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error) {
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.user_id = ? AND t.retweet_of_tweet_id = ?
+	`
+
+	tweet, err := scanTweetWithUser(r.db.QueryRowContext(ctx, query, userID, tweetID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("retweet not found: %d", tweetID)
+		}
+		return nil, fmt.Errorf("error getting retweet: %w", err)
+	}
+
+	if err = r.attachMentions(ctx, []*model.TweetWithUser{tweet}); err != nil {
+		return nil, err
+	}
+
+	return tweet, nil
+}
+
+// GetRetweets obtiene todos los retweets de un tweet
+func (r *tweetRepository) GetRetweets(ctx context.Context, tweetID int64) ([]*model.TweetWithUser, error) {
+	query := `
+		SELECT ` + tweetWithUserColumns + `
+		` + tweetWithUserFrom + `
+		WHERE t.retweet_of_tweet_id = ?
+	`
+
+	tweets, err := r.queryTweetsWithUser(ctx, query, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting retweets: %w", err)
+	}
+
+	return tweets, nil
+}
+
+// insertHashtags asocia un tweet a sus hashtags dentro de la transacción dada, creando los que no existan
+func insertHashtags(ctx context.Context, tx *sql.Tx, tweetID int64, hashtags []string) error {
+	if len(hashtags) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(hashtags))
+	for i, tag := range hashtags {
+		args[i] = tag
+	}
+
+	// INSERT IGNORE apoyado en el índice único de tag evita duplicados ante tweets concurrentes
+	values := strings.TrimSuffix(strings.Repeat("(?), ", len(hashtags)), ", ")
+	_, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO hashtags (tag)
+		VALUES `+values, args...)
+	if err != nil {
+		return fmt.Errorf("error creating hashtags: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT IGNORE INTO tweet_hashtags (tweet_id, hashtag_id)
+		SELECT ?, id
+		FROM hashtags
+		WHERE tag IN (`+placeholders(len(hashtags))+`)
+	`, append([]any{tweetID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("error creating tweet hashtags: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"microx/internal/model"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// openTestDB abre la base de MYSQL_TEST_DSN, con las migraciones ya aplicadas. Sin ella el test se
+// saltea.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN no está definida")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("error abriendo la base: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("error conectando a la base: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// createTestUser crea un usuario que se borra al terminar el test
+func createTestUser(t *testing.T, db *sql.DB, name string) *model.User {
+	t.Helper()
+
+	suffix := fmt.Sprintf("%s%d", name, time.Now().UnixNano()%1e9)
+	user := &model.User{Username: suffix, Email: suffix + "@test.local", PasswordHash: "x"}
+	if err := NewUserRepository(db).Create(context.Background(), user); err != nil {
+		t.Fatalf("error creando el usuario: %v", err)
+	}
+	t.Cleanup(func() { db.Exec(`DELETE FROM users WHERE id = ?`, user.ID) })
+
+	return user
+}
+
+func TestTweetRepository_ReferencedTweetOfDeletedAuthor(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := NewTweetRepository(db)
+
+	author := createTestUser(t, db, "autor")
+	sharer := createTestUser(t, db, "comparte")
+
+	original := &model.Tweet{UserID: author.ID, Content: "original"}
+	if err := repo.Create(ctx, original); err != nil {
+		t.Fatalf("error creando el tweet: %v", err)
+	}
+	retweet := &model.Tweet{UserID: sharer.ID, RetweetOfTweetID: &original.ID}
+	quote := &model.Tweet{UserID: sharer.ID, Content: "cita", QuotedTweetID: &original.ID}
+	for _, tweet := range []*model.Tweet{retweet, quote} {
+		if err := repo.Create(ctx, tweet); err != nil {
+			t.Fatalf("error creando el tweet: %v", err)
+		}
+	}
+
+	t.Run("embebe el tweet de una cuenta activa", func(t *testing.T) {
+		tweet, err := repo.GetByID(ctx, retweet.ID)
+		if err != nil || tweet.RetweetedTweet == nil || tweet.RetweetedTweet.Username != author.Username {
+			t.Errorf("esperaba el tweet original embebido, obtuve err: %v, tweet: %+v", err, tweet)
+		}
+	})
+
+	if err := NewUserRepository(db).SoftDelete(ctx, author.ID, time.Now()); err != nil {
+		t.Fatalf("error dando de baja al autor: %v", err)
+	}
+
+	t.Run("no embebe el tweet de una cuenta dada de baja", func(t *testing.T) {
+		tweets, err := repo.GetByUserID(ctx, sharer.ID, model.PageQuery{Limit: 10})
+		if err != nil || len(tweets) != 2 {
+			t.Fatalf("esperaba el retweet y la cita, obtuve err: %v, tweets: %+v", err, tweets)
+		}
+		for _, tweet := range tweets {
+			if tweet.RetweetedTweet != nil || tweet.QuotedTweet != nil {
+				t.Errorf("no esperaba el tweet de la cuenta dada de baja en %d, obtuve %+v", tweet.ID, tweet)
+			}
+		}
+	})
+}
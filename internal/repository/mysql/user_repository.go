@@ -5,9 +5,33 @@ import (
 	"database/sql"
 	"fmt"
 	"microx/internal/model"
+	"strings"
 	"time"
 )
 
+// userColumns son las columnas que se seleccionan para construir un User (alias u).
+// Deben mantenerse en sincronía con scanUser.
+const userColumns = `u.id, u.username, u.email, u.display_name, u.bio, u.location, u.website,
+		u.avatar_url, u.protected, u.created_at, u.updated_at`
+
+// scanUser escanea una fila seleccionada con userColumns, seguida de las columnas de extra
+func scanUser(row rowScanner, user *model.User, extra ...any) error {
+	dest := []any{
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.DisplayName,
+		&user.Bio,
+		&user.Location,
+		&user.Website,
+		&user.AvatarURL,
+		&user.Protected,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
 type userRepository struct {
 	db *sql.DB
 }
@@ -19,19 +43,13 @@ func NewUserRepository(db *sql.DB) *userRepository {
 
 func (r *userRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	query := `
-		SELECT id, username, email, created_at, updated_at 
-		FROM users 
-		WHERE id = ?
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.id = ? AND u.deleted_at IS NULL
 	`
 
 	user := &model.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	err := scanUser(r.db.QueryRowContext(ctx, query, id), user)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -43,19 +61,148 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*model.User, er
 	return user, nil
 }
 
+// GetByUsername obtiene un usuario por su username, incluyendo el hash de su contraseña
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	query := `
+		SELECT ` + userColumns + `, u.password_hash
+		FROM users u
+		WHERE u.username = ? AND u.deleted_at IS NULL
+	`
+
+	user := &model.User{}
+	err := scanUser(r.db.QueryRowContext(ctx, query, username), user, &user.PasswordHash)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found: %s", username)
+		}
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByUsernames obtiene varios usuarios por username en una sola consulta. La comparación no
+// distingue mayúsculas por la collation de la tabla.
+func (r *userRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*model.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.username IN (` + placeholders(len(usernames)) + `) AND u.deleted_at IS NULL
+	`
+
+	args := make([]any, len(usernames))
+	for i, username := range usernames {
+		args[i] = username
+	}
+
+	users, err := r.queryUsers(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users by username: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetByIDs obtiene varios usuarios por ID en una sola consulta; los que no existen se omiten
+func (r *userRepository) GetByIDs(ctx context.Context, ids []int64) ([]*model.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.id IN (` + placeholders(len(ids)) + `) AND u.deleted_at IS NULL
+	`
+
+	users, err := r.queryUsers(ctx, query, int64Args(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users by id: %w", err)
+	}
+
+	return users, nil
+}
+
+// usernameSearchCandidates es la cantidad máxima de usernames con el prefijo que se ordenan por
+// seguidores, para que un prefijo corto no cuente los seguidores de toda la tabla
+const usernameSearchCandidates = 200
+
+// SearchByUsernamePrefix busca los usuarios cuyo username empieza con prefix. Primero va la
+// coincidencia exacta y después el resto, de más a menos seguidores. Solo se ordenan los primeros
+// usernameSearchCandidates en orden alfabético, que incluyen siempre a la coincidencia exacta.
+func (r *userRepository) SearchByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]*model.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM (
+			SELECT id
+			FROM users
+			WHERE username LIKE ? AND deleted_at IS NULL
+			ORDER BY username
+			LIMIT ?
+		) c
+		JOIN users u ON u.id = c.id
+		LEFT JOIN follows f ON f.following_id = u.id
+		GROUP BY u.id
+		ORDER BY u.username = ? DESC, COUNT(f.follower_id) DESC, u.username
+		LIMIT ?
+	`
+
+	users, err := r.queryUsers(ctx, query, escapeLike(prefix)+"%", usernameSearchCandidates, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching users: %w", err)
+	}
+
+	return users, nil
+}
+
+// queryUsers ejecuta una consulta que selecciona userColumns y escanea sus filas
+func (r *userRepository) queryUsers(ctx context.Context, query string, args ...any) ([]*model.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*model.User
+	for rows.Next() {
+		user := &model.User{}
+		if err := scanUser(rows, user); err != nil {
+			return nil, fmt.Errorf("error scanning user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// escapeLike escapa los comodines de LIKE para que value se compare de forma literal
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	now := time.Now()
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
 	query := `
-		INSERT INTO users (username, email, created_at, updated_at)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO users (username, email, password_hash, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		user.Username,
 		user.Email,
+		user.PasswordHash,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -74,6 +221,138 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+// UpdateProfile guarda los campos del perfil del usuario, incluido si la cuenta es protegida. Una
+// cuenta pública no recibe pedidos de follow, así que en la misma transacción se descartan los
+// pendientes: los solicitantes pueden seguirla directamente.
+func (r *userRepository) UpdateProfile(ctx context.Context, user *model.User) error {
+	user.UpdatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET display_name = ?, bio = ?, location = ?, website = ?, avatar_url = ?, protected = ?,
+			updated_at = ?
+		WHERE id = ?
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		user.DisplayName,
+		user.Bio,
+		user.Location,
+		user.Website,
+		user.AvatarURL,
+		user.Protected,
+		user.UpdatedAt,
+		user.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating profile: %w", err)
+	}
+
+	if !user.Protected {
+		_, err = tx.ExecContext(ctx, `DELETE FROM follow_requests WHERE target_id = ?`, user.ID)
+		if err != nil {
+			return fmt.Errorf("error deleting follow requests: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SoftDelete da de baja la cuenta: deja de aparecer en todas las consultas, pero sus datos se
+// conservan hasta que se borra definitivamente con Delete
+func (r *userRepository) SoftDelete(ctx context.Context, userID int64, deletedAt time.Time) error {
+	query := `UPDATE users SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, deletedAt, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	return nil
+}
+
+// GetDeletedBefore obtiene hasta limit cuentas dadas de baja antes de la fecha dada
+func (r *userRepository) GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?
+		ORDER BY deleted_at
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deleted users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Delete borra definitivamente una cuenta dada de baja. Sus tweets, follows, likes y tokens se
+// borran en cascada; antes se descuentan sus likes de los contadores de los tweets de otros.
+func (r *userRepository) Delete(ctx context.Context, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE tweet_stats ts
+		JOIN likes l ON l.tweet_id = ts.tweet_id
+		SET ts.like_count = GREATEST(ts.like_count - 1, 0)
+		WHERE l.user_id = ?
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("error updating like counts: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE id = ? AND deleted_at IS NOT NULL`, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (r *userRepository) GetStats(ctx context.Context, userID int64) (*model.UserStats, error) {
 	query := `
 		SELECT 
@@ -82,7 +361,7 @@ func (r *userRepository) GetStats(ctx context.Context, userID int64) (*model.Use
 			(SELECT COUNT(*) FROM follows WHERE follower_id = u.id) as following_count,
 			(SELECT COUNT(*) FROM tweets WHERE user_id = u.id) as tweets_count
 		FROM users u
-		WHERE u.id = ?
+		WHERE u.id = ? AND u.deleted_at IS NULL
 	`
 
 	stats := &model.UserStats{}
@@ -105,36 +384,16 @@ func (r *userRepository) GetStats(ctx context.Context, userID int64) (*model.Use
 
 func (r *userRepository) GetAllUsers(ctx context.Context) ([]*model.User, error) {
 	query := `
-		SELECT id, username, email, created_at, updated_at 
-		FROM users 
-		ORDER BY id
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.deleted_at IS NULL
+		ORDER BY u.id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	users, err := r.queryUsers(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("error getting all users: %w", err)
 	}
-	defer rows.Close()
-
-	var users []*model.User
-	for rows.Next() {
-		user := &model.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Username,
-			&user.Email,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning user: %w", err)
-		}
-		users = append(users, user)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating users: %w", err)
-	}
 
 	return users, nil
 }
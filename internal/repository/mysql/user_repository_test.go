@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUserRepository_UpdateProfileUnprotect(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	users := NewUserRepository(db)
+	follows := NewFollowRepository(db)
+
+	owner := createTestUser(t, db, "protegida")
+	requester := createTestUser(t, db, "pide")
+
+	owner.Protected = true
+	if err := users.UpdateProfile(ctx, owner); err != nil {
+		t.Fatalf("error protegiendo la cuenta: %v", err)
+	}
+	if err := follows.CreateRequest(ctx, requester.ID, owner.ID); err != nil {
+		t.Fatalf("error creando el pedido: %v", err)
+	}
+
+	t.Run("seguir protegida conserva los pedidos", func(t *testing.T) {
+		owner.Bio = "hola"
+		if err := users.UpdateProfile(ctx, owner); err != nil {
+			t.Fatalf("error actualizando el perfil: %v", err)
+		}
+		if exists, err := follows.RequestExists(ctx, requester.ID, owner.ID); err != nil || !exists {
+			t.Errorf("esperaba el pedido pendiente, obtuve %v, err: %v", exists, err)
+		}
+	})
+
+	t.Run("volver a pública descarta los pedidos", func(t *testing.T) {
+		owner.Protected = false
+		if err := users.UpdateProfile(ctx, owner); err != nil {
+			t.Fatalf("error actualizando el perfil: %v", err)
+		}
+		if exists, err := follows.RequestExists(ctx, requester.ID, owner.ID); err != nil || exists {
+			t.Errorf("no esperaba pedidos pendientes, obtuve %v, err: %v", exists, err)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microx/internal/model"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type eventRepository struct {
+	client *redis.Client
+}
+
+// NewEventRepository crea una nueva instancia del canal de eventos en tiempo real sobre
+// Redis Pub/Sub, compartido por todas las instancias del servidor
+func NewEventRepository(client *redis.Client) *eventRepository {
+	return &eventRepository{client: client}
+}
+
+// generateUserChannel genera el canal de eventos de un usuario. Todos los eventos del usuario
+// (timeline, notificaciones, follows...) van por el mismo canal y se distinguen por Event.Channel.
+func (r *eventRepository) generateUserChannel(userID int64) string {
+	return fmt.Sprintf("events:%d", userID)
+}
+
+// generateAuthorChannel genera el canal de eventos de los tweets de un autor que no se
+// distribuyen al escribir (cuentas sobre el umbral del modelo híbrido)
+func (r *eventRepository) generateAuthorChannel(authorID int64) string {
+	return fmt.Sprintf("tweets:events:%d", authorID)
+}
+
+// PublishToUsers publica el evento en el canal de cada usuario, en un pipeline
+func (r *eventRepository) PublishToUsers(ctx context.Context, userIDs []int64, event *model.Event) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshaling event: %w", err)
+	}
+
+	pipe := r.client.Pipeline()
+	for _, userID := range userIDs {
+		pipe.Publish(ctx, r.generateUserChannel(userID), eventJSON)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error publishing events: %w", err)
+	}
+
+	return nil
+}
+
+// PublishToAuthor publica el evento en el canal del autor
+func (r *eventRepository) PublishToAuthor(ctx context.Context, authorID int64, event *model.Event) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshaling event: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, r.generateAuthorChannel(authorID), eventJSON).Err(); err != nil {
+		return fmt.Errorf("error publishing author event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe se suscribe al canal del usuario y a los canales de los autores dados. La
+// suscripción ya está activa al volver, y se cierra junto con el canal devuelto al cancelar ctx.
+func (r *eventRepository) Subscribe(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+	channels := []string{r.generateUserChannel(userID)}
+	for _, authorID := range authorIDs {
+		channels = append(channels, r.generateAuthorChannel(authorID))
+	}
+
+	pubsub := r.client.Subscribe(ctx, channels...)
+
+	// Esperar la confirmación para no perder eventos publicados justo después de volver
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("error subscribing to events: %w", err)
+	}
+
+	events := make(chan *model.Event)
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event model.Event
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					continue // Skip malformed events
+				}
+
+				select {
+				case events <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
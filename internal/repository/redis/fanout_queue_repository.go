@@ -0,0 +1,244 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microx/internal/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	fanoutStreamKey  = "fanout:jobs"
+	fanoutDelayedKey = "fanout:delayed"
+	fanoutDeadKey    = "fanout:dead"
+	fanoutGroup      = "fanout-workers"
+)
+
+// promoteScript quita un job del sorted set de reintentos (ARGV[1]) y lo agrega al stream con los
+// campos del resto de ARGV, en una sola operación. Devuelve false si el job ya no estaba.
+var promoteScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return false
+end
+return redis.call('XADD', KEYS[2], '*', unpack(ARGV, 2))
+`)
+
+type fanoutQueueRepository struct {
+	client *redis.Client
+}
+
+// NewFanoutQueueRepository crea una nueva instancia de la cola de fan-out sobre Redis Streams
+func NewFanoutQueueRepository(client *redis.Client) *fanoutQueueRepository {
+	return &fanoutQueueRepository{client: client}
+}
+
+// EnsureGroup crea el stream y el grupo de consumidores si no existen
+func (r *fanoutQueueRepository) EnsureGroup(ctx context.Context) error {
+	err := r.client.XGroupCreateMkStream(ctx, fanoutStreamKey, fanoutGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("error creating fanout consumer group: %w", err)
+	}
+
+	return nil
+}
+
+// Enqueue agrega un job al stream de fan-out
+func (r *fanoutQueueRepository) Enqueue(ctx context.Context, job *model.FanoutJob) error {
+	if job.EnqueuedAt.IsZero() {
+		job.EnqueuedAt = time.Now()
+	}
+
+	id, err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: fanoutStreamKey,
+		Values: r.jobValues(job),
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("error enqueuing fanout job: %w", err)
+	}
+
+	job.ID = id
+	return nil
+}
+
+// Consume lee jobs nuevos del grupo para el consumidor dado
+func (r *fanoutQueueRepository) Consume(ctx context.Context, consumer string, count int, block time.Duration) ([]*model.FanoutJob, error) {
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    fanoutGroup,
+		Consumer: consumer,
+		Streams:  []string{fanoutStreamKey, ">"},
+		Count:    int64(count),
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading fanout jobs: %w", err)
+	}
+
+	var jobs []*model.FanoutJob
+	for _, stream := range streams {
+		jobs = append(jobs, r.parseMessages(stream.Messages)...)
+	}
+
+	return jobs, nil
+}
+
+// ClaimStale reasigna los jobs pendientes de consumidores que dejaron de procesarlos (por ejemplo, por una caída)
+func (r *fanoutQueueRepository) ClaimStale(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]*model.FanoutJob, error) {
+	messages, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   fanoutStreamKey,
+		Group:    fanoutGroup,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    int64(count),
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming stale fanout jobs: %w", err)
+	}
+
+	return r.parseMessages(messages), nil
+}
+
+// Ack confirma un job procesado y lo elimina del stream
+func (r *fanoutQueueRepository) Ack(ctx context.Context, job *model.FanoutJob) error {
+	pipe := r.client.TxPipeline()
+	pipe.XAck(ctx, fanoutStreamKey, fanoutGroup, job.ID)
+	pipe.XDel(ctx, fanoutStreamKey, job.ID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error acknowledging fanout job: %w", err)
+	}
+
+	return nil
+}
+
+// Retry confirma el job y lo guarda en el sorted set de reintentos, con el momento en que debe volver a encolarse como score
+func (r *fanoutQueueRepository) Retry(ctx context.Context, job *model.FanoutJob, delay time.Duration) error {
+	jobJSON, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("error marshaling fanout job: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, fanoutDelayedKey, redis.Z{
+		Score:  float64(time.Now().Add(delay).UnixMilli()),
+		Member: jobJSON,
+	})
+	pipe.XAck(ctx, fanoutStreamKey, fanoutGroup, job.ID)
+	pipe.XDel(ctx, fanoutStreamKey, job.ID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error scheduling fanout retry: %w", err)
+	}
+
+	return nil
+}
+
+// PromoteDelayed vuelve a encolar los reintentos vencidos. Cada uno se quita del sorted set y se
+// agrega al stream en una sola operación: el ZREM actúa como lock para que varios workers no lo
+// encolen dos veces, y un job nunca queda fuera de los dos. Un error en un job no frena al resto;
+// se devuelve el primero al terminar.
+func (r *fanoutQueueRepository) PromoteDelayed(ctx context.Context, now time.Time) (int, error) {
+	members, err := r.client.ZRangeByScore(ctx, fanoutDelayedKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error getting delayed fanout jobs: %w", err)
+	}
+
+	promoted := 0
+	var firstErr error
+	for _, member := range members {
+		var job model.FanoutJob
+		if err := json.Unmarshal([]byte(member), &job); err != nil {
+			// Un reintento ilegible nunca se podría encolar; se descarta para no leerlo en cada pasada
+			r.client.ZRem(ctx, fanoutDelayedKey, member)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("error unmarshaling delayed fanout job: %w", err)
+			}
+			continue
+		}
+		if job.EnqueuedAt.IsZero() {
+			job.EnqueuedAt = time.Now()
+		}
+
+		args := []interface{}{member}
+		for field, value := range r.jobValues(&job) {
+			args = append(args, field, value)
+		}
+
+		err := promoteScript.Run(ctx, r.client, []string{fanoutDelayedKey, fanoutStreamKey}, args...).Err()
+		if err == redis.Nil {
+			continue // Otro worker ya lo encoló
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("error promoting delayed fanout job: %w", err)
+			}
+			continue
+		}
+		promoted++
+	}
+
+	return promoted, firstErr
+}
+
+// DeadLetter confirma el job y lo registra en el stream de jobs descartados junto con el motivo
+func (r *fanoutQueueRepository) DeadLetter(ctx context.Context, job *model.FanoutJob, reason string) error {
+	values := r.jobValues(job)
+	values["error"] = reason
+	values["failed_at"] = time.Now().Format(time.RFC3339)
+
+	pipe := r.client.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: fanoutDeadKey,
+		Values: values,
+	})
+	pipe.XAck(ctx, fanoutStreamKey, fanoutGroup, job.ID)
+	pipe.XDel(ctx, fanoutStreamKey, job.ID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error dead-lettering fanout job: %w", err)
+	}
+
+	return nil
+}
+
+// jobValues convierte un job en los campos de un mensaje del stream
+func (r *fanoutQueueRepository) jobValues(job *model.FanoutJob) map[string]interface{} {
+	return map[string]interface{}{
+		"tweet_id":    job.TweetID,
+		"author_id":   job.AuthorID,
+		"attempts":    job.Attempts,
+		"enqueued_at": job.EnqueuedAt.Format(time.RFC3339),
+	}
+}
+
+func (r *fanoutQueueRepository) parseMessages(messages []redis.XMessage) []*model.FanoutJob {
+	jobs := make([]*model.FanoutJob, 0, len(messages))
+	for _, message := range messages {
+		jobs = append(jobs, r.parseJob(message))
+	}
+	return jobs
+}
+
+// parseJob reconstruye un job a partir de un mensaje del stream
+func (r *fanoutQueueRepository) parseJob(message redis.XMessage) *model.FanoutJob {
+	job := &model.FanoutJob{ID: message.ID}
+	job.TweetID, _ = strconv.ParseInt(fmt.Sprint(message.Values["tweet_id"]), 10, 64)
+	job.AuthorID, _ = strconv.ParseInt(fmt.Sprint(message.Values["author_id"]), 10, 64)
+	job.Attempts, _ = strconv.Atoi(fmt.Sprint(message.Values["attempts"]))
+	job.EnqueuedAt, _ = time.Parse(time.RFC3339, fmt.Sprint(message.Values["enqueued_at"]))
+	return job
+}
@@ -0,0 +1,143 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// consumeScript lee el usuario de la clave dada y la elimina junto con la de su token par en una
+// sola operación. Devuelve false si la clave no existe.
+var consumeScript = redis.NewScript(`
+local values = redis.call('HMGET', KEYS[1], 'user_id', 'pair')
+if not values[1] then
+	return false
+end
+redis.call('DEL', KEYS[1])
+if values[2] then
+	redis.call('DEL', ARGV[1] .. values[2])
+end
+return values[1]
+`)
+
+type sessionRepository struct {
+	client *redis.Client
+}
+
+// NewSessionRepository crea una nueva instancia del repositorio de sesiones
+func NewSessionRepository(client *redis.Client) *sessionRepository {
+	return &sessionRepository{client: client}
+}
+
+// hashToken obtiene el hash de un token. En Redis solo se guardan hashes, nunca los tokens en claro.
+func (r *sessionRepository) hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateAccessKey genera la clave de un access token a partir de su hash
+func (r *sessionRepository) generateAccessKey(tokenHash string) string {
+	return fmt.Sprintf("session:access:%s", tokenHash)
+}
+
+// generateRefreshKey genera la clave de un refresh token a partir de su hash
+func (r *sessionRepository) generateRefreshKey(tokenHash string) string {
+	return fmt.Sprintf("session:refresh:%s", tokenHash)
+}
+
+// Create guarda el par access/refresh de una sesión. Cada token referencia al otro
+// para que revocar cualquiera de ellos revoque la sesión completa.
+func (r *sessionRepository) Create(ctx context.Context, userID int64, tokens *model.AuthTokens, accessTTL, refreshTTL time.Duration) error {
+	accessHash := r.hashToken(tokens.AccessToken)
+	refreshHash := r.hashToken(tokens.RefreshToken)
+	accessKey := r.generateAccessKey(accessHash)
+	refreshKey := r.generateRefreshKey(refreshHash)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, accessKey, "user_id", userID, "pair", refreshHash)
+	pipe.Expire(ctx, accessKey, accessTTL)
+	pipe.HSet(ctx, refreshKey, "user_id", userID, "pair", accessHash)
+	pipe.Expire(ctx, refreshKey, refreshTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error creating session: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserIDByAccessToken obtiene el usuario dueño de un access token vigente
+func (r *sessionRepository) GetUserIDByAccessToken(ctx context.Context, accessToken string) (int64, error) {
+	return r.getUserID(ctx, r.generateAccessKey(r.hashToken(accessToken)))
+}
+
+// RevokeByAccessToken revoca la sesión a la que pertenece un access token
+func (r *sessionRepository) RevokeByAccessToken(ctx context.Context, accessToken string) error {
+	accessKey := r.generateAccessKey(r.hashToken(accessToken))
+	return r.revoke(ctx, accessKey, r.generateRefreshKey)
+}
+
+// ConsumeRefreshToken revoca la sesión de un refresh token y devuelve su usuario. Leer y borrar se
+// hace en un script Lua: con dos requests concurrentes con el mismo token, solo una lo obtiene.
+func (r *sessionRepository) ConsumeRefreshToken(ctx context.Context, refreshToken string) (int64, error) {
+	refreshKey := r.generateRefreshKey(r.hashToken(refreshToken))
+	accessPrefix := r.generateAccessKey("")
+
+	value, err := consumeScript.Run(ctx, r.client, []string{refreshKey}, accessPrefix).Text()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, fmt.Errorf("session %w", repository.ErrNotFound)
+		}
+		return 0, fmt.Errorf("error consuming session: %w", err)
+	}
+
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing session user: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *sessionRepository) getUserID(ctx context.Context, key string) (int64, error) {
+	value, err := r.client.HGet(ctx, key, "user_id").Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, fmt.Errorf("session not found")
+		}
+		return 0, fmt.Errorf("error getting session: %w", err)
+	}
+
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing session user: %w", err)
+	}
+
+	return userID, nil
+}
+
+// revoke elimina la clave dada y la de su token par
+func (r *sessionRepository) revoke(ctx context.Context, key string, pairKey func(string) string) error {
+	pair, err := r.client.HGet(ctx, key, "pair").Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("error getting session: %w", err)
+	}
+
+	keys := []string{key}
+	if pair != "" {
+		keys = append(keys, pairKey(pair))
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+
+	return nil
+}
@@ -2,109 +2,171 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"microx/internal/model"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// timelineRepository guarda timelines como sorted sets de IDs de tweets. La misma estructura sirve
+// para el timeline de cada usuario y para el de cada lista; solo cambia el formato de la clave.
 type timelineRepository struct {
 	client *redis.Client
+	// keyFormat arma la clave del timeline a partir del ID de su dueño (usuario o lista)
+	keyFormat string
+	// maxLength es la cantidad máxima de tweets que se conservan por timeline; los más antiguos
+	// se descartan al insertar y se leen desde MySQL
+	maxLength int
+	ttl       time.Duration
 }
 
 // NewTimelineRepository crea una nueva instancia del repositorio de timeline
-func NewTimelineRepository(client *redis.Client) *timelineRepository {
-	return &timelineRepository{client: client}
+func NewTimelineRepository(client *redis.Client, maxLength int, ttl time.Duration) *timelineRepository {
+	return &timelineRepository{client: client, keyFormat: "timeline:%d", maxLength: maxLength, ttl: ttl}
 }
 
-// generateTimelineKey genera la clave para el timeline de un usuario
-func (r *timelineRepository) generateTimelineKey(userID int64) string {
-	return fmt.Sprintf("timeline:%d", userID)
+// NewListTimelineRepository crea el repositorio de los timelines de listas. Los métodos reciben el
+// ID de la lista en lugar del ID del usuario.
+func NewListTimelineRepository(client *redis.Client, maxLength int, ttl time.Duration) *timelineRepository {
+	return &timelineRepository{client: client, keyFormat: "list:%d:timeline", maxLength: maxLength, ttl: ttl}
 }
 
-// AddToTimeline agrega un tweet al timeline de un usuario
+// generateTimelineKey genera la clave para el timeline de un usuario o de una lista
+func (r *timelineRepository) generateTimelineKey(id int64) string {
+	return fmt.Sprintf(r.keyFormat, id)
+}
+
+// AddToTimeline agrega un tweet al timeline de un usuario. Solo se guarda el ID del tweet;
+// el contenido vive en la caché de tweets.
 func (r *timelineRepository) AddToTimeline(ctx context.Context, userID int64, tweet *model.TweetWithUser) error {
-	key := r.generateTimelineKey(userID)
+	// MULTI/EXEC para que el timeline nunca quede por encima del máximo
+	pipe := r.client.TxPipeline()
+	r.addToTimeline(ctx, pipe, userID, tweet)
 
-	// Serializar el tweet a JSON
-	tweetJSON, err := json.Marshal(tweet)
-	if err != nil {
-		return fmt.Errorf("error marshaling tweet: %w", err)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error adding tweet to timeline: %w", err)
 	}
 
-	// Usar el timestamp como score para ordenar por fecha
-	score := float64(tweet.CreatedAt.Unix())
+	return nil
+}
 
-	// Agregar al sorted set de Redis
-	err = r.client.ZAdd(ctx, key, redis.Z{
-		Score:  score,
-		Member: tweetJSON,
-	}).Err()
+// addToTimeline encola en el pipeline la inserción del tweet, el recorte de los tweets que
+// exceden el máximo y la renovación del TTL
+func (r *timelineRepository) addToTimeline(ctx context.Context, pipe redis.Pipeliner, userID int64, tweet *model.TweetWithUser) {
+	key := r.generateTimelineKey(userID)
 
-	if err != nil {
-		return fmt.Errorf("error adding tweet to timeline: %w", err)
+	// Usar el timestamp como score para ordenar por fecha
+	pipe.ZAdd(ctx, key, redis.Z{
+		Score:  float64(tweet.CreatedAt.Unix()),
+		Member: tweet.ID,
+	})
+
+	// Conservar solo los maxLength tweets más recientes (rank 0 es el más antiguo)
+	if r.maxLength > 0 {
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-r.maxLength-1))
 	}
 
-	// Establecer TTL para el timeline (1 hora por defecto)
-	err = r.client.Expire(ctx, key, time.Hour).Err()
-	if err != nil {
-		return fmt.Errorf("error setting timeline TTL: %w", err)
+	if r.ttl > 0 {
+		pipe.Expire(ctx, key, r.ttl)
 	}
-
-	return nil
 }
 
-// GetTimeline obtiene el timeline de un usuario desde Redis
-func (r *timelineRepository) GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+// GetTimeline obtiene una página del timeline de un usuario desde Redis.
+// El score de cada tweet es su created_at en segundos, así que los límites de la página se
+// resuelven con ZREVRANGEBYSCORE y los empates dentro de un mismo segundo se ordenan por ID.
+func (r *timelineRepository) GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
 	key := r.generateTimelineKey(userID)
 
-	// Obtener tweets ordenados por score (fecha) descendente
-	// ZREVRANGE para obtener los más recientes primero
-	result, err := r.client.ZRevRange(ctx, key, int64(offset), int64(offset+limit-1)).Result()
-	if err != nil {
-		return nil, fmt.Errorf("error getting timeline from redis: %w", err)
+	rangeBy := &redis.ZRangeBy{Min: "-inf", Max: "+inf", Count: int64(page.Limit) + 1}
+	if page.Before != nil {
+		rangeBy.Max = strconv.FormatInt(page.Before.CreatedAt.Unix(), 10)
+	}
+	if page.Since != nil {
+		rangeBy.Min = strconv.FormatInt(page.Since.CreatedAt.Unix(), 10)
 	}
 
-	var tweets []*model.TweetWithUser
-	for _, tweetJSON := range result {
-		var tweet model.TweetWithUser
-		err := json.Unmarshal([]byte(tweetJSON), &tweet)
+	var entries []*model.Cursor
+	var boundary float64
+	for {
+		result, err := r.client.ZRevRangeByScoreWithScores(ctx, key, rangeBy).Result()
 		if err != nil {
-			return nil, fmt.Errorf("error unmarshaling tweet: %w", err)
+			return nil, fmt.Errorf("error getting timeline from redis: %w", err)
+		}
+
+		for _, z := range result {
+			// Con la página completa solo se siguen leyendo los empates del último segundo
+			if len(entries) >= page.Limit && z.Score < boundary {
+				return sortAndLimit(entries, page.Limit), nil
+			}
+
+			member, _ := z.Member.(string)
+			tweetID, err := strconv.ParseInt(member, 10, 64)
+			if err != nil {
+				continue // Skip malformed entries
+			}
+
+			entry := &model.Cursor{CreatedAt: time.Unix(int64(z.Score), 0), ID: tweetID}
+			if !page.Contains(entry) {
+				continue
+			}
+
+			entries = append(entries, entry)
+			boundary = z.Score
 		}
-		tweets = append(tweets, &tweet)
+
+		if int64(len(result)) < rangeBy.Count {
+			return sortAndLimit(entries, page.Limit), nil
+		}
+		rangeBy.Offset += int64(len(result))
 	}
+}
+
+// sortAndLimit ordena las entradas por (created_at, id) descendente y conserva las primeras limit
+func sortAndLimit(entries []*model.Cursor, limit int) []*model.Cursor {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[j].Before(entries[i])
+	})
 
-	return tweets, nil
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
 }
 
 // RemoveFromTimeline remueve un tweet del timeline
 func (r *timelineRepository) RemoveFromTimeline(ctx context.Context, userID int64, tweetID int64) error {
 	key := r.generateTimelineKey(userID)
 
-	// Obtener todos los tweets del timeline
-	result, err := r.client.ZRange(ctx, key, 0, -1).Result()
+	err := r.client.ZRem(ctx, key, tweetID).Err()
 	if err != nil {
-		return fmt.Errorf("error getting timeline for removal: %w", err)
+		return fmt.Errorf("error removing tweet from timeline: %w", err)
 	}
 
-	// Buscar y remover el tweet específico
-	for _, tweetJSON := range result {
-		var tweet model.TweetWithUser
-		err := json.Unmarshal([]byte(tweetJSON), &tweet)
-		if err != nil {
-			continue // Skip malformed tweets
-		}
+	return nil
+}
 
-		if tweet.ID == tweetID {
-			err = r.client.ZRem(ctx, key, tweetJSON).Err()
-			if err != nil {
-				return fmt.Errorf("error removing tweet from timeline: %w", err)
-			}
-			break
-		}
+// RemoveTweetsFromTimelines elimina varios tweets de los timelines de varios usuarios en un pipeline
+func (r *timelineRepository) RemoveTweetsFromTimelines(ctx context.Context, userIDs []int64, tweetIDs []int64) error {
+	if len(userIDs) == 0 || len(tweetIDs) == 0 {
+		return nil
+	}
+
+	members := make([]any, len(tweetIDs))
+	for i, tweetID := range tweetIDs {
+		members[i] = tweetID
+	}
+
+	pipe := r.client.Pipeline()
+	for _, userID := range userIDs {
+		pipe.ZRem(ctx, r.generateTimelineKey(userID), members...)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error removing tweets from timelines: %w", err)
 	}
 
 	return nil
@@ -124,23 +186,11 @@ func (r *timelineRepository) InvalidateTimeline(ctx context.Context, userID int6
 
 // AddToMultipleTimelines agrega un tweet a múltiples timelines (para cuando alguien publica)
 func (r *timelineRepository) AddToMultipleTimelines(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
-	// Usar pipeline para operaciones en lote
-	pipe := r.client.Pipeline()
+	// Usar una transacción para operaciones en lote; cada inserción se recorta en la misma transacción
+	pipe := r.client.TxPipeline()
 
 	for _, followerID := range followerIDs {
-		key := r.generateTimelineKey(followerID)
-		score := float64(tweet.CreatedAt.Unix())
-
-		tweetJSON, err := json.Marshal(tweet)
-		if err != nil {
-			return fmt.Errorf("error marshaling tweet for pipeline: %w", err)
-		}
-
-		pipe.ZAdd(ctx, key, redis.Z{
-			Score:  score,
-			Member: tweetJSON,
-		})
-		pipe.Expire(ctx, key, time.Hour)
+		r.addToTimeline(ctx, pipe, followerID, tweet)
 	}
 
 	_, err := pipe.Exec(ctx)
@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"microx/internal/model"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// trendRankingTTL es cuánto se reutiliza un ranking ya calculado antes de volver a combinar los buckets
+const trendRankingTTL = time.Minute
+
+type trendRepository struct {
+	client *redis.Client
+}
+
+// NewTrendRepository crea una nueva instancia del repositorio de tendencias. Cada ventana cuenta
+// los usos de hashtags en un sorted set por bucket de tiempo, que expira cuando sale de la ventana.
+func NewTrendRepository(client *redis.Client) *trendRepository {
+	return &trendRepository{client: client}
+}
+
+// generateBucketKey genera la clave del bucket de una ventana que empieza en start
+func (r *trendRepository) generateBucketKey(window model.TrendWindow, start time.Time) string {
+	return fmt.Sprintf("trends:%s:%d", window.Name, start.Unix())
+}
+
+// generateScoresKey genera la clave del ranking calculado de una ventana, con el puntaje con decaimiento
+func (r *trendRepository) generateScoresKey(window model.TrendWindow) string {
+	return fmt.Sprintf("trends:%s:scores", window.Name)
+}
+
+// generateCountsKey genera la clave con la cantidad de usos sin ponderar de cada hashtag de la ventana
+func (r *trendRepository) generateCountsKey(window model.TrendWindow) string {
+	return fmt.Sprintf("trends:%s:counts", window.Name)
+}
+
+// Record suma un uso de cada hashtag en el bucket actual de cada ventana
+func (r *trendRepository) Record(ctx context.Context, hashtags []string, at time.Time) error {
+	if len(hashtags) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for _, window := range model.TrendWindows {
+		key := r.generateBucketKey(window, at.Truncate(window.Bucket))
+		for _, tag := range hashtags {
+			pipe.ZIncrBy(ctx, key, 1, tag)
+		}
+		// El bucket se conserva mientras forme parte de la ventana
+		pipe.Expire(ctx, key, window.Length+window.Bucket)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error recording hashtags: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrending combina los buckets de la ventana con ZUNIONSTORE, ponderando cada uno según su
+// antigüedad. El resultado se cachea durante trendRankingTTL para no recalcularlo en cada request.
+func (r *trendRepository) GetTrending(ctx context.Context, window model.TrendWindow, now time.Time, limit int) ([]*model.Trend, error) {
+	scoresKey := r.generateScoresKey(window)
+	countsKey := r.generateCountsKey(window)
+
+	exists, err := r.client.Exists(ctx, scoresKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error checking trends ranking: %w", err)
+	}
+	if exists == 0 {
+		if err := r.buildRanking(ctx, window, now, scoresKey, countsKey); err != nil {
+			return nil, err
+		}
+	}
+
+	top, err := r.client.ZRevRangeWithScores(ctx, scoresKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting trends: %w", err)
+	}
+	if len(top) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]string, len(top))
+	for i, entry := range top {
+		tags[i] = entry.Member.(string)
+	}
+
+	counts, err := r.client.ZMScore(ctx, countsKey, tags...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting trend counts: %w", err)
+	}
+
+	trends := make([]*model.Trend, len(top))
+	for i, entry := range top {
+		trends[i] = &model.Trend{
+			Hashtag:    tags[i],
+			Score:      entry.Score,
+			TweetCount: int64(counts[i]),
+		}
+	}
+
+	return trends, nil
+}
+
+// buildRanking calcula el ranking de la ventana a partir de sus buckets
+func (r *trendRepository) buildRanking(ctx context.Context, window model.TrendWindow, now time.Time, scoresKey, countsKey string) error {
+	current := now.Truncate(window.Bucket)
+	buckets := int(window.Length / window.Bucket)
+
+	keys := make([]string, 0, buckets)
+	weights := make([]float64, 0, buckets)
+	for i := 0; i < buckets; i++ {
+		start := current.Add(-time.Duration(i) * window.Bucket)
+		keys = append(keys, r.generateBucketKey(window, start))
+
+		// La antigüedad de un bucket se mide desde su punto medio
+		age := now.Sub(start.Add(window.Bucket / 2))
+		if age < 0 {
+			age = 0
+		}
+		weights = append(weights, math.Pow(0.5, age.Seconds()/window.HalfLife.Seconds()))
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZUnionStore(ctx, scoresKey, &redis.ZStore{Keys: keys, Weights: weights})
+	pipe.ZUnionStore(ctx, countsKey, &redis.ZStore{Keys: keys})
+	pipe.Expire(ctx, scoresKey, trendRankingTTL)
+	pipe.Expire(ctx, countsKey, trendRankingTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error building trends ranking: %w", err)
+	}
+
+	return nil
+}
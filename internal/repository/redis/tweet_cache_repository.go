@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"microx/internal/model"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type tweetCacheRepository struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewTweetCacheRepository crea una nueva instancia de la caché de tweets. Cada tweet se guarda
+// una sola vez bajo su propia clave, con el TTL dado.
+func NewTweetCacheRepository(client *redis.Client, ttl time.Duration) *tweetCacheRepository {
+	return &tweetCacheRepository{client: client, ttl: ttl}
+}
+
+// generateTweetKey genera la clave de un tweet cacheado
+func (r *tweetCacheRepository) generateTweetKey(tweetID int64) string {
+	return fmt.Sprintf("tweet:%d", tweetID)
+}
+
+// GetMany obtiene varios tweets con un único MGET
+func (r *tweetCacheRepository) GetMany(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error) {
+	tweets := make(map[int64]*model.TweetWithUser, len(ids))
+	if len(ids) == 0 {
+		return tweets, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.generateTweetKey(id)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting cached tweets: %w", err)
+	}
+
+	for _, value := range values {
+		tweetJSON, ok := value.(string)
+		if !ok {
+			continue // No está en caché
+		}
+
+		var tweet model.TweetWithUser
+		if err := json.Unmarshal([]byte(tweetJSON), &tweet); err != nil {
+			continue // Skip malformed tweets
+		}
+		tweets[tweet.ID] = &tweet
+	}
+
+	return tweets, nil
+}
+
+// SetMany guarda varios tweets en un pipeline
+func (r *tweetCacheRepository) SetMany(ctx context.Context, tweets []*model.TweetWithUser) error {
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for _, tweet := range tweets {
+		tweetJSON, err := json.Marshal(tweet)
+		if err != nil {
+			return fmt.Errorf("error marshaling tweet: %w", err)
+		}
+		pipe.Set(ctx, r.generateTweetKey(tweet.ID), tweetJSON, r.ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error caching tweets: %w", err)
+	}
+
+	return nil
+}
+
+// Delete elimina tweets de la caché para que la próxima lectura los obtenga desde la base de datos
+func (r *tweetCacheRepository) Delete(ctx context.Context, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.generateTweetKey(id)
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("error deleting cached tweets: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,487 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strconv"
+	"time"
+)
+
+const (
+	// exportBatchSize es la cantidad de elementos que se leen por página al armar una exportación
+	exportBatchSize = 500
+	// purgeBatchSize es la cantidad de cuentas o exportaciones vencidas que se borran por pasada
+	purgeBatchSize = 100
+	// exportFailureReason es el motivo que ve el usuario cuando su exportación falla; el error
+	// real solo se registra en el log, porque puede contener detalles internos
+	exportFailureReason = "the export could not be generated, please request a new one"
+)
+
+type accountService struct {
+	userRepo      repository.UserRepository
+	tweetRepo     repository.TweetRepository
+	followRepo    repository.FollowRepository
+	likeRepo      repository.LikeRepository
+	timelineRepo  repository.TimelineRepository
+	tweetCache    repository.TweetCacheRepository
+	exportRepo    repository.DataExportRepository
+	exportStorage repository.ExportStorage
+	// exportTTL es el tiempo durante el que se puede descargar una exportación lista
+	exportTTL time.Duration
+}
+
+// NewAccountService crea una nueva instancia del servicio de baja de cuentas y exportación de datos
+func NewAccountService(
+	userRepo repository.UserRepository,
+	tweetRepo repository.TweetRepository,
+	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	timelineRepo repository.TimelineRepository,
+	tweetCache repository.TweetCacheRepository,
+	exportRepo repository.DataExportRepository,
+	exportStorage repository.ExportStorage,
+	exportTTL time.Duration,
+) AccountService {
+	return &accountService{
+		userRepo:      userRepo,
+		tweetRepo:     tweetRepo,
+		followRepo:    followRepo,
+		likeRepo:      likeRepo,
+		timelineRepo:  timelineRepo,
+		tweetCache:    tweetCache,
+		exportRepo:    exportRepo,
+		exportStorage: exportStorage,
+		exportTTL:     exportTTL,
+	}
+}
+
+// DeleteAccount da de baja la cuenta: desde ese momento no aparece en ninguna consulta y no puede
+// autenticarse. Sus tweets se quitan de los timelines de sus seguidores y de la caché; los datos
+// se borran definitivamente al vencer el período de gracia (PurgeDeletedAccounts).
+func (s *accountService) DeleteAccount(ctx context.Context, userID int64) error {
+	// Los tweets y seguidores se leen antes de la baja, mientras la cuenta sigue visible
+	tweetIDs, err := s.tweetRepo.GetIDsByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error getting tweets: %w", err)
+	}
+
+	followerIDs, err := s.getFollowerIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepo.SoftDelete(ctx, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("error deleting account: %w", err)
+	}
+
+	// La baja ya está hecha: si Redis falla, los tweets quedan ocultos igual, porque la
+	// hidratación de los timelines los lee de MySQL cuando no están en la caché
+	if s.timelineRepo != nil {
+		if len(tweetIDs) > 0 && len(followerIDs) > 0 {
+			if err := s.timelineRepo.RemoveTweetsFromTimelines(ctx, followerIDs, tweetIDs); err != nil {
+				fmt.Printf("Warning: error removing tweets of user %d from timelines: %v\n", userID, err)
+			}
+		}
+		if err := s.timelineRepo.InvalidateTimeline(ctx, userID); err != nil {
+			fmt.Printf("Warning: error invalidating timeline of user %d: %v\n", userID, err)
+		}
+	}
+
+	if s.tweetCache != nil && len(tweetIDs) > 0 {
+		if err := s.tweetCache.Delete(ctx, tweetIDs...); err != nil {
+			fmt.Printf("Warning: error removing tweets of user %d from cache: %v\n", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// getFollowerIDs obtiene los IDs de todos los seguidores del usuario
+func (s *accountService) getFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var followerIDs []int64
+
+	page := model.PageQuery{Limit: followerBatchSize}
+	for {
+		followers, err := s.followRepo.GetFollowers(ctx, userID, page)
+		if err != nil {
+			return nil, fmt.Errorf("error getting followers: %w", err)
+		}
+
+		for _, follower := range followers.Users {
+			followerIDs = append(followerIDs, follower.ID)
+		}
+
+		if followers.NextCursor == nil {
+			return followerIDs, nil
+		}
+		page.Before = followers.NextCursor
+	}
+}
+
+// PurgeDeletedAccounts borra definitivamente las cuentas dadas de baja antes de la fecha dada, con
+// los archivos de sus exportaciones. Devuelve la cantidad de cuentas borradas.
+func (s *accountService) PurgeDeletedAccounts(ctx context.Context, before time.Time) (int, error) {
+	userIDs, err := s.userRepo.GetDeletedBefore(ctx, before, purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error getting deleted accounts: %w", err)
+	}
+
+	purged := 0
+	for _, userID := range userIDs {
+		// Los registros de las exportaciones se borran en cascada con el usuario; los archivos no
+		exports, err := s.exportRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return purged, fmt.Errorf("error getting exports of user %d: %w", userID, err)
+		}
+		for _, export := range exports {
+			if export.FileName == "" {
+				continue
+			}
+			if err := s.exportStorage.Delete(export.FileName); err != nil {
+				return purged, fmt.Errorf("error deleting export %d: %w", export.ID, err)
+			}
+		}
+
+		if err := s.userRepo.Delete(ctx, userID); err != nil {
+			return purged, fmt.Errorf("error purging user %d: %w", userID, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// RequestExport registra un pedido de exportación de los datos del usuario. Solo puede haber un
+// pedido en curso a la vez.
+func (s *accountService) RequestExport(ctx context.Context, userID int64) (*model.DataExport, error) {
+	exports, err := s.exportRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting exports: %w", err)
+	}
+
+	for _, export := range exports {
+		if export.Status == model.DataExportPending || export.Status == model.DataExportProcessing {
+			return nil, ErrExportInProgress
+		}
+	}
+
+	export := &model.DataExport{UserID: userID}
+	err = s.exportRepo.Create(ctx, export)
+	if err != nil {
+		return nil, fmt.Errorf("error creating export: %w", err)
+	}
+
+	return export, nil
+}
+
+// GetExport obtiene una exportación del usuario; las de otros usuarios se tratan como inexistentes
+func (s *accountService) GetExport(ctx context.Context, userID, exportID int64) (*model.DataExport, error) {
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, fmt.Errorf("error getting export: %w", err)
+	}
+
+	if export.UserID != userID {
+		return nil, ErrExportNotFound
+	}
+
+	return export, nil
+}
+
+// GetExportFile devuelve la ruta local del archivo de una exportación lista y el nombre con el
+// que se descarga
+func (s *accountService) GetExportFile(ctx context.Context, userID, exportID int64) (string, string, error) {
+	export, err := s.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if export.Status != model.DataExportReady {
+		return "", "", ErrExportNotReady
+	}
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return "", "", ErrExportNotFound
+	}
+
+	downloadName := fmt.Sprintf("microx-export-%s.zip", export.CreatedAt.UTC().Format("20060102"))
+	return s.exportStorage.Path(export.FileName), downloadName, nil
+}
+
+// ProcessNextExport genera la exportación pendiente más antigua. Devuelve false si no había
+// ninguna pendiente.
+func (s *accountService) ProcessNextExport(ctx context.Context) (bool, error) {
+	export, err := s.exportRepo.ClaimPending(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error claiming export: %w", err)
+	}
+	if export == nil {
+		return false, nil
+	}
+
+	fileName := fmt.Sprintf("export-%d-%d.zip", export.UserID, export.ID)
+	err = s.writeExport(ctx, export.UserID, fileName)
+	now := time.Now()
+	if err != nil {
+		if deleteErr := s.exportStorage.Delete(fileName); deleteErr != nil {
+			fmt.Printf("Warning: error deleting incomplete export %d: %v\n", export.ID, deleteErr)
+		}
+		if markErr := s.exportRepo.MarkFailed(ctx, export.ID, exportFailureReason, now); markErr != nil {
+			fmt.Printf("Warning: error marking export %d as failed: %v\n", export.ID, markErr)
+		}
+		return true, fmt.Errorf("error generating export %d: %w", export.ID, err)
+	}
+
+	err = s.exportRepo.MarkReady(ctx, export.ID, fileName, now, now.Add(s.exportTTL))
+	if err != nil {
+		return true, fmt.Errorf("error marking export %d as ready: %w", export.ID, err)
+	}
+
+	return true, nil
+}
+
+// PurgeExpiredExports borra las exportaciones vencidas y sus archivos. Devuelve la cantidad borrada.
+func (s *accountService) PurgeExpiredExports(ctx context.Context, now time.Time) (int, error) {
+	exports, err := s.exportRepo.GetExpired(ctx, now, purgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error getting expired exports: %w", err)
+	}
+
+	purged := 0
+	for _, export := range exports {
+		if err := s.exportStorage.Delete(export.FileName); err != nil {
+			return purged, fmt.Errorf("error deleting export %d: %w", export.ID, err)
+		}
+		if err := s.exportRepo.Delete(ctx, export.ID); err != nil {
+			return purged, fmt.Errorf("error deleting export %d: %w", export.ID, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// writeExport arma el archivo zip con el perfil, los tweets, los follows y los likes del usuario,
+// cada uno en JSON y en CSV
+func (s *accountService) writeExport(ctx context.Context, userID int64, fileName string) (err error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+
+	tweets, err := s.getAllTweets(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	following, err := s.getAllUsers(ctx, userID, s.followRepo.GetFollowing)
+	if err != nil {
+		return fmt.Errorf("error getting following: %w", err)
+	}
+
+	followers, err := s.getAllUsers(ctx, userID, s.followRepo.GetFollowers)
+	if err != nil {
+		return fmt.Errorf("error getting followers: %w", err)
+	}
+
+	likes, err := s.getAllLikes(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	file, err := s.exportStorage.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	archive := zip.NewWriter(file)
+
+	if err := writeJSONEntry(archive, "profile.json", model.NewAccount(user)); err != nil {
+		return err
+	}
+
+	if err := writeJSONEntry(archive, "tweets.json", tweets); err != nil {
+		return err
+	}
+	tweetRows := make([][]string, 0, len(tweets))
+	for _, tweet := range tweets {
+		tweetRows = append(tweetRows, []string{
+			strconv.FormatInt(tweet.ID, 10),
+			tweet.CreatedAt.UTC().Format(time.RFC3339),
+			tweet.Content,
+			formatOptionalID(tweet.InReplyToTweetID),
+			formatOptionalID(tweet.RetweetOfTweetID),
+			formatOptionalID(tweet.QuotedTweetID),
+		})
+	}
+	err = writeCSVEntry(archive, "tweets.csv",
+		[]string{"id", "created_at", "content", "in_reply_to_tweet_id", "retweet_of_tweet_id", "quoted_tweet_id"},
+		tweetRows)
+	if err != nil {
+		return err
+	}
+
+	userLists := []struct {
+		name  string
+		users []*model.User
+	}{
+		{"following", following},
+		{"followers", followers},
+	}
+	for _, list := range userLists {
+		if err := writeJSONEntry(archive, list.name+".json", list.users); err != nil {
+			return err
+		}
+		userRows := make([][]string, 0, len(list.users))
+		for _, u := range list.users {
+			userRows = append(userRows, []string{strconv.FormatInt(u.ID, 10), u.Username, u.DisplayName})
+		}
+		if err := writeCSVEntry(archive, list.name+".csv", []string{"id", "username", "display_name"}, userRows); err != nil {
+			return err
+		}
+	}
+
+	if err := writeJSONEntry(archive, "likes.json", likes); err != nil {
+		return err
+	}
+	likeRows := make([][]string, 0, len(likes))
+	for _, like := range likes {
+		likeRows = append(likeRows, []string{
+			strconv.FormatInt(like.TweetID, 10),
+			like.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := writeCSVEntry(archive, "likes.csv", []string{"tweet_id", "created_at"}, likeRows); err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("error writing export archive: %w", err)
+	}
+
+	return nil
+}
+
+// getAllTweets obtiene todos los tweets del usuario, del más reciente al más antiguo
+func (s *accountService) getAllTweets(ctx context.Context, userID int64) ([]*model.Tweet, error) {
+	var tweets []*model.Tweet
+
+	page := model.PageQuery{Limit: exportBatchSize}
+	for {
+		batch, err := s.tweetRepo.GetByUserID(ctx, userID, page)
+		if err != nil {
+			return nil, fmt.Errorf("error getting tweets: %w", err)
+		}
+
+		for _, tweet := range batch {
+			tweets = append(tweets, &tweet.Tweet)
+		}
+
+		if len(batch) < page.Limit {
+			return tweets, nil
+		}
+		last := batch[len(batch)-1]
+		page.Before = model.NewCursor(last.CreatedAt, last.ID)
+	}
+}
+
+// getAllUsers recorre todas las páginas de una lista de usuarios (seguidores o seguidos)
+func (s *accountService) getAllUsers(
+	ctx context.Context,
+	userID int64,
+	getPage func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error),
+) ([]*model.User, error) {
+	var users []*model.User
+
+	page := model.PageQuery{Limit: exportBatchSize}
+	for {
+		result, err := getPage(ctx, userID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, result.Users...)
+
+		if result.NextCursor == nil {
+			return users, nil
+		}
+		page.Before = result.NextCursor
+	}
+}
+
+// getAllLikes obtiene todos los likes que dio el usuario, del más reciente al más antiguo
+func (s *accountService) getAllLikes(ctx context.Context, userID int64) ([]*model.Like, error) {
+	var likes []*model.Like
+
+	page := model.PageQuery{Limit: exportBatchSize}
+	for {
+		batch, err := s.likeRepo.GetByUserID(ctx, userID, page)
+		if err != nil {
+			return nil, fmt.Errorf("error getting likes: %w", err)
+		}
+
+		likes = append(likes, batch...)
+
+		if len(batch) < page.Limit {
+			return likes, nil
+		}
+		last := batch[len(batch)-1]
+		page.Before = model.NewCursor(last.CreatedAt, last.ID)
+	}
+}
+
+// writeJSONEntry agrega al archivo un documento JSON con el valor dado
+func writeJSONEntry(archive *zip.Writer, name string, value any) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeCSVEntry agrega al archivo un CSV con el encabezado y las filas dadas
+func writeCSVEntry(archive *zip.Writer, name string, header []string, rows [][]string) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", name, err)
+	}
+
+	writer := csv.NewWriter(entry)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// formatOptionalID formatea un ID opcional para un CSV; nil queda como celda vacía
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"microx/internal/model"
+	"testing"
+	"time"
+)
+
+func newTestAccountService(userRepo *mockUserRepo, tweetRepo *mockTweetRepo, followRepo *mockFollowRepo, likeRepo *mockLikeRepo, timelineRepo *mockTimelineRepo, tweetCache *mockTweetCache, exportRepo *mockDataExportRepo, storage *mockExportStorage) AccountService {
+	return NewAccountService(userRepo, tweetRepo, followRepo, likeRepo, timelineRepo, tweetCache, exportRepo, storage, time.Hour)
+}
+
+func TestAccountService_DeleteAccount(t *testing.T) {
+	t.Run("baja y limpieza de timelines y caché", func(t *testing.T) {
+		var softDeleted int64
+		var removedFrom, removedTweets, deletedFromCache []int64
+
+		userRepo := &mockUserRepo{softDeleteFunc: func(ctx context.Context, userID int64, deletedAt time.Time) error {
+			softDeleted = userID
+			return nil
+		}}
+		tweetRepo := &mockTweetRepo{getIDsByUserFunc: func(ctx context.Context, userID int64) ([]int64, error) {
+			return []int64{10, 11}, nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			// Dos páginas de seguidores
+			if page.Before == nil {
+				return &model.UserPage{
+					Users:      []*model.User{{ID: 2}},
+					NextCursor: model.NewCursor(time.Now(), 2),
+				}, nil
+			}
+			return &model.UserPage{Users: []*model.User{{ID: 3}}}, nil
+		}}
+		timelineRepo := &mockTimelineRepo{removeTweetsFunc: func(ctx context.Context, userIDs []int64, tweetIDs []int64) error {
+			removedFrom, removedTweets = userIDs, tweetIDs
+			return nil
+		}}
+		tweetCache := &mockTweetCache{deleteFunc: func(ctx context.Context, ids ...int64) error {
+			deletedFromCache = ids
+			return nil
+		}}
+
+		service := newTestAccountService(userRepo, tweetRepo, followRepo, &mockLikeRepo{}, timelineRepo, tweetCache, newMockDataExportRepo(), newMockExportStorage())
+		if err := service.DeleteAccount(context.Background(), 1); err != nil {
+			t.Fatalf("no esperaba error: %v", err)
+		}
+
+		if softDeleted != 1 {
+			t.Errorf("esperaba dar de baja al usuario 1, obtuve %d", softDeleted)
+		}
+		if len(removedFrom) != 2 || removedFrom[0] != 2 || removedFrom[1] != 3 {
+			t.Errorf("esperaba limpiar los timelines de 2 y 3, obtuve %v", removedFrom)
+		}
+		if len(removedTweets) != 2 || len(deletedFromCache) != 2 {
+			t.Errorf("esperaba quitar los 2 tweets de timelines y caché, obtuve %v y %v", removedTweets, deletedFromCache)
+		}
+	})
+
+	t.Run("error al dar de baja no toca Redis", func(t *testing.T) {
+		userRepo := &mockUserRepo{softDeleteFunc: func(ctx context.Context, userID int64, deletedAt time.Time) error {
+			return errors.New("fallo repo")
+		}}
+		tweetRepo := &mockTweetRepo{getIDsByUserFunc: func(ctx context.Context, userID int64) ([]int64, error) {
+			return []int64{10}, nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
+		}}
+		timelineRepo := &mockTimelineRepo{removeTweetsFunc: func(ctx context.Context, userIDs []int64, tweetIDs []int64) error {
+			t.Error("no esperaba limpiar timelines")
+			return nil
+		}}
+
+		service := newTestAccountService(userRepo, tweetRepo, followRepo, &mockLikeRepo{}, timelineRepo, &mockTweetCache{}, newMockDataExportRepo(), newMockExportStorage())
+		if err := service.DeleteAccount(context.Background(), 1); err == nil {
+			t.Error("esperaba error del repositorio")
+		}
+	})
+
+	t.Run("un fallo de Redis no impide la baja", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getIDsByUserFunc: func(ctx context.Context, userID int64) ([]int64, error) {
+			return []int64{10}, nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
+		}}
+		timelineRepo := &mockTimelineRepo{removeTweetsFunc: func(ctx context.Context, userIDs []int64, tweetIDs []int64) error {
+			return errors.New("redis caído")
+		}}
+
+		service := newTestAccountService(&mockUserRepo{}, tweetRepo, followRepo, &mockLikeRepo{}, timelineRepo, &mockTweetCache{}, newMockDataExportRepo(), newMockExportStorage())
+		if err := service.DeleteAccount(context.Background(), 1); err != nil {
+			t.Errorf("no esperaba error, obtuve: %v", err)
+		}
+	})
+}
+
+func TestAccountService_PurgeDeletedAccounts(t *testing.T) {
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	var queriedBefore time.Time
+	var purged []int64
+	userRepo := &mockUserRepo{
+		deletedBeforeFunc: func(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+			queriedBefore = before
+			return []int64{1, 2}, nil
+		},
+		deleteFunc: func(ctx context.Context, userID int64) error {
+			purged = append(purged, userID)
+			return nil
+		},
+	}
+
+	exportRepo := newMockDataExportRepo()
+	export := &model.DataExport{UserID: 1}
+	exportRepo.Create(context.Background(), export)
+	exportRepo.MarkReady(context.Background(), export.ID, "export-1-1.zip", time.Now(), time.Now().Add(time.Hour))
+	storage := newMockExportStorage()
+	storage.files["export-1-1.zip"] = []byte("zip")
+
+	service := newTestAccountService(userRepo, &mockTweetRepo{}, &mockFollowRepo{}, &mockLikeRepo{}, &mockTimelineRepo{}, &mockTweetCache{}, exportRepo, storage)
+	count, err := service.PurgeDeletedAccounts(context.Background(), cutoff)
+	if err != nil || count != 2 {
+		t.Fatalf("esperaba borrar 2 cuentas, obtuve %d, err: %v", count, err)
+	}
+	if !queriedBefore.Equal(cutoff) {
+		t.Errorf("esperaba buscar cuentas dadas de baja antes de %v, obtuve %v", cutoff, queriedBefore)
+	}
+	if len(purged) != 2 || purged[0] != 1 || purged[1] != 2 {
+		t.Errorf("esperaba borrar los usuarios 1 y 2, obtuve %v", purged)
+	}
+	if _, ok := storage.files["export-1-1.zip"]; ok {
+		t.Error("esperaba borrar el archivo de la exportación")
+	}
+}
+
+func TestAccountService_RequestExport(t *testing.T) {
+	exportRepo := newMockDataExportRepo()
+	service := newTestAccountService(&mockUserRepo{}, &mockTweetRepo{}, &mockFollowRepo{}, &mockLikeRepo{}, &mockTimelineRepo{}, &mockTweetCache{}, exportRepo, newMockExportStorage())
+
+	export, err := service.RequestExport(context.Background(), 1)
+	if err != nil || export.Status != model.DataExportPending || export.UserID != 1 {
+		t.Fatalf("esperaba un pedido pendiente, obtuve err: %v, export: %+v", err, export)
+	}
+
+	t.Run("un pedido en curso impide otro", func(t *testing.T) {
+		_, err := service.RequestExport(context.Background(), 1)
+		if !errors.Is(err, ErrExportInProgress) {
+			t.Errorf("esperaba ErrExportInProgress, obtuve: %v", err)
+		}
+	})
+
+	t.Run("otro usuario puede pedir el suyo", func(t *testing.T) {
+		if _, err := service.RequestExport(context.Background(), 2); err != nil {
+			t.Errorf("no esperaba error, obtuve: %v", err)
+		}
+	})
+
+	t.Run("solo el dueño ve la exportación", func(t *testing.T) {
+		if _, err := service.GetExport(context.Background(), 2, export.ID); !errors.Is(err, ErrExportNotFound) {
+			t.Errorf("esperaba ErrExportNotFound, obtuve: %v", err)
+		}
+		if _, err := service.GetExport(context.Background(), 1, 999); !errors.Is(err, ErrExportNotFound) {
+			t.Errorf("esperaba ErrExportNotFound, obtuve: %v", err)
+		}
+	})
+
+	t.Run("no se descarga antes de estar lista", func(t *testing.T) {
+		if _, _, err := service.GetExportFile(context.Background(), 1, export.ID); !errors.Is(err, ErrExportNotReady) {
+			t.Errorf("esperaba ErrExportNotReady, obtuve: %v", err)
+		}
+	})
+}
+
+func TestAccountService_ProcessNextExport(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	replyTo := int64(50)
+
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "ana", Email: "ana@mail.com", PasswordHash: "hash"}, nil
+	}}
+	tweetRepo := &mockTweetRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return []*model.TweetWithUser{
+			{Tweet: model.Tweet{ID: 11, UserID: userID, Content: "hola, \"mundo\"", CreatedAt: createdAt}},
+			{Tweet: model.Tweet{ID: 10, UserID: userID, Content: "respuesta", InReplyToTweetID: &replyTo, CreatedAt: createdAt}},
+		}, nil
+	}}
+	followRepo := &mockFollowRepo{
+		getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2, Username: "beto"}}}, nil
+		},
+		getFollowingFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 3, Username: "caro"}, {ID: 4, Username: "dani"}}}, nil
+		},
+	}
+	likeRepo := &mockLikeRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Like, error) {
+		return []*model.Like{{ID: 1, UserID: userID, TweetID: 99, CreatedAt: createdAt}}, nil
+	}}
+
+	exportRepo := newMockDataExportRepo()
+	storage := newMockExportStorage()
+	service := newTestAccountService(userRepo, tweetRepo, followRepo, likeRepo, &mockTimelineRepo{}, &mockTweetCache{}, exportRepo, storage)
+
+	processed, err := service.ProcessNextExport(context.Background())
+	if err != nil || processed {
+		t.Fatalf("esperaba no procesar nada sin pedidos, obtuve %v, err: %v", processed, err)
+	}
+
+	export, _ := service.RequestExport(context.Background(), 1)
+	processed, err = service.ProcessNextExport(context.Background())
+	if err != nil || !processed {
+		t.Fatalf("esperaba procesar el pedido, obtuve %v, err: %v", processed, err)
+	}
+
+	export, _ = service.GetExport(context.Background(), 1, export.ID)
+	if export.Status != model.DataExportReady || export.ExpiresAt == nil {
+		t.Fatalf("esperaba la exportación lista y con vencimiento, obtuve %+v", export)
+	}
+
+	path, fileName, err := service.GetExportFile(context.Background(), 1, export.ID)
+	if err != nil || path != "/exports/"+export.FileName || fileName == "" {
+		t.Errorf("esperaba la ruta del archivo, obtuve %q, %q, err: %v", path, fileName, err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(storage.files[export.FileName]), int64(len(storage.files[export.FileName])))
+	if err != nil {
+		t.Fatalf("esperaba un zip válido: %v", err)
+	}
+	entries := map[string][]byte{}
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		entries[file.Name], _ = io.ReadAll(reader)
+		reader.Close()
+	}
+
+	for _, name := range []string{"profile.json", "tweets.json", "tweets.csv", "following.json", "following.csv", "followers.json", "followers.csv", "likes.json", "likes.csv"} {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("esperaba %s en el archivo", name)
+		}
+	}
+
+	t.Run("el perfil incluye el email pero no la contraseña", func(t *testing.T) {
+		var profile map[string]any
+		if err := json.Unmarshal(entries["profile.json"], &profile); err != nil {
+			t.Fatalf("profile.json inválido: %v", err)
+		}
+		if profile["email"] != "ana@mail.com" {
+			t.Errorf("esperaba el email en el perfil, obtuve %v", profile["email"])
+		}
+		if bytes.Contains(entries["profile.json"], []byte("hash")) {
+			t.Error("no esperaba el hash de la contraseña en el perfil")
+		}
+	})
+
+	t.Run("CSV de tweets", func(t *testing.T) {
+		rows, err := csv.NewReader(bytes.NewReader(entries["tweets.csv"])).ReadAll()
+		if err != nil || len(rows) != 3 {
+			t.Fatalf("esperaba encabezado y 2 tweets, obtuve %d filas, err: %v", len(rows), err)
+		}
+		if rows[1][2] != "hola, \"mundo\"" {
+			t.Errorf("esperaba el contenido sin alterar, obtuve %q", rows[1][2])
+		}
+		if rows[2][3] != "50" || rows[1][3] != "" {
+			t.Errorf("esperaba in_reply_to_tweet_id solo en la respuesta, obtuve %q y %q", rows[1][3], rows[2][3])
+		}
+	})
+
+	t.Run("seguidos y likes", func(t *testing.T) {
+		var following []*model.User
+		if err := json.Unmarshal(entries["following.json"], &following); err != nil || len(following) != 2 {
+			t.Errorf("esperaba 2 seguidos, obtuve %d, err: %v", len(following), err)
+		}
+		rows, _ := csv.NewReader(bytes.NewReader(entries["likes.csv"])).ReadAll()
+		if len(rows) != 2 || rows[1][0] != "99" {
+			t.Errorf("esperaba el like al tweet 99, obtuve %v", rows)
+		}
+	})
+}
+
+func TestAccountService_ProcessNextExportFailure(t *testing.T) {
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id}, nil
+	}}
+	tweetRepo := &mockTweetRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return nil, errors.New("fallo repo")
+	}}
+
+	exportRepo := newMockDataExportRepo()
+	storage := newMockExportStorage()
+	service := newTestAccountService(userRepo, tweetRepo, &mockFollowRepo{}, &mockLikeRepo{}, &mockTimelineRepo{}, &mockTweetCache{}, exportRepo, storage)
+
+	export, _ := service.RequestExport(context.Background(), 1)
+	processed, err := service.ProcessNextExport(context.Background())
+	if err == nil || !processed {
+		t.Fatalf("esperaba un error al procesar, obtuve %v, err: %v", processed, err)
+	}
+
+	export, _ = service.GetExport(context.Background(), 1, export.ID)
+	if export.Status != model.DataExportFailed || export.Error != exportFailureReason {
+		t.Errorf("esperaba la exportación fallida con el motivo genérico, obtuve %+v", export)
+	}
+	if len(storage.files) != 0 {
+		t.Errorf("no esperaba archivos guardados, obtuve %v", storage.files)
+	}
+
+	// Tras un fallo se puede volver a pedir
+	if _, err := service.RequestExport(context.Background(), 1); err != nil {
+		t.Errorf("esperaba poder pedir otra exportación, obtuve: %v", err)
+	}
+}
+
+func TestAccountService_PurgeExpiredExports(t *testing.T) {
+	exportRepo := newMockDataExportRepo()
+	storage := newMockExportStorage()
+	service := newTestAccountService(&mockUserRepo{}, &mockTweetRepo{}, &mockFollowRepo{}, &mockLikeRepo{}, &mockTimelineRepo{}, &mockTweetCache{}, exportRepo, storage)
+
+	now := time.Now()
+	for i, expiresAt := range []time.Time{now.Add(-time.Minute), now.Add(time.Hour)} {
+		export := &model.DataExport{UserID: 1}
+		exportRepo.Create(context.Background(), export)
+		fileName := []string{"vencida.zip", "vigente.zip"}[i]
+		exportRepo.MarkReady(context.Background(), export.ID, fileName, now, expiresAt)
+		storage.files[fileName] = []byte("zip")
+	}
+
+	count, err := service.PurgeExpiredExports(context.Background(), now)
+	if err != nil || count != 1 {
+		t.Fatalf("esperaba borrar 1 exportación, obtuve %d, err: %v", count, err)
+	}
+	if _, ok := storage.files["vencida.zip"]; ok {
+		t.Error("esperaba borrar el archivo vencido")
+	}
+	if _, ok := storage.files["vigente.zip"]; !ok {
+		t.Error("no esperaba borrar el archivo vigente")
+	}
+	if _, err := exportRepo.GetByID(context.Background(), 1); err == nil {
+		t.Error("esperaba borrar el registro vencido")
+	}
+}
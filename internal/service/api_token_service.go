@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strings"
+)
+
+type apiTokenService struct {
+	apiTokenRepo repository.APITokenRepository
+}
+
+// NewAPITokenService crea una nueva instancia del servicio de tokens de API
+func NewAPITokenService(apiTokenRepo repository.APITokenRepository) APITokenService {
+	return &apiTokenService{
+		apiTokenRepo: apiTokenRepo,
+	}
+}
+
+func (s *apiTokenService) CreateToken(ctx context.Context, userID int64, name string, scopes []string) (*model.CreateAPITokenResponse, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("token name cannot be empty")
+	}
+
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	token := model.APITokenPrefix + secret
+
+	apiToken := &model.APIToken{
+		UserID: userID,
+		Name:   name,
+		Scopes: scopes,
+	}
+
+	err = s.apiTokenRepo.Create(ctx, apiToken, token)
+	if err != nil {
+		return nil, fmt.Errorf("error creating api token: %w", err)
+	}
+
+	return &model.CreateAPITokenResponse{
+		Token:    token,
+		APIToken: apiToken,
+	}, nil
+}
+
+func (s *apiTokenService) ListTokens(ctx context.Context, userID int64) ([]*model.APIToken, error) {
+	apiTokens, err := s.apiTokenRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api tokens: %w", err)
+	}
+
+	return apiTokens, nil
+}
+
+func (s *apiTokenService) RevokeToken(ctx context.Context, userID, tokenID int64) error {
+	err := s.apiTokenRepo.Delete(ctx, userID, tokenID)
+	if err != nil {
+		return fmt.Errorf("error revoking api token: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeScopes valida los scopes pedidos y elimina los duplicados
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+
+	valid := make(map[string]bool, len(model.APITokenScopes))
+	for _, scope := range model.APITokenScopes {
+		valid[scope] = true
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !valid[scope] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		normalized = append(normalized, scope)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"strings"
+	"testing"
+)
+
+func TestAPITokenService_CreateToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("devuelve el token en claro con prefijo y guarda los scopes sin duplicados", func(t *testing.T) {
+		var storedToken string
+		var stored *model.APIToken
+		repo := &mockAPITokenRepo{
+			createFunc: func(ctx context.Context, apiToken *model.APIToken, token string) error {
+				apiToken.ID = 1
+				stored = apiToken
+				storedToken = token
+				return nil
+			},
+		}
+		svc := NewAPITokenService(repo)
+
+		response, err := svc.CreateToken(ctx, 5, "bot", []string{model.ScopeTweetWrite, model.ScopeTweetWrite, model.ScopeTimelineRead})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(response.Token, model.APITokenPrefix) {
+			t.Errorf("expected token with prefix %q, got %q", model.APITokenPrefix, response.Token)
+		}
+		if storedToken != response.Token {
+			t.Error("expected repository to receive the generated token")
+		}
+		if stored.UserID != 5 || len(stored.Scopes) != 2 {
+			t.Errorf("expected user 5 with 2 scopes, got user %d with %v", stored.UserID, stored.Scopes)
+		}
+	})
+
+	t.Run("scope inválido", func(t *testing.T) {
+		svc := NewAPITokenService(&mockAPITokenRepo{})
+
+		_, err := svc.CreateToken(ctx, 5, "bot", []string{"admin:all"})
+		if !errors.Is(err, ErrInvalidScope) {
+			t.Errorf("expected ErrInvalidScope, got %v", err)
+		}
+	})
+
+	t.Run("sin scopes", func(t *testing.T) {
+		svc := NewAPITokenService(&mockAPITokenRepo{})
+
+		_, err := svc.CreateToken(ctx, 5, "bot", nil)
+		if err == nil {
+			t.Error("expected error for empty scopes")
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenBytes es la cantidad de bytes aleatorios de cada token de sesión
+const tokenBytes = 32
+
+type authService struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+}
+
+// NewAuthService crea una nueva instancia del servicio de autenticación
+func NewAuthService(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	accessTTL time.Duration,
+	refreshTTL time.Duration,
+) AuthService {
+	return &authService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+	}
+}
+
+func (s *authService) Register(ctx context.Context, username, email, password string) (*model.User, error) {
+	username = strings.TrimSpace(username)
+	email = strings.TrimSpace(email)
+	if username == "" || email == "" || password == "" {
+		return nil, fmt.Errorf("username, email and password are required")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	user := &model.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: string(hash),
+	}
+
+	err = s.userRepo.Create(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *authService) Login(ctx context.Context, username, password string) (*model.AuthTokens, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// Los usuarios creados sin contraseña no pueden iniciar sesión
+	if user.PasswordHash == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.createSession(ctx, user.ID)
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*model.AuthTokens, error) {
+	// Rotar la sesión: el refresh token usado (y su access token) dejan de ser válidos. Se consume
+	// en una sola operación para que dos refresh concurrentes no creen dos sesiones.
+	userID, err := s.sessionRepo.ConsumeRefreshToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("error revoking session: %w", err)
+	}
+
+	return s.createSession(ctx, userID)
+}
+
+func (s *authService) Logout(ctx context.Context, accessToken string) error {
+	err := s.sessionRepo.RevokeByAccessToken(ctx, accessToken)
+	if err != nil {
+		return fmt.Errorf("error revoking session: %w", err)
+	}
+
+	return nil
+}
+
+// createSession emite un nuevo par de tokens para el usuario
+func (s *authService) createSession(ctx context.Context, userID int64) (*model.AuthTokens, error) {
+	accessToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &model.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}
+
+	err = s.sessionRepo.Create(ctx, userID, tokens, s.accessTTL, s.refreshTTL)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// generateToken genera un token opaco aleatorio
+func generateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+	"time"
+)
+
+func newTestAuthService(userRepo *mockUserRepo, sessionRepo *mockSessionRepo) AuthService {
+	return NewAuthService(userRepo, sessionRepo, 15*time.Minute, 24*time.Hour)
+}
+
+func TestAuthService_Register(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("guarda el hash de la contraseña y no la contraseña en claro", func(t *testing.T) {
+		var created *model.User
+		userRepo := &mockUserRepo{
+			createFunc: func(ctx context.Context, user *model.User) error {
+				user.ID = 1
+				created = user
+				return nil
+			},
+		}
+		svc := newTestAuthService(userRepo, newMockSessionRepo())
+
+		_, err := svc.Register(ctx, "jose", "jose@example.com", "secreto123")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if created.PasswordHash == "" || created.PasswordHash == "secreto123" {
+			t.Errorf("expected hashed password, got %q", created.PasswordHash)
+		}
+	})
+}
+
+func TestAuthService_Login(t *testing.T) {
+	ctx := context.Background()
+
+	var stored *model.User
+	userRepo := &mockUserRepo{
+		createFunc: func(ctx context.Context, user *model.User) error {
+			user.ID = 1
+			stored = user
+			return nil
+		},
+		getByUsernameFunc: func(ctx context.Context, username string) (*model.User, error) {
+			if stored != nil && stored.Username == username {
+				return stored, nil
+			}
+			return nil, errors.New("user not found")
+		},
+	}
+	sessionRepo := newMockSessionRepo()
+	svc := newTestAuthService(userRepo, sessionRepo)
+
+	if _, err := svc.Register(ctx, "jose", "jose@example.com", "secreto123"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Run("credenciales válidas emiten una sesión", func(t *testing.T) {
+		tokens, err := svc.Login(ctx, "jose", "secreto123")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+			t.Fatal("expected access and refresh tokens")
+		}
+		userID, err := sessionRepo.GetUserIDByAccessToken(ctx, tokens.AccessToken)
+		if err != nil || userID != 1 {
+			t.Errorf("expected session for user 1, got %d (%v)", userID, err)
+		}
+	})
+
+	t.Run("contraseña incorrecta", func(t *testing.T) {
+		_, err := svc.Login(ctx, "jose", "incorrecta")
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected ErrInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("usuario inexistente", func(t *testing.T) {
+		_, err := svc.Login(ctx, "nadie", "secreto123")
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected ErrInvalidCredentials, got %v", err)
+		}
+	})
+
+	t.Run("usuario sin contraseña no puede iniciar sesión", func(t *testing.T) {
+		stored = &model.User{ID: 2, Username: "legacy"}
+		_, err := svc.Login(ctx, "legacy", "")
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected ErrInvalidCredentials, got %v", err)
+		}
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := newMockSessionRepo()
+	svc := newTestAuthService(&mockUserRepo{}, sessionRepo)
+	sessionRepo.userIDs["refresh-viejo"] = 7
+
+	t.Run("rota el refresh token", func(t *testing.T) {
+		tokens, err := svc.Refresh(ctx, "refresh-viejo")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if tokens.RefreshToken == "refresh-viejo" {
+			t.Error("expected a new refresh token")
+		}
+		if _, ok := sessionRepo.userIDs["refresh-viejo"]; ok {
+			t.Error("expected old refresh token to be revoked")
+		}
+	})
+
+	t.Run("un refresh token ya usado es rechazado", func(t *testing.T) {
+		_, err := svc.Refresh(ctx, "refresh-viejo")
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+)
+
+type blockService struct {
+	blockRepo    repository.BlockRepository
+	followRepo   repository.FollowRepository
+	userRepo     repository.UserRepository
+	timelineRepo repository.TimelineRepository
+	tweetRepo    repository.TweetRepository
+	bookmarkRepo repository.BookmarkRepository
+}
+
+// NewBlockService crea una nueva instancia del servicio de bloqueos
+func NewBlockService(
+	blockRepo repository.BlockRepository,
+	followRepo repository.FollowRepository,
+	userRepo repository.UserRepository,
+	timelineRepo repository.TimelineRepository,
+	tweetRepo repository.TweetRepository,
+	bookmarkRepo repository.BookmarkRepository,
+) BlockService {
+	return &blockService{
+		blockRepo:    blockRepo,
+		followRepo:   followRepo,
+		userRepo:     userRepo,
+		timelineRepo: timelineRepo,
+		tweetRepo:    tweetRepo,
+		bookmarkRepo: bookmarkRepo,
+	}
+}
+
+func (s *blockService) BlockUser(ctx context.Context, blockerID, blockedID int64) error {
+	if blockerID == blockedID {
+		return fmt.Errorf("user cannot block themselves")
+	}
+
+	_, err := s.userRepo.GetByID(ctx, blockedID)
+	if err != nil {
+		return fmt.Errorf("blocked user not found: %w", err)
+	}
+
+	// Los follows se eliminan junto con el bloqueo; antes se averigua cuáles existían para
+	// limpiar después el timeline cacheado de cada lado
+	following, err := s.followRepo.Exists(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("error checking follow relationship: %w", err)
+	}
+	followed, err := s.followRepo.Exists(ctx, blockedID, blockerID)
+	if err != nil {
+		return fmt.Errorf("error checking follow relationship: %w", err)
+	}
+
+	created, err := s.blockRepo.Create(ctx, &model.Block{BlockerID: blockerID, BlockedID: blockedID})
+	if err != nil {
+		return fmt.Errorf("error creating block: %w", err)
+	}
+	if !created {
+		return ErrAlreadyBlocked
+	}
+
+	// El bloqueado ya no puede ver los tweets de quien lo bloquea, así que pierde sus guardados
+	if s.bookmarkRepo != nil {
+		if err := s.bookmarkRepo.DeleteByAuthor(ctx, blockedID, blockerID); err != nil {
+			fmt.Printf("Warning: error removing bookmarks: %v\n", err)
+		}
+	}
+
+	if s.timelineRepo != nil {
+		if following {
+			if err := removeFromFollowerTimeline(ctx, s.tweetRepo, s.timelineRepo, blockerID, blockedID); err != nil {
+				fmt.Printf("Warning: error removing from timeline: %v\n", err)
+			}
+		}
+		if followed {
+			if err := removeFromFollowerTimeline(ctx, s.tweetRepo, s.timelineRepo, blockedID, blockerID); err != nil {
+				fmt.Printf("Warning: error removing from timeline: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnblockUser levanta el bloqueo; los follows eliminados al bloquear no se restauran
+func (s *blockService) UnblockUser(ctx context.Context, blockerID, blockedID int64) error {
+	err := s.blockRepo.Delete(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("error deleting block: %w", err)
+	}
+
+	return nil
+}
+
+// checkNotBlocked devuelve ErrBlocked si alguno de otherIDs bloqueó a userID o fue bloqueado por él
+func checkNotBlocked(ctx context.Context, blockRepo repository.BlockRepository, userID int64, otherIDs ...int64) error {
+	if blockRepo == nil || len(otherIDs) == 0 {
+		return nil
+	}
+
+	blocked, err := blockRepo.GetBlockedBetween(ctx, userID, otherIDs)
+	if err != nil {
+		return fmt.Errorf("error checking blocks: %w", err)
+	}
+	if len(blocked) > 0 {
+		return ErrBlocked
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+	"time"
+)
+
+func TestBlockService_BlockUser(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "user"}, nil
+	}}
+	tweetRepo := &mockTweetRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return []*model.TweetWithUser{{Tweet: model.Tweet{ID: userID * 10, UserID: userID}}}, nil
+	}}
+
+	t.Run("bloquear limpia los timelines de ambos lados", func(t *testing.T) {
+		// 1 sigue a 2 y 2 sigue a 1
+		followRepo := &mockFollowRepo{existsFunc: func(ctx context.Context, followerID, followingID int64) (bool, error) {
+			return true, nil
+		}}
+		removed := map[int64][]int64{}
+		timelineRepo := &mockTimelineRepo{removeFromTimelineFunc: func(ctx context.Context, userID int64, tweetID int64) error {
+			removed[userID] = append(removed[userID], tweetID)
+			return nil
+		}}
+		blockRepo := newMockBlockRepo()
+		service := NewBlockService(blockRepo, followRepo, userRepo, timelineRepo, tweetRepo, nil)
+
+		if err := service.BlockUser(ctx, 1, 2); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !blockRepo.blocks[[2]int64{1, 2}] {
+			t.Error("esperaba registrar el bloqueo")
+		}
+		if len(removed[1]) != 1 || removed[1][0] != 20 || len(removed[2]) != 1 || removed[2][0] != 10 {
+			t.Errorf("esperaba quitar los tweets de cada uno del timeline del otro, obtuve %v", removed)
+		}
+	})
+
+	t.Run("sin follows no toca los timelines", func(t *testing.T) {
+		timelineRepo := &mockTimelineRepo{removeFromTimelineFunc: func(ctx context.Context, userID int64, tweetID int64) error {
+			t.Errorf("no esperaba limpiar el timeline de %d", userID)
+			return nil
+		}}
+		service := NewBlockService(newMockBlockRepo(), &mockFollowRepo{}, userRepo, timelineRepo, tweetRepo, nil)
+		if err := service.BlockUser(ctx, 1, 2); err != nil {
+			t.Errorf("error inesperado: %v", err)
+		}
+	})
+
+	t.Run("bloquear elimina los guardados del bloqueado sobre tweets de quien bloquea", func(t *testing.T) {
+		bookmarkRepo := &mockBookmarkRepo{}
+		service := NewBlockService(newMockBlockRepo(), &mockFollowRepo{}, userRepo, &mockTimelineRepo{}, tweetRepo, bookmarkRepo)
+		if err := service.BlockUser(ctx, 1, 2); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(bookmarkRepo.deletedByAuthor) != 1 || bookmarkRepo.deletedByAuthor[0] != [2]int64{2, 1} {
+			t.Errorf("esperaba eliminar los guardados de 2 sobre tweets de 1, obtuve %v", bookmarkRepo.deletedByAuthor)
+		}
+	})
+
+	t.Run("usuario ya bloqueado", func(t *testing.T) {
+		service := NewBlockService(newMockBlockRepo([2]int64{1, 2}), &mockFollowRepo{}, userRepo, &mockTimelineRepo{}, tweetRepo, nil)
+		if err := service.BlockUser(ctx, 1, 2); !errors.Is(err, ErrAlreadyBlocked) {
+			t.Errorf("esperaba ErrAlreadyBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("bloquearse a sí mismo", func(t *testing.T) {
+		service := NewBlockService(newMockBlockRepo(), &mockFollowRepo{}, userRepo, &mockTimelineRepo{}, tweetRepo, nil)
+		if err := service.BlockUser(ctx, 1, 1); err == nil {
+			t.Error("esperaba error al bloquearse a sí mismo")
+		}
+	})
+}
+
+func TestBlocks_PreventInteractions(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{
+		getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "user"}, nil
+		},
+		getByUsernamesFunc: func(ctx context.Context, usernames []string) ([]*model.User, error) {
+			return []*model.User{{ID: 2, Username: "bloqueador"}}, nil
+		},
+	}
+	// 2 bloqueó a 1
+	blockRepo := newMockBlockRepo([2]int64{2, 1})
+
+	t.Run("no se puede seguir en ninguna dirección", func(t *testing.T) {
+		service := NewFollowService(&mockFollowRepo{}, userRepo, nil, &mockTweetRepo{}, nil, blockRepo)
+		if _, err := service.FollowUser(ctx, 1, 2); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked del bloqueado, obtuve: %v", err)
+		}
+		if _, err := service.FollowUser(ctx, 2, 1); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked del bloqueador, obtuve: %v", err)
+		}
+	})
+
+	tweetRepo := &mockTweetRepo{
+		getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+		},
+		createFunc: func(ctx context.Context, tweet *model.Tweet) error {
+			t.Error("no esperaba que se cree el tweet")
+			return nil
+		},
+	}
+	service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, blockRepo, nil, 280, time.Hour)
+
+	t.Run("no se puede responder", func(t *testing.T) {
+		if _, err := service.ReplyToTweet(ctx, 1, 5, "hola"); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("no se puede mencionar", func(t *testing.T) {
+		if _, err := service.CreateTweet(ctx, 1, "hola @bloqueador"); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el bloqueado no ve los tweets del bloqueador", func(t *testing.T) {
+		if _, err := service.GetUserTweets(ctx, 1, 2, model.PageQuery{Limit: 20}); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el bloqueado no ve los hilos del bloqueador", func(t *testing.T) {
+		if _, err := service.GetThread(ctx, 1, 5, 20, 0); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("las respuestas de un hilo ajeno se filtran para el bloqueado", func(t *testing.T) {
+		var repliesViewer int64
+		threadRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3}}, nil
+			},
+			getRepliesFunc: func(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+				repliesViewer = viewerID
+				return nil, nil
+			},
+		}
+		service := NewTweetService(threadRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, blockRepo, nil, 280, time.Hour)
+		if _, err := service.GetThread(ctx, 1, 5, 20, 0); err != nil || repliesViewer != 1 {
+			t.Errorf("esperaba leer las respuestas visibles para 1, obtuve err: %v, viewer: %d", err, repliesViewer)
+		}
+	})
+
+	t.Run("el bloqueador sí ve los tweets del bloqueado", func(t *testing.T) {
+		if _, err := service.GetUserTweets(ctx, 2, 1, model.PageQuery{Limit: 20}); err != nil {
+			t.Errorf("error inesperado: %v", err)
+		}
+	})
+}
+
+func TestTimelineService_GetTimeline_HidesBlockers(t *testing.T) {
+	ctx := context.Background()
+	blockerTweet := &model.TweetWithUser{Tweet: model.Tweet{ID: 1, UserID: 2}}
+	tweets := []*model.TweetWithUser{
+		{Tweet: model.Tweet{ID: 3, UserID: 3, RetweetOfTweetID: &blockerTweet.ID}, RetweetedTweet: blockerTweet},
+		{Tweet: model.Tweet{ID: 2, UserID: 3}},
+	}
+	tweetRepo := &mockTweetRepo{getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return tweets, nil
+	}}
+
+	// 2 bloqueó a 1: su tweet no debe llegar a 1 aunque lo retuitee alguien que sigue
+	service := NewTimelineService(&mockTimelineRepo{}, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, newMockBlockRepo([2]int64{2, 1}), nil, 0)
+	resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if len(resp.Tweets) != 1 || resp.Tweets[0].ID != 2 {
+		t.Errorf("esperaba solo el tweet 2, obtuve %+v", resp.Tweets)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+)
+
+type bookmarkService struct {
+	bookmarkRepo repository.BookmarkRepository
+	tweetRepo    repository.TweetRepository
+	userRepo     repository.UserRepository
+	followRepo   repository.FollowRepository
+	likeRepo     repository.LikeRepository
+	blockRepo    repository.BlockRepository
+	hydrator     *tweetHydrator
+}
+
+// NewBookmarkService crea una nueva instancia del servicio de tweets guardados
+func NewBookmarkService(
+	bookmarkRepo repository.BookmarkRepository,
+	tweetRepo repository.TweetRepository,
+	userRepo repository.UserRepository,
+	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	tweetCache repository.TweetCacheRepository,
+	blockRepo repository.BlockRepository,
+) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		tweetRepo:    tweetRepo,
+		userRepo:     userRepo,
+		followRepo:   followRepo,
+		likeRepo:     likeRepo,
+		blockRepo:    blockRepo,
+		hydrator:     newTweetHydrator(tweetCache, tweetRepo),
+	}
+}
+
+// BookmarkTweet guarda un tweet que el usuario puede ver. Guardar un retweet guarda el original.
+func (s *bookmarkService) BookmarkTweet(ctx context.Context, userID, tweetID int64) error {
+	tweet, err := resolveOriginalTweet(ctx, s.tweetRepo, tweetID)
+	if err != nil {
+		return err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, tweet.UserID)
+	if err != nil {
+		return fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, userID, author); err != nil {
+		return err
+	}
+
+	created, err := s.bookmarkRepo.Create(ctx, &model.Bookmark{UserID: userID, TweetID: tweet.ID})
+	if err != nil {
+		return fmt.Errorf("error bookmarking tweet: %w", err)
+	}
+
+	if !created {
+		return ErrAlreadyBookmarked
+	}
+
+	return nil
+}
+
+func (s *bookmarkService) RemoveBookmark(ctx context.Context, userID, tweetID int64) error {
+	tweet, err := resolveOriginalTweet(ctx, s.tweetRepo, tweetID)
+	if err != nil {
+		return err
+	}
+
+	err = s.bookmarkRepo.Delete(ctx, userID, tweet.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrBookmarkNotFound
+		}
+		return fmt.Errorf("error removing bookmark: %w", err)
+	}
+
+	return nil
+}
+
+// GetBookmarks pagina por la fecha en que se guardó cada tweet, no por la del tweet. Los tweets
+// borrados se eliminan de los guardados en cascada; además se omiten los que retuitean o citan a
+// cuentas que bloquearon al usuario, y los de cuentas protegidas que el usuario ya no puede ver.
+func (s *bookmarkService) GetBookmarks(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error) {
+	bookmarks, err := s.bookmarkRepo.GetByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bookmarks: %w", err)
+	}
+
+	tweetIDs := make([]int64, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		tweetIDs[i] = bookmark.TweetID
+	}
+
+	tweets, err := s.hydrator.Hydrate(ctx, tweetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error hydrating bookmarks: %w", err)
+	}
+
+	filter := loadTimelineFilter(ctx, s.blockRepo, nil, userID)
+	if err := s.hideProtectedAuthors(ctx, userID, tweets, filter); err != nil {
+		return nil, err
+	}
+	responses := toTweetResponses(filter.apply(tweets))
+	applyLikes(ctx, s.likeRepo, userID, responses)
+	applyBookmarks(ctx, s.bookmarkRepo, userID, responses)
+
+	result := &model.TweetPage{
+		Tweets:      responses,
+		SinceCursor: page.Since,
+	}
+	if len(bookmarks) > 0 {
+		result.SinceCursor = model.NewCursor(bookmarks[0].CreatedAt, bookmarks[0].ID)
+		if len(bookmarks) >= page.Limit {
+			last := bookmarks[len(bookmarks)-1]
+			result.NextCursor = model.NewCursor(last.CreatedAt, last.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// hideProtectedAuthors agrega al filtro los autores de los tweets (y de los tweets que embeben) con
+// la cuenta protegida que userID no sigue: la cuenta pudo protegerse, o el usuario dejar de
+// seguirla, después de guardar el tweet
+func (s *bookmarkService) hideProtectedAuthors(ctx context.Context, userID int64, tweets []*model.TweetWithUser, filter *timelineFilter) error {
+	seen := make(map[int64]bool)
+	var authorIDs []int64
+	for _, tweet := range tweets {
+		for _, t := range []*model.TweetWithUser{tweet, tweet.RetweetedTweet, tweet.QuotedTweet} {
+			if t != nil && t.UserID != userID && !seen[t.UserID] {
+				seen[t.UserID] = true
+				authorIDs = append(authorIDs, t.UserID)
+			}
+		}
+	}
+	if len(authorIDs) == 0 {
+		return nil
+	}
+
+	authors, err := s.userRepo.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		return fmt.Errorf("error getting bookmark authors: %w", err)
+	}
+
+	var protectedIDs []int64
+	for _, author := range authors {
+		if author.Protected {
+			protectedIDs = append(protectedIDs, author.ID)
+		}
+	}
+
+	return filter.hideUnfollowed(ctx, s.followRepo, userID, protectedIDs)
+}
+
+// applyBookmarks completa bookmarked_by_me de las respuestas (y de los tweets que embeben) con una
+// consulta por página. Para un usuario anónimo (viewerID 0) queda en false.
+func applyBookmarks(ctx context.Context, bookmarkRepo repository.BookmarkRepository, viewerID int64, responses []*model.TweetResponse) {
+	if bookmarkRepo == nil || viewerID <= 0 || len(responses) == 0 {
+		return
+	}
+
+	var all []*model.TweetResponse
+	for _, response := range responses {
+		all = append(all, response)
+		if response.RetweetedTweet != nil {
+			all = append(all, response.RetweetedTweet)
+		}
+		if response.QuotedTweet != nil {
+			all = append(all, response.QuotedTweet)
+		}
+	}
+
+	tweetIDs := make([]int64, 0, len(all))
+	for _, response := range all {
+		tweetIDs = append(tweetIDs, response.ID)
+	}
+
+	bookmarked, err := bookmarkRepo.GetBookmarkedTweetIDs(ctx, viewerID, tweetIDs)
+	if err != nil {
+		fmt.Printf("Warning: error getting bookmarked tweets: %v\n", err)
+		return
+	}
+
+	for _, response := range all {
+		response.BookmarkedByMe = bookmarked[response.ID]
+	}
+}
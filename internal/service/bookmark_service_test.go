@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+)
+
+func TestBookmarkService_BookmarkTweet(t *testing.T) {
+	ctx := context.Background()
+	originalID := int64(7)
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		if id == originalID {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+		}
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3, RetweetOfTweetID: &originalID}}, nil
+	}}
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "autor"}, nil
+	}}
+
+	t.Run("guardar un retweet guarda el original", func(t *testing.T) {
+		bookmarkRepo := &mockBookmarkRepo{}
+		service := NewBookmarkService(bookmarkRepo, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil, nil)
+		err := service.BookmarkTweet(ctx, 1, 50)
+		if err != nil || len(bookmarkRepo.bookmarks) != 1 || bookmarkRepo.bookmarks[0].TweetID != originalID {
+			t.Errorf("esperaba guardar el tweet %d, obtuve err: %v, guardados: %v", originalID, err, bookmarkRepo.bookmarks)
+		}
+	})
+
+	t.Run("guardado duplicado", func(t *testing.T) {
+		bookmarkRepo := &mockBookmarkRepo{bookmarks: []*model.Bookmark{{UserID: 1, TweetID: originalID}}}
+		service := NewBookmarkService(bookmarkRepo, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil, nil)
+		err := service.BookmarkTweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrAlreadyBookmarked) {
+			t.Errorf("esperaba ErrAlreadyBookmarked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el autor bloqueó al usuario", func(t *testing.T) {
+		bookmarkRepo := &mockBookmarkRepo{}
+		service := NewBookmarkService(bookmarkRepo, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil, newMockBlockRepo([2]int64{2, 1}))
+		err := service.BookmarkTweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrBlocked) || len(bookmarkRepo.bookmarks) != 0 {
+			t.Errorf("esperaba ErrBlocked sin guardar, obtuve: %v", err)
+		}
+	})
+
+	t.Run("cuenta protegida que no sigue", func(t *testing.T) {
+		protectedRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "privado", Protected: true}, nil
+		}}
+		service := NewBookmarkService(&mockBookmarkRepo{}, tweetRepo, protectedRepo, &mockFollowRepo{}, nil, nil, nil)
+		err := service.BookmarkTweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+}
+
+func TestBookmarkService_RemoveBookmark(t *testing.T) {
+	ctx := context.Background()
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+	}}
+
+	bookmarkRepo := &mockBookmarkRepo{bookmarks: []*model.Bookmark{{UserID: 1, TweetID: 7}}}
+	service := NewBookmarkService(bookmarkRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil)
+
+	if err := service.RemoveBookmark(ctx, 1, 7); err != nil || len(bookmarkRepo.bookmarks) != 0 {
+		t.Errorf("esperaba quitar el guardado, obtuve err: %v", err)
+	}
+	if err := service.RemoveBookmark(ctx, 1, 7); !errors.Is(err, ErrBookmarkNotFound) {
+		t.Errorf("esperaba ErrBookmarkNotFound, obtuve: %v", err)
+	}
+}
+
+func TestBookmarkService_GetBookmarks(t *testing.T) {
+	ctx := context.Background()
+	// El tweet 30 se borró; el 40 retuitea un tweet de la cuenta 9, que bloqueó al usuario
+	blockedID := int64(11)
+	tweets := []*model.TweetWithUser{
+		{Tweet: model.Tweet{ID: 10, UserID: 2}},
+		{Tweet: model.Tweet{ID: 20, UserID: 3}},
+		{Tweet: model.Tweet{ID: 40, UserID: 4, RetweetOfTweetID: &blockedID}},
+		{Tweet: model.Tweet{ID: blockedID, UserID: 9}},
+	}
+	bookmarkRepo := &mockBookmarkRepo{}
+	for _, tweetID := range []int64{20, 30, 10, 40} {
+		bookmarkRepo.Create(ctx, &model.Bookmark{UserID: 1, TweetID: tweetID})
+	}
+	service := NewBookmarkService(bookmarkRepo, tweetsByID(tweets), &mockUserRepo{}, &mockFollowRepo{}, nil, nil, newMockBlockRepo([2]int64{9, 1}))
+
+	t.Run("ordena por fecha de guardado y omite los tweets no visibles", func(t *testing.T) {
+		page, err := service.GetBookmarks(ctx, 1, model.PageQuery{Limit: 10})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(page, 10, 20) {
+			t.Errorf("esperaba los tweets 10 y 20, obtuve %v", page.Tweets)
+		}
+		for _, tweet := range page.Tweets {
+			if !tweet.BookmarkedByMe {
+				t.Errorf("esperaba bookmarked_by_me en el tweet %d", tweet.ID)
+			}
+		}
+	})
+
+	t.Run("el cursor apunta al guardado, no al tweet", func(t *testing.T) {
+		page, err := service.GetBookmarks(ctx, 1, model.PageQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		// Los dos guardados más recientes son el del tweet 40 (oculto) y el del tweet 10
+		if !hasTweetIDs(page, 10) || page.NextCursor == nil || page.NextCursor.ID != 3 {
+			t.Errorf("esperaba el tweet 10 y un cursor al guardado 3, obtuve %v, cursor %v", page.Tweets, page.NextCursor)
+		}
+	})
+
+	t.Run("omite las cuentas protegidas que ya no sigue", func(t *testing.T) {
+		// 2 y 3 protegieron su cuenta después del guardado; el usuario solo sigue a 3
+		userRepo := &mockUserRepo{getByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.User, error) {
+			var users []*model.User
+			for _, id := range ids {
+				users = append(users, &model.User{ID: id, Protected: id == 2 || id == 3})
+			}
+			return users, nil
+		}}
+		followRepo := &mockFollowRepo{getFollowedAmongFunc: func(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error) {
+			return []int64{3}, nil
+		}}
+		service := NewBookmarkService(bookmarkRepo, tweetsByID(tweets), userRepo, followRepo, nil, nil, newMockBlockRepo([2]int64{9, 1}))
+
+		page, err := service.GetBookmarks(ctx, 1, model.PageQuery{Limit: 10})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(page, 20) {
+			t.Errorf("esperaba solo el tweet 20, obtuve %v", page.Tweets)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// mentionPattern reconoce @username al inicio del contenido o después de un carácter que no puede
+// formar parte de un username, para no confundir direcciones de email con menciones
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])(@\w{1,50})`)
+
+// hashtagPattern reconoce #hashtag con letras de cualquier idioma, números y guiones bajos, al inicio
+// del contenido o después de un carácter que no forme parte de la etiqueta (para ignorar URLs con #)
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&#/])(#[\p{L}\p{N}_]{1,100})`)
+
+// hashtagTagPattern valida un hashtag normalizado recibido por parámetro
+var hashtagTagPattern = regexp.MustCompile(`^[\p{L}\p{N}_]{1,100}$`)
+
+// extractMentions devuelve las menciones del contenido en orden de aparición, sin resolver sus usuarios
+func extractMentions(content string) []*model.Mention {
+	var mentions []*model.Mention
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		start, end := match[2], match[3]
+		// Las posiciones se devuelven en caracteres, no en bytes
+		runeStart := utf8.RuneCountInString(content[:start])
+		mentions = append(mentions, &model.Mention{
+			Username: content[start+1 : end],
+			Start:    runeStart,
+			End:      runeStart + utf8.RuneCountInString(content[start:end]),
+		})
+	}
+	return mentions
+}
+
+// extractHashtags devuelve los hashtags del contenido en orden de aparición. Las etiquetas formadas
+// solo por números (#1) no se consideran hashtags.
+func extractHashtags(content string) []*model.Hashtag {
+	var hashtags []*model.Hashtag
+	for _, match := range hashtagPattern.FindAllStringSubmatchIndex(content, -1) {
+		start, end := match[2], match[3]
+		tag := content[start+1 : end]
+		if strings.Trim(tag, "0123456789") == "" {
+			continue
+		}
+
+		runeStart := utf8.RuneCountInString(content[:start])
+		hashtags = append(hashtags, &model.Hashtag{
+			Tag:   tag,
+			Start: runeStart,
+			End:   runeStart + utf8.RuneCountInString(content[start:end]),
+		})
+	}
+	return hashtags
+}
+
+// hashtagTags devuelve los hashtags normalizados del contenido, sin repetir
+func hashtagTags(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, hashtag := range extractHashtags(content) {
+		tag := normalizeHashtag(hashtag.Tag)
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// normalizeHashtag lleva un hashtag a la forma con la que se indexa: sin # y en minúsculas
+func normalizeHashtag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(tag, "#"))
+}
+
+// resolveMentions extrae las menciones del contenido y las asocia a sus usuarios con una sola
+// consulta. Las menciones a usernames que no existen se descartan.
+func resolveMentions(ctx context.Context, userRepo repository.UserRepository, content string) ([]*model.Mention, error) {
+	mentions := extractMentions(content)
+	if len(mentions) == 0 {
+		return nil, nil
+	}
+
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, mention := range mentions {
+		key := strings.ToLower(mention.Username)
+		if !seen[key] {
+			seen[key] = true
+			usernames = append(usernames, mention.Username)
+		}
+	}
+
+	users, err := userRepo.GetByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving mentions: %w", err)
+	}
+
+	byUsername := make(map[string]*model.User, len(users))
+	for _, user := range users {
+		byUsername[strings.ToLower(user.Username)] = user
+	}
+
+	var resolved []*model.Mention
+	for _, mention := range mentions {
+		user, ok := byUsername[strings.ToLower(mention.Username)]
+		if !ok {
+			continue
+		}
+		mention.UserID = user.ID
+		mention.Username = user.Username
+		resolved = append(resolved, mention)
+	}
+
+	return resolved, nil
+}
+
+// mentionedUserIDs devuelve los usuarios mencionados, sin repetir
+func mentionedUserIDs(mentions []*model.Mention) []int64 {
+	var userIDs []int64
+	seen := make(map[int64]bool)
+	for _, mention := range mentions {
+		if !seen[mention.UserID] {
+			seen[mention.UserID] = true
+			userIDs = append(userIDs, mention.UserID)
+		}
+	}
+	return userIDs
+}
+
+// toTweetEntities arma las entidades de la respuesta de un tweet. Los hashtags no dependen de otros
+// datos, así que se calculan a partir del contenido.
+func toTweetEntities(tweet *model.TweetWithUser) model.TweetEntities {
+	mentions := tweet.Mentions
+	if mentions == nil {
+		mentions = []*model.Mention{}
+	}
+	hashtags := extractHashtags(tweet.Content)
+	if hashtags == nil {
+		hashtags = []*model.Hashtag{}
+	}
+	return model.TweetEntities{Mentions: mentions, Hashtags: hashtags}
+}
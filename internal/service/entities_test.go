@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"microx/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractMentions(t *testing.T) {
+	t.Run("posiciones en caracteres", func(t *testing.T) {
+		mentions := extractMentions("¡Hola @jose y @rocio_2!")
+		if len(mentions) != 2 {
+			t.Fatalf("esperaba 2 menciones, obtuve %d", len(mentions))
+		}
+		if mentions[0].Username != "jose" || mentions[0].Start != 6 || mentions[0].End != 11 {
+			t.Errorf("mención inesperada: %+v", mentions[0])
+		}
+		if mentions[1].Username != "rocio_2" || mentions[1].Start != 14 || mentions[1].End != 22 {
+			t.Errorf("mención inesperada: %+v", mentions[1])
+		}
+	})
+
+	t.Run("ignora emails y @ sueltas", func(t *testing.T) {
+		mentions := extractMentions("escribime a jose@example.com o @ nadie @@doble")
+		if len(mentions) != 0 {
+			t.Errorf("no esperaba menciones, obtuve %+v", mentions)
+		}
+	})
+}
+
+func TestResolveMentions(t *testing.T) {
+	ctx := context.Background()
+	var queried []string
+	userRepo := &mockUserRepo{getByUsernamesFunc: func(ctx context.Context, usernames []string) ([]*model.User, error) {
+		queried = usernames
+		return []*model.User{{ID: 2, Username: "Jose"}}, nil
+	}}
+
+	mentions, err := resolveMentions(ctx, userRepo, "@jose @JOSE @fantasma")
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	// Cada username se consulta una sola vez y sin distinguir mayúsculas
+	if strings.Join(queried, ",") != "jose,fantasma" {
+		t.Errorf("esperaba consultar jose y fantasma, obtuve %v", queried)
+	}
+	if len(mentions) != 2 || mentions[0].UserID != 2 || mentions[1].UserID != 2 || mentions[1].Username != "Jose" {
+		t.Errorf("esperaba dos menciones a Jose, obtuve %+v", mentions)
+	}
+	if ids := mentionedUserIDs(mentions); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("esperaba un único usuario mencionado, obtuve %v", ids)
+	}
+}
+
+func TestTweetService_Mentions(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{
+		getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "testuser"}, nil
+		},
+		getByUsernamesFunc: func(ctx context.Context, usernames []string) ([]*model.User, error) {
+			var users []*model.User
+			for _, username := range usernames {
+				switch username {
+				case "rocio":
+					users = append(users, &model.User{ID: 2, Username: "rocio"})
+				case "axel":
+					users = append(users, &model.User{ID: 4, Username: "axel"})
+				}
+			}
+			return users, nil
+		},
+	}
+
+	t.Run("crear guarda las menciones y avisa a los mencionados", func(t *testing.T) {
+		var stored []*model.Mention
+		tweetRepo := &mockTweetRepo{createFunc: func(ctx context.Context, tweet *model.Tweet) error {
+			stored = tweet.Mentions
+			return nil
+		}}
+		var notified []int64
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+			if event.Channel == model.EventChannelMentions {
+				notified = append(notified, userIDs...)
+			}
+			return nil
+		}}
+
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, eventRepo, nil, nil, nil, 280, time.Hour)
+		resp, err := service.CreateTweet(ctx, 1, "hola @rocio y @desconocido")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if len(stored) != 1 || stored[0].UserID != 2 {
+			t.Errorf("esperaba guardar la mención a rocio, obtuve %+v", stored)
+		}
+		if len(resp.Entities.Mentions) != 1 || resp.Entities.Mentions[0].Start != 5 {
+			t.Errorf("esperaba la mención en las entidades, obtuve %+v", resp.Entities)
+		}
+		if len(notified) != 1 || notified[0] != 2 {
+			t.Errorf("esperaba avisar a rocio, obtuve %v", notified)
+		}
+	})
+
+	t.Run("editar solo avisa a los nuevos mencionados", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{
+				ID:        id,
+				UserID:    1,
+				Content:   "hola @rocio",
+				Mentions:  []*model.Mention{{UserID: 2, Username: "rocio", Start: 5, End: 11}},
+				CreatedAt: time.Now(),
+			}}, nil
+		}}
+		var notified []int64
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+			notified = append(notified, userIDs...)
+			return nil
+		}}
+
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, eventRepo, nil, nil, nil, 280, time.Hour)
+		resp, err := service.UpdateTweet(ctx, 1, 9, "hola @rocio y @axel")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if len(resp.Entities.Mentions) != 2 {
+			t.Errorf("esperaba 2 menciones, obtuve %+v", resp.Entities.Mentions)
+		}
+		if len(notified) != 1 || notified[0] != 4 {
+			t.Errorf("esperaba avisar solo a axel, obtuve %v", notified)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import "errors"
+
+// Errores de negocio que los handlers traducen a códigos HTTP específicos
+var (
+	ErrTweetNotFound      = errors.New("tweet not found")
+	ErrNotTweetAuthor     = errors.New("only the author can modify this tweet")
+	ErrEditWindowExpired  = errors.New("tweet can no longer be edited")
+	ErrAlreadyRetweeted   = errors.New("tweet already retweeted")
+	ErrAlreadyLiked       = errors.New("tweet already liked")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrInvalidScope       = errors.New("invalid api token scope")
+	ErrStreamUnavailable  = errors.New("live timeline updates are not available")
+	ErrInvalidHashtag     = errors.New("invalid hashtag")
+	ErrInvalidTrendWindow = errors.New("invalid trends window")
+	ErrInvalidSearch      = errors.New("invalid search query")
+	ErrInvalidProfile     = errors.New("invalid profile")
+	ErrExportInProgress   = errors.New("a data export is already in progress")
+	ErrExportNotFound     = errors.New("data export not found")
+	ErrExportNotReady     = errors.New("data export is not ready yet")
+	ErrProtectedAccount   = errors.New("this account's tweets are protected")
+	ErrBlocked            = errors.New("action not allowed: one of the users has blocked the other")
+	ErrAlreadyBlocked     = errors.New("user already blocked")
+	ErrInvalidMute        = errors.New("invalid mute")
+	ErrAlreadyMuted       = errors.New("already muted")
+	ErrMuteNotFound       = errors.New("mute not found")
+	ErrInvalidList        = errors.New("invalid list")
+	ErrListNotFound       = errors.New("list not found")
+	ErrNotListOwner       = errors.New("only the owner can modify this list")
+	ErrAlreadyListMember  = errors.New("user is already a member of the list")
+	ErrListMemberNotFound = errors.New("user is not a member of the list")
+	ErrAlreadySubscribed  = errors.New("already subscribed to the list")
+	ErrNotSubscribed      = errors.New("not subscribed to the list")
+	ErrAlreadyBookmarked  = errors.New("tweet already bookmarked")
+	ErrBookmarkNotFound   = errors.New("bookmark not found")
+)
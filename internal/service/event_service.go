@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+type eventService struct {
+	eventRepo    repository.EventRepository
+	followRepo   repository.FollowRepository
+	likeRepo     repository.LikeRepository
+	bookmarkRepo repository.BookmarkRepository
+	blockRepo    repository.BlockRepository
+	muteRepo     repository.MuteRepository
+	hydrator     *tweetHydrator
+	// fanoutThreshold indica qué cuentas seguidas publican sus tweets en su propio canal
+	fanoutThreshold int
+}
+
+// NewEventService crea una nueva instancia del servicio de eventos en tiempo real
+func NewEventService(
+	eventRepo repository.EventRepository,
+	tweetRepo repository.TweetRepository,
+	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+	tweetCache repository.TweetCacheRepository,
+	blockRepo repository.BlockRepository,
+	muteRepo repository.MuteRepository,
+	fanoutThreshold int,
+) EventService {
+	return &eventService{
+		eventRepo:       eventRepo,
+		followRepo:      followRepo,
+		likeRepo:        likeRepo,
+		bookmarkRepo:    bookmarkRepo,
+		blockRepo:       blockRepo,
+		muteRepo:        muteRepo,
+		hydrator:        newTweetHydrator(tweetCache, tweetRepo),
+		fanoutThreshold: fanoutThreshold,
+	}
+}
+
+func (s *eventService) Subscribe(ctx context.Context, userID int64) (<-chan *model.EventMessage, error) {
+	if s.eventRepo == nil {
+		return nil, ErrStreamUnavailable
+	}
+
+	// Los tweets de las cuentas sobre el umbral no llegan al timeline, se publican en el canal del autor
+	var authorIDs []int64
+	if s.fanoutThreshold > 0 {
+		var err error
+		authorIDs, err = s.followRepo.GetFollowingIDsOverThreshold(ctx, userID, int64(s.fanoutThreshold))
+		if err != nil {
+			fmt.Printf("Warning: error getting high-follower accounts: %v\n", err)
+		}
+	}
+
+	events, err := s.eventRepo.Subscribe(ctx, userID, authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to events: %w", err)
+	}
+
+	// Los filtros se arman una vez por suscripción: un bloqueo o silencio posterior aplica al
+	// reconectar. Los silencios, como en GET /api/timeline, solo afectan al canal timeline.
+	blockFilter := loadTimelineFilter(ctx, s.blockRepo, nil, userID)
+	timelineFilter := loadTimelineFilter(ctx, s.blockRepo, s.muteRepo, userID)
+
+	messages := make(chan *model.EventMessage)
+	go func() {
+		defer close(messages)
+
+		for event := range events {
+			message := &model.EventMessage{
+				Channel:   event.Channel,
+				Type:      event.Type,
+				ActorID:   event.ActorID,
+				CreatedAt: event.CreatedAt,
+			}
+
+			if event.TweetID != 0 {
+				hydrated, err := s.hydrator.Hydrate(ctx, []int64{event.TweetID})
+				if err != nil {
+					fmt.Printf("Warning: error hydrating tweet %d: %v\n", event.TweetID, err)
+					continue
+				}
+				if len(hydrated) == 0 {
+					continue // El tweet se borró antes de enviarse
+				}
+				filter := blockFilter
+				if event.Channel == model.EventChannelTimeline {
+					filter = timelineFilter
+				}
+				if hydrated = filter.apply(hydrated); len(hydrated) == 0 {
+					continue
+				}
+
+				responses := toTweetResponses(hydrated)
+				applyLikes(ctx, s.likeRepo, userID, responses)
+				applyBookmarks(ctx, s.bookmarkRepo, userID, responses)
+				message.Tweet = responses[0]
+			}
+
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// publishEvent envía un evento en tiempo real a un usuario. Los eventos son best-effort: un error
+// solo se registra, y no se avisa a un usuario de sus propias acciones.
+func publishEvent(ctx context.Context, eventRepo repository.EventRepository, userID int64, event *model.Event) {
+	if eventRepo == nil || userID == event.ActorID {
+		return
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if err := eventRepo.PublishToUsers(ctx, []int64{userID}, event); err != nil {
+		fmt.Printf("Warning: error publishing %s event to user %d: %v\n", event.Type, userID, err)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+	"time"
+)
+
+func TestEventService_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("hidrata el tweet de cada evento", func(t *testing.T) {
+		tweets := []*model.TweetWithUser{{Tweet: model.Tweet{ID: 9, UserID: 2, Content: "hola"}}}
+		eventRepo := &mockEventRepo{subscribeFunc: func(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+			events := make(chan *model.Event, 2)
+			events <- &model.Event{Channel: model.EventChannelNotifications, Type: model.EventTypeLike, ActorID: 2, TweetID: 9}
+			events <- &model.Event{Channel: model.EventChannelFollows, Type: model.EventTypeFollow, ActorID: 3}
+			close(events)
+			return events, nil
+		}}
+
+		service := NewEventService(eventRepo, tweetsByID(tweets), &mockFollowRepo{}, &mockLikeRepo{}, nil, nil, nil, nil, 0)
+		messages, err := service.Subscribe(ctx, 1)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		var received []*model.EventMessage
+		for message := range messages {
+			received = append(received, message)
+		}
+
+		if len(received) != 2 {
+			t.Fatalf("esperaba 2 eventos, obtuve %d", len(received))
+		}
+		if received[0].Tweet == nil || received[0].Tweet.Content != "hola" {
+			t.Errorf("esperaba el tweet 9 hidratado, obtuve %+v", received[0].Tweet)
+		}
+		if received[1].Tweet != nil || received[1].ActorID != 3 {
+			t.Errorf("esperaba un evento de follow sin tweet, obtuve %+v", received[1])
+		}
+	})
+
+	t.Run("descarta los tweets del bloqueador", func(t *testing.T) {
+		originalID := int64(9)
+		tweets := []*model.TweetWithUser{
+			{Tweet: model.Tweet{ID: 9, UserID: 2, Content: "del bloqueador"}},
+			{Tweet: model.Tweet{ID: 10, UserID: 3, RetweetOfTweetID: &originalID}},
+			{Tweet: model.Tweet{ID: 11, UserID: 3, Content: "hola"}},
+		}
+		eventRepo := &mockEventRepo{subscribeFunc: func(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+			events := make(chan *model.Event, 3)
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 2, TweetID: 9}
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 3, TweetID: 10}
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 3, TweetID: 11}
+			close(events)
+			return events, nil
+		}}
+
+		service := NewEventService(eventRepo, tweetsByID(tweets), &mockFollowRepo{}, &mockLikeRepo{}, nil, nil, newMockBlockRepo([2]int64{2, 1}), nil, 0)
+		messages, err := service.Subscribe(ctx, 1)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		var received []*model.EventMessage
+		for message := range messages {
+			received = append(received, message)
+		}
+
+		if len(received) != 1 || received[0].Tweet == nil || received[0].Tweet.ID != 11 {
+			t.Errorf("esperaba solo el tweet 11, obtuve %+v", received)
+		}
+	})
+
+	t.Run("descarta del timeline los tweets silenciados", func(t *testing.T) {
+		tweets := []*model.TweetWithUser{
+			{Tweet: model.Tweet{ID: 9, UserID: 2, Content: "de una cuenta silenciada"}},
+			{Tweet: model.Tweet{ID: 10, UserID: 3, Content: "hablando de spoilers"}},
+			{Tweet: model.Tweet{ID: 11, UserID: 3, Content: "hola"}},
+		}
+		eventRepo := &mockEventRepo{subscribeFunc: func(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+			events := make(chan *model.Event, 4)
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 2, TweetID: 9}
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 3, TweetID: 10}
+			events <- &model.Event{Channel: model.EventChannelTimeline, Type: model.EventTypeTweet, ActorID: 3, TweetID: 11}
+			events <- &model.Event{Channel: model.EventChannelMentions, Type: model.EventTypeMention, ActorID: 2, TweetID: 9}
+			close(events)
+			return events, nil
+		}}
+		muteRepo := &mockMuteRepo{
+			users: []*model.MutedUser{{UserID: 1, MutedUserID: 2}},
+			words: []*model.MutedWord{{UserID: 1, Phrase: "spoilers"}},
+		}
+
+		service := NewEventService(eventRepo, tweetsByID(tweets), &mockFollowRepo{}, &mockLikeRepo{}, nil, nil, nil, muteRepo, 0)
+		messages, err := service.Subscribe(ctx, 1)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		var received []*model.EventMessage
+		for message := range messages {
+			received = append(received, message)
+		}
+
+		if len(received) != 2 || received[0].Tweet.ID != 11 {
+			t.Fatalf("esperaba el tweet 11 y la mención, obtuve %+v", received)
+		}
+		if received[1].Channel != model.EventChannelMentions || received[1].Tweet.ID != 9 {
+			t.Errorf("esperaba que la mención de la cuenta silenciada llegue, obtuve %+v", received[1])
+		}
+	})
+
+	t.Run("sin repositorio de eventos", func(t *testing.T) {
+		service := NewEventService(nil, &mockTweetRepo{}, &mockFollowRepo{}, &mockLikeRepo{}, nil, nil, nil, nil, 0)
+		_, err := service.Subscribe(ctx, 1)
+		if !errors.Is(err, ErrStreamUnavailable) {
+			t.Errorf("esperaba ErrStreamUnavailable, obtuve: %v", err)
+		}
+	})
+}
+
+func TestPublishEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("envía el evento al usuario", func(t *testing.T) {
+		var published []int64
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+			published = append(published, userIDs...)
+			if event.CreatedAt.IsZero() {
+				t.Error("esperaba que se completara CreatedAt")
+			}
+			return nil
+		}}
+
+		publishEvent(ctx, eventRepo, 5, &model.Event{Type: model.EventTypeFollow, ActorID: 1})
+		if len(published) != 1 || published[0] != 5 {
+			t.Errorf("esperaba publicar al usuario 5, obtuve %v", published)
+		}
+	})
+
+	t.Run("no avisa al usuario de sus propias acciones", func(t *testing.T) {
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+			t.Error("no esperaba publicar el evento")
+			return nil
+		}}
+
+		publishEvent(ctx, eventRepo, 1, &model.Event{Type: model.EventTypeLike, ActorID: 1, CreatedAt: time.Now()})
+	})
+}
+
+func TestLikeService_PublishesNotification(t *testing.T) {
+	ctx := context.Background()
+	originalID := int64(7)
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		if id == originalID {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+		}
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3, RetweetOfTweetID: &originalID}}, nil
+	}}
+
+	var notified *model.Event
+	var notifiedUser int64
+	eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+		notified, notifiedUser = event, userIDs[0]
+		return nil
+	}}
+
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "autor"}, nil
+	}}
+
+	service := NewLikeService(&mockLikeRepo{}, tweetRepo, userRepo, &mockFollowRepo{}, nil, eventRepo)
+	if err := service.LikeTweet(ctx, 1, 50); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	// El like sobre un retweet se avisa al autor del original
+	if notified == nil || notifiedUser != 2 || notified.TweetID != originalID || notified.Channel != model.EventChannelNotifications {
+		t.Errorf("esperaba notificar al usuario 2 del like al tweet 7, obtuve usuario %d, evento %+v", notifiedUser, notified)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+type fanoutService struct {
+	queue        repository.FanoutQueueRepository
+	tweetRepo    repository.TweetRepository
+	followRepo   repository.FollowRepository
+	listRepo     repository.ListRepository
+	timelineRepo repository.TimelineRepository
+	// listTimelineRepo guarda los timelines de las listas, que se alimentan con el mismo fan-out
+	listTimelineRepo repository.TimelineRepository
+	eventRepo        repository.EventRepository
+	// threshold es la cantidad de seguidores a partir de la cual un tweet no se
+	// distribuye al escribir. 0 desactiva el modelo híbrido.
+	threshold int
+}
+
+// NewFanoutService crea una nueva instancia del servicio de fan-out.
+// Si queue es nil los tweets se distribuyen en línea, dentro de la misma request.
+func NewFanoutService(
+	queue repository.FanoutQueueRepository,
+	tweetRepo repository.TweetRepository,
+	followRepo repository.FollowRepository,
+	listRepo repository.ListRepository,
+	timelineRepo repository.TimelineRepository,
+	listTimelineRepo repository.TimelineRepository,
+	eventRepo repository.EventRepository,
+	threshold int,
+) FanoutService {
+	return &fanoutService{
+		queue:            queue,
+		tweetRepo:        tweetRepo,
+		followRepo:       followRepo,
+		listRepo:         listRepo,
+		timelineRepo:     timelineRepo,
+		listTimelineRepo: listTimelineRepo,
+		eventRepo:        eventRepo,
+		threshold:        threshold,
+	}
+}
+
+func (s *fanoutService) Dispatch(ctx context.Context, tweet *model.TweetWithUser) error {
+	if s.timelineRepo == nil {
+		return nil
+	}
+
+	if s.queue != nil {
+		err := s.queue.Enqueue(ctx, &model.FanoutJob{
+			TweetID:    tweet.ID,
+			AuthorID:   tweet.UserID,
+			EnqueuedAt: time.Now(),
+		})
+		if err == nil {
+			return nil
+		}
+		// Si la cola no está disponible se distribuye en línea para no perder el tweet
+		fmt.Printf("Warning: error enqueuing fanout for tweet %d, fanning out inline: %v\n", tweet.ID, err)
+	}
+
+	return s.fanout(ctx, tweet)
+}
+
+func (s *fanoutService) ProcessJob(ctx context.Context, job *model.FanoutJob) error {
+	tweet, err := s.tweetRepo.GetByID(ctx, job.TweetID)
+	if err != nil {
+		// El tweet se borró antes de distribuirse: no hay nada que hacer
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	return s.fanout(ctx, tweet)
+}
+
+// fanout agrega el tweet al timeline de las listas de las que el autor es miembro y al de todos
+// sus seguidores, por lotes
+func (s *fanoutService) fanout(ctx context.Context, tweet *model.TweetWithUser) error {
+	if err := s.fanoutToLists(ctx, tweet); err != nil {
+		return err
+	}
+
+	event := &model.Event{
+		Channel:   model.EventChannelTimeline,
+		Type:      model.EventTypeTweet,
+		ActorID:   tweet.UserID,
+		TweetID:   tweet.ID,
+		CreatedAt: tweet.CreatedAt,
+	}
+
+	// Las cuentas con muchos seguidores no se distribuyen al escribir: sus tweets se
+	// mezclan al leer el timeline de cada seguidor
+	if s.isHighFollowerAccount(ctx, tweet.UserID) {
+		if s.eventRepo != nil {
+			if err := s.eventRepo.PublishToAuthor(ctx, tweet.UserID, event); err != nil {
+				fmt.Printf("Warning: error publishing tweet %d event: %v\n", tweet.ID, err)
+			}
+		}
+		return nil
+	}
+
+	page := model.PageQuery{Limit: followerBatchSize}
+	for {
+		followers, err := s.followRepo.GetFollowers(ctx, tweet.UserID, page)
+		if err != nil {
+			return fmt.Errorf("error getting followers: %w", err)
+		}
+
+		if len(followers.Users) > 0 {
+			followerIDs := make([]int64, 0, len(followers.Users))
+			for _, follower := range followers.Users {
+				followerIDs = append(followerIDs, follower.ID)
+			}
+
+			// Agregar un tweet ya presente no lo duplica, así que un reintento puede repetir lotes
+			err = s.timelineRepo.AddToMultipleTimelines(ctx, followerIDs, tweet)
+			if err != nil {
+				return fmt.Errorf("error adding to timelines: %w", err)
+			}
+
+			// Avisar a los clientes conectados; un evento perdido se recupera al leer el timeline
+			if s.eventRepo != nil {
+				if err := s.eventRepo.PublishToUsers(ctx, followerIDs, event); err != nil {
+					fmt.Printf("Warning: error publishing tweet %d events: %v\n", tweet.ID, err)
+				}
+			}
+		}
+
+		if followers.NextCursor == nil {
+			return nil
+		}
+		page.Before = followers.NextCursor
+	}
+}
+
+// fanoutToLists agrega el tweet al timeline de las listas de las que el autor es miembro. A
+// diferencia de los seguidores, las listas reciben también los tweets de las cuentas sobre el
+// umbral: cada lista es un único timeline compartido por todos sus lectores.
+func (s *fanoutService) fanoutToLists(ctx context.Context, tweet *model.TweetWithUser) error {
+	if s.listRepo == nil || s.listTimelineRepo == nil {
+		return nil
+	}
+
+	listIDs, err := s.listRepo.GetListIDsByMember(ctx, tweet.UserID)
+	if err != nil {
+		return fmt.Errorf("error getting author lists: %w", err)
+	}
+
+	for start := 0; start < len(listIDs); start += followerBatchSize {
+		end := min(start+followerBatchSize, len(listIDs))
+		if err := s.listTimelineRepo.AddToMultipleTimelines(ctx, listIDs[start:end], tweet); err != nil {
+			return fmt.Errorf("error adding to list timelines: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// isHighFollowerAccount indica si el usuario supera el umbral de seguidores del modelo híbrido.
+// Ante un error se asume que no lo supera y el tweet se distribuye normalmente.
+func (s *fanoutService) isHighFollowerAccount(ctx context.Context, userID int64) bool {
+	if s.threshold <= 0 {
+		return false
+	}
+
+	count, err := s.followRepo.CountFollowers(ctx, userID)
+	if err != nil {
+		fmt.Printf("Warning: error counting followers of user %d: %v\n", userID, err)
+		return false
+	}
+
+	return count > int64(s.threshold)
+}
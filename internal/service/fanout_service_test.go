@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"testing"
+	"time"
+)
+
+type mockFanoutQueue struct {
+	enqueueFunc func(ctx context.Context, job *model.FanoutJob) error
+}
+
+func (m *mockFanoutQueue) EnsureGroup(ctx context.Context) error { return nil }
+func (m *mockFanoutQueue) Enqueue(ctx context.Context, job *model.FanoutJob) error {
+	if m.enqueueFunc != nil {
+		return m.enqueueFunc(ctx, job)
+	}
+	return nil
+}
+func (m *mockFanoutQueue) Consume(ctx context.Context, consumer string, count int, block time.Duration) ([]*model.FanoutJob, error) {
+	return nil, nil
+}
+func (m *mockFanoutQueue) ClaimStale(ctx context.Context, consumer string, minIdle time.Duration, count int) ([]*model.FanoutJob, error) {
+	return nil, nil
+}
+func (m *mockFanoutQueue) Ack(ctx context.Context, job *model.FanoutJob) error { return nil }
+func (m *mockFanoutQueue) Retry(ctx context.Context, job *model.FanoutJob, delay time.Duration) error {
+	return nil
+}
+func (m *mockFanoutQueue) PromoteDelayed(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+func (m *mockFanoutQueue) DeadLetter(ctx context.Context, job *model.FanoutJob, reason string) error {
+	return nil
+}
+
+func TestFanoutService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	tweet := &model.TweetWithUser{Tweet: model.Tweet{ID: 7, UserID: 1}}
+
+	t.Run("encola el job sin tocar los timelines", func(t *testing.T) {
+		var enqueued *model.FanoutJob
+		queue := &mockFanoutQueue{enqueueFunc: func(ctx context.Context, job *model.FanoutJob) error {
+			enqueued = job
+			return nil
+		}}
+		timelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+			t.Error("no esperaba distribución en línea")
+			return nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
+		}}
+		svc := NewFanoutService(queue, &mockTweetRepo{}, followRepo, nil, timelineRepo, nil, nil, 0)
+
+		err := svc.Dispatch(ctx, tweet)
+		if err != nil || enqueued == nil || enqueued.TweetID != 7 || enqueued.AuthorID != 1 {
+			t.Errorf("esperaba job encolado para el tweet 7, obtuve %+v (err: %v)", enqueued, err)
+		}
+	})
+
+	t.Run("si la cola falla distribuye en línea", func(t *testing.T) {
+		queue := &mockFanoutQueue{enqueueFunc: func(ctx context.Context, job *model.FanoutJob) error {
+			return errors.New("redis caído")
+		}}
+		var fannedOut int
+		timelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+			fannedOut += len(followerIDs)
+			return nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}, {ID: 3}}}, nil
+		}}
+		svc := NewFanoutService(queue, &mockTweetRepo{}, followRepo, nil, timelineRepo, nil, nil, 0)
+
+		if err := svc.Dispatch(ctx, tweet); err != nil || fannedOut != 2 {
+			t.Errorf("esperaba distribución en línea a 2 seguidores, obtuve %d (err: %v)", fannedOut, err)
+		}
+	})
+}
+
+func TestFanoutService_ProcessJob(t *testing.T) {
+	ctx := context.Background()
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 1}}, nil
+	}}
+	job := &model.FanoutJob{TweetID: 7, AuthorID: 1}
+
+	t.Run("distribuye a todos los seguidores por lotes", func(t *testing.T) {
+		total := followerBatchSize*2 + 5
+		// El cursor de cada seguidor guarda su posición en el ID
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			start := 0
+			if page.Before != nil {
+				start = int(page.Before.ID) + 1
+			}
+			result := &model.UserPage{}
+			for i := start; i < total && i < start+page.Limit; i++ {
+				result.Users = append(result.Users, &model.User{ID: int64(i + 10)})
+			}
+			if len(result.Users) == page.Limit {
+				result.NextCursor = model.NewCursor(time.Unix(int64(total-start), 0), int64(start+page.Limit-1))
+			}
+			return result, nil
+		}}
+		var batches, fannedOut int
+		timelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+			batches++
+			fannedOut += len(followerIDs)
+			return nil
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, nil, timelineRepo, nil, nil, 0)
+
+		err := svc.ProcessJob(ctx, job)
+		if err != nil || fannedOut != total || batches != 3 {
+			t.Errorf("esperaba %d seguidores en 3 lotes, obtuve %d en %d (err: %v)", total, fannedOut, batches, err)
+		}
+	})
+
+	t.Run("cuenta sobre el umbral no se distribuye", func(t *testing.T) {
+		followRepo := &mockFollowRepo{countFollowersFunc: func(ctx context.Context, userID int64) (int64, error) {
+			return 101, nil
+		}}
+		timelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+			t.Error("no esperaba distribución a timelines")
+			return nil
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, nil, timelineRepo, nil, nil, 100)
+
+		if err := svc.ProcessJob(ctx, job); err != nil {
+			t.Errorf("esperaba éxito, obtuve %v", err)
+		}
+	})
+
+	t.Run("publica eventos para los clientes conectados", func(t *testing.T) {
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}, {ID: 3}}}, nil
+		}}
+		var notified []int64
+		events := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, event *model.Event) error {
+			if event.TweetID != 7 {
+				t.Errorf("esperaba evento del tweet 7, obtuve %+v", event)
+			}
+			notified = append(notified, userIDs...)
+			return nil
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, nil, &mockTimelineRepo{}, nil, events, 0)
+
+		if err := svc.ProcessJob(ctx, job); err != nil || len(notified) != 2 {
+			t.Errorf("esperaba avisar a 2 seguidores, obtuve %v (err: %v)", notified, err)
+		}
+	})
+
+	t.Run("cuenta sobre el umbral publica en el canal del autor", func(t *testing.T) {
+		followRepo := &mockFollowRepo{countFollowersFunc: func(ctx context.Context, userID int64) (int64, error) {
+			return 101, nil
+		}}
+		var authorID int64
+		events := &mockEventRepo{publishToAuthorFunc: func(ctx context.Context, id int64, event *model.Event) error {
+			authorID = id
+			return nil
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, nil, &mockTimelineRepo{}, nil, events, 100)
+
+		if err := svc.ProcessJob(ctx, job); err != nil || authorID != 1 {
+			t.Errorf("esperaba publicar en el canal del autor 1, obtuve %d (err: %v)", authorID, err)
+		}
+	})
+
+	t.Run("las listas del autor reciben el tweet aunque supere el umbral", func(t *testing.T) {
+		followRepo := &mockFollowRepo{countFollowersFunc: func(ctx context.Context, userID int64) (int64, error) {
+			return 101, nil
+		}}
+		listRepo := newMockListRepo()
+		listRepo.members[20] = []int64{1}
+		listRepo.members[21] = []int64{1, 2}
+		listRepo.members[22] = []int64{2}
+		var listIDs []int64
+		listTimelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, ids []int64, tweet *model.TweetWithUser) error {
+			listIDs = append(listIDs, ids...)
+			return nil
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, listRepo, &mockTimelineRepo{}, listTimelineRepo, nil, 100)
+
+		if err := svc.ProcessJob(ctx, job); err != nil || len(listIDs) != 2 {
+			t.Errorf("esperaba distribuir a las listas 20 y 21, obtuve %v (err: %v)", listIDs, err)
+		}
+	})
+
+	t.Run("tweet borrado antes de distribuirse", func(t *testing.T) {
+		deletedRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return nil, fmt.Errorf("tweet %w: %d", repository.ErrNotFound, id)
+		}}
+		svc := NewFanoutService(nil, deletedRepo, &mockFollowRepo{}, nil, &mockTimelineRepo{}, nil, nil, 0)
+
+		if err := svc.ProcessJob(ctx, job); err != nil {
+			t.Errorf("esperaba que el job se diera por terminado, obtuve %v", err)
+		}
+	})
+
+	t.Run("error en timelines se devuelve para reintentar", func(t *testing.T) {
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
+		}}
+		timelineRepo := &mockTimelineRepo{addToMultipleFunc: func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+			return errors.New("redis caído")
+		}}
+		svc := NewFanoutService(nil, tweetRepo, followRepo, nil, timelineRepo, nil, nil, 0)
+
+		if err := svc.ProcessJob(ctx, job); err == nil {
+			t.Error("esperaba error para reintentar el job")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"microx/internal/model"
 	"microx/internal/repository"
+	"time"
 )
 
 type followService struct {
@@ -12,6 +13,8 @@ type followService struct {
 	userRepo     repository.UserRepository
 	timelineRepo repository.TimelineRepository
 	tweetRepo    repository.TweetRepository
+	eventRepo    repository.EventRepository
+	blockRepo    repository.BlockRepository
 }
 
 func NewFollowService(
@@ -19,41 +22,59 @@ func NewFollowService(
 	userRepo repository.UserRepository,
 	timelineRepo repository.TimelineRepository,
 	tweetRepo repository.TweetRepository,
+	eventRepo repository.EventRepository,
+	blockRepo repository.BlockRepository,
 ) FollowService {
 	return &followService{
 		followRepo:   followRepo,
 		userRepo:     userRepo,
 		timelineRepo: timelineRepo,
 		tweetRepo:    tweetRepo,
+		eventRepo:    eventRepo,
+		blockRepo:    blockRepo,
 	}
 }
 
 // Métodos con receiver (s *followService)
-func (s *followService) FollowUser(ctx context.Context, followerID, followingID int64) error {
+func (s *followService) FollowUser(ctx context.Context, followerID, followingID int64) (string, error) {
 	// Validaciones básicas
 	if followerID == followingID {
-		return fmt.Errorf("user cannot follow themselves")
+		return "", fmt.Errorf("user cannot follow themselves")
 	}
 
 	// Verificar que ambos usuarios existen
 	_, err := s.userRepo.GetByID(ctx, followerID)
 	if err != nil {
-		return fmt.Errorf("follower user not found: %w", err)
+		return "", fmt.Errorf("follower user not found: %w", err)
 	}
 
-	_, err = s.userRepo.GetByID(ctx, followingID)
+	following, err := s.userRepo.GetByID(ctx, followingID)
 	if err != nil {
-		return fmt.Errorf("following user not found: %w", err)
+		return "", fmt.Errorf("following user not found: %w", err)
+	}
+
+	// Un bloqueo, en cualquier dirección, impide seguir y pedir seguir
+	if err := checkNotBlocked(ctx, s.blockRepo, followerID, followingID); err != nil {
+		return "", err
 	}
 
 	// Verificar si ya existe la relación de follow
 	exists, err := s.followRepo.Exists(ctx, followerID, followingID)
 	if err != nil {
-		return fmt.Errorf("error checking follow relationship: %w", err)
+		return "", fmt.Errorf("error checking follow relationship: %w", err)
 	}
 
 	if exists {
-		return fmt.Errorf("user is already following this user")
+		return "", fmt.Errorf("user is already following this user")
+	}
+
+	// Las cuentas protegidas aprueban a cada seguidor: el follow queda como pedido pendiente
+	if following.Protected {
+		err = s.requestFollow(ctx, followerID, followingID)
+		if err != nil {
+			return "", err
+		}
+		return model.FollowStatusPending, nil
 	}
 
 	// Crear la relación de follow
@@ -64,18 +85,55 @@ func (s *followService) FollowUser(ctx context.Context, followerID, followingID
 
 	err = s.followRepo.Create(ctx, follow)
 	if err != nil {
-		return fmt.Errorf("error creating follow relationship: %w", err)
+		return "", fmt.Errorf("error creating follow relationship: %w", err)
+	}
+
+	s.onFollowed(ctx, followerID, followingID, follow.CreatedAt)
+
+	return model.FollowStatusFollowing, nil
+}
+
+// requestFollow registra un pedido de follow hacia una cuenta protegida y se lo avisa
+func (s *followService) requestFollow(ctx context.Context, requesterID, targetID int64) error {
+	pending, err := s.followRepo.RequestExists(ctx, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error checking follow request: %w", err)
 	}
 
+	if pending {
+		return fmt.Errorf("follow request already sent")
+	}
+
+	err = s.followRepo.CreateRequest(ctx, requesterID, targetID)
+	if err != nil {
+		return fmt.Errorf("error creating follow request: %w", err)
+	}
+
+	publishEvent(ctx, s.eventRepo, targetID, &model.Event{
+		Channel: model.EventChannelFollows,
+		Type:    model.EventTypeFollowRequest,
+		ActorID: requesterID,
+	})
+
+	return nil
+}
+
+// onFollowed completa un follow recién creado: carga los tweets del seguido en el timeline del
+// seguidor y le avisa al seguido
+func (s *followService) onFollowed(ctx context.Context, followerID, followingID int64, followedAt time.Time) {
 	// Actualizar timeline del follower con tweets del usuario seguido
 	if s.timelineRepo != nil {
-		err = s.updateFollowerTimeline(ctx, followerID, followingID)
-		if err != nil {
+		if err := s.updateFollowerTimeline(ctx, followerID, followingID); err != nil {
 			fmt.Printf("Warning: error updating timeline: %v\n", err)
 		}
 	}
 
-	return nil
+	publishEvent(ctx, s.eventRepo, followingID, &model.Event{
+		Channel:   model.EventChannelFollows,
+		Type:      model.EventTypeFollow,
+		ActorID:   followerID,
+		CreatedAt: followedAt,
+	})
 }
 
 func (s *followService) UnfollowUser(ctx context.Context, followerID, followingID int64) error {
@@ -102,7 +160,20 @@ func (s *followService) UnfollowUser(ctx context.Context, followerID, followingI
 	}
 
 	if !exists {
-		return fmt.Errorf("user is not following this user")
+		// Sin follow, dejar de seguir cancela el pedido pendiente, si lo hay
+		pending, err := s.followRepo.RequestExists(ctx, followerID, followingID)
+		if err != nil {
+			return fmt.Errorf("error checking follow request: %w", err)
+		}
+		if !pending {
+			return fmt.Errorf("user is not following this user")
+		}
+
+		err = s.followRepo.DeleteRequest(ctx, followerID, followingID)
+		if err != nil {
+			return fmt.Errorf("error cancelling follow request: %w", err)
+		}
+		return nil
 	}
 
 	// Eliminar la relación de follow
@@ -119,16 +190,22 @@ func (s *followService) UnfollowUser(ctx context.Context, followerID, followingI
 		}
 	}
 
+	publishEvent(ctx, s.eventRepo, followingID, &model.Event{
+		Channel: model.EventChannelFollows,
+		Type:    model.EventTypeUnfollow,
+		ActorID: followerID,
+	})
+
 	return nil
 }
 
-func (s *followService) GetFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+func (s *followService) GetFollowers(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
 	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	followers, err := s.followRepo.GetFollowers(ctx, userID, limit, offset)
+	followers, err := s.followRepo.GetFollowers(ctx, userID, page)
 	if err != nil {
 		return nil, fmt.Errorf("error getting followers: %w", err)
 	}
@@ -136,13 +213,13 @@ func (s *followService) GetFollowers(ctx context.Context, userID int64, limit, o
 	return followers, nil
 }
 
-func (s *followService) GetFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+func (s *followService) GetFollowing(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
 	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	following, err := s.followRepo.GetFollowing(ctx, userID, limit, offset)
+	following, err := s.followRepo.GetFollowing(ctx, userID, page)
 	if err != nil {
 		return nil, fmt.Errorf("error getting following: %w", err)
 	}
@@ -163,8 +240,66 @@ func (s *followService) IsFollowing(ctx context.Context, followerID, followingID
 	return exists, nil
 }
 
+// GetFollowRequests devuelve una página de los usuarios que esperan que la cuenta apruebe su pedido
+func (s *followService) GetFollowRequests(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+	requests, err := s.followRepo.GetRequests(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting follow requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// ApproveFollowRequest convierte el pedido de requesterID en un follow
+func (s *followService) ApproveFollowRequest(ctx context.Context, userID, requesterID int64) error {
+	err := s.followRepo.ApproveRequest(ctx, requesterID, userID)
+	if err != nil {
+		return fmt.Errorf("error approving follow request: %w", err)
+	}
+
+	now := time.Now()
+	s.onFollowed(ctx, requesterID, userID, now)
+
+	publishEvent(ctx, s.eventRepo, requesterID, &model.Event{
+		Channel:   model.EventChannelFollows,
+		Type:      model.EventTypeFollowAccepted,
+		ActorID:   userID,
+		CreatedAt: now,
+	})
+
+	return nil
+}
+
+// RejectFollowRequest descarta el pedido de requesterID sin avisarle
+func (s *followService) RejectFollowRequest(ctx context.Context, userID, requesterID int64) error {
+	err := s.followRepo.DeleteRequest(ctx, requesterID, userID)
+	if err != nil {
+		return fmt.Errorf("error rejecting follow request: %w", err)
+	}
+
+	return nil
+}
+
+// canViewTweets indica si viewerID puede ver los tweets de author: los de una cuenta pública los
+// ve cualquiera; los de una protegida, solo el dueño y sus seguidores aprobados
+func canViewTweets(ctx context.Context, followRepo repository.FollowRepository, viewerID int64, author *model.User) (bool, error) {
+	if !author.Protected || viewerID == author.ID {
+		return true, nil
+	}
+	if viewerID <= 0 {
+		return false, nil
+	}
+
+	follows, err := followRepo.Exists(ctx, viewerID, author.ID)
+	if err != nil {
+		return false, fmt.Errorf("error checking follow relationship: %w", err)
+	}
+
+	return follows, nil
+}
+
 func (s *followService) updateFollowerTimeline(ctx context.Context, followerID, followingID int64) error {
-	tweets, err := s.tweetRepo.GetByUserID(ctx, followingID, 50, 0)
+	tweets, err := s.tweetRepo.GetByUserID(ctx, followingID, model.PageQuery{Limit: 50})
 	if err != nil {
 		return fmt.Errorf("error getting user tweets: %w", err)
 	}
@@ -180,13 +315,19 @@ func (s *followService) updateFollowerTimeline(ctx context.Context, followerID,
 }
 
 func (s *followService) removeFromFollowerTimeline(ctx context.Context, followerID, followingID int64) error {
-	tweets, err := s.tweetRepo.GetByUserID(ctx, followingID, 1000, 0)
+	return removeFromFollowerTimeline(ctx, s.tweetRepo, s.timelineRepo, followerID, followingID)
+}
+
+// removeFromFollowerTimeline quita del timeline cacheado de followerID los tweets de followingID.
+// La usan tanto el unfollow como el bloqueo, que elimina los follows en ambas direcciones.
+func removeFromFollowerTimeline(ctx context.Context, tweetRepo repository.TweetRepository, timelineRepo repository.TimelineRepository, followerID, followingID int64) error {
+	tweets, err := tweetRepo.GetByUserID(ctx, followingID, model.PageQuery{Limit: 1000})
 	if err != nil {
 		return fmt.Errorf("error getting user tweets: %w", err)
 	}
 
 	for _, tweet := range tweets {
-		err = s.timelineRepo.RemoveFromTimeline(ctx, followerID, tweet.ID)
+		err = timelineRepo.RemoveFromTimeline(ctx, followerID, tweet.ID)
 		if err != nil {
 			return fmt.Errorf("error removing tweet from timeline: %w", err)
 		}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+)
+
+func TestFollowService_FollowUser(t *testing.T) {
+	ctx := context.Background()
+	newUserRepo := func(protected bool) *mockUserRepo {
+		return &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "user", Protected: protected && id == 2}, nil
+		}}
+	}
+
+	t.Run("seguir una cuenta pública crea el follow", func(t *testing.T) {
+		var created *model.Follow
+		requested := false
+		followRepo := &mockFollowRepo{
+			createFunc:        func(ctx context.Context, follow *model.Follow) error { created = follow; return nil },
+			createRequestFunc: func(ctx context.Context, requesterID, targetID int64) error { requested = true; return nil },
+		}
+		service := NewFollowService(followRepo, newUserRepo(false), nil, &mockTweetRepo{}, nil, nil)
+		status, err := service.FollowUser(ctx, 1, 2)
+		if err != nil || status != model.FollowStatusFollowing || created == nil || requested {
+			t.Errorf("esperaba follow directo, obtuve status: %q, err: %v, follow: %+v, pedido: %v", status, err, created, requested)
+		}
+	})
+
+	t.Run("seguir una cuenta protegida crea un pedido", func(t *testing.T) {
+		var requester, target int64
+		var event *model.Event
+		followRepo := &mockFollowRepo{
+			createFunc: func(ctx context.Context, follow *model.Follow) error {
+				t.Error("no esperaba que se cree el follow")
+				return nil
+			},
+			createRequestFunc: func(ctx context.Context, requesterID, targetID int64) error {
+				requester, target = requesterID, targetID
+				return nil
+			},
+		}
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, e *model.Event) error {
+			event = e
+			return nil
+		}}
+		service := NewFollowService(followRepo, newUserRepo(true), nil, &mockTweetRepo{}, eventRepo, nil)
+		status, err := service.FollowUser(ctx, 1, 2)
+		if err != nil || status != model.FollowStatusPending || requester != 1 || target != 2 {
+			t.Errorf("esperaba pedido pendiente de 1 a 2, obtuve status: %q, err: %v, pedido: %d->%d", status, err, requester, target)
+		}
+		if event == nil || event.Type != model.EventTypeFollowRequest {
+			t.Errorf("esperaba evento %q, obtuve: %+v", model.EventTypeFollowRequest, event)
+		}
+	})
+
+	t.Run("pedido ya enviado", func(t *testing.T) {
+		followRepo := &mockFollowRepo{requestExistsFunc: func(ctx context.Context, requesterID, targetID int64) (bool, error) {
+			return true, nil
+		}}
+		service := NewFollowService(followRepo, newUserRepo(true), nil, &mockTweetRepo{}, nil, nil)
+		_, err := service.FollowUser(ctx, 1, 2)
+		if err == nil {
+			t.Error("esperaba error por pedido duplicado")
+		}
+	})
+}
+
+func TestFollowService_UnfollowUserCancelsRequest(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "user"}, nil
+	}}
+
+	t.Run("sin follow cancela el pedido pendiente", func(t *testing.T) {
+		cancelled := false
+		followRepo := &mockFollowRepo{
+			requestExistsFunc: func(ctx context.Context, requesterID, targetID int64) (bool, error) { return true, nil },
+			deleteRequestFunc: func(ctx context.Context, requesterID, targetID int64) error { cancelled = true; return nil },
+		}
+		service := NewFollowService(followRepo, userRepo, nil, &mockTweetRepo{}, nil, nil)
+		if err := service.UnfollowUser(ctx, 1, 2); err != nil || !cancelled {
+			t.Errorf("esperaba pedido cancelado, obtuve err: %v, cancelado: %v", err, cancelled)
+		}
+	})
+
+	t.Run("sin follow ni pedido", func(t *testing.T) {
+		service := NewFollowService(&mockFollowRepo{}, userRepo, nil, &mockTweetRepo{}, nil, nil)
+		if err := service.UnfollowUser(ctx, 1, 2); err == nil {
+			t.Error("esperaba error por no seguir al usuario")
+		}
+	})
+}
+
+func TestFollowService_ApproveFollowRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("aprobar crea el follow y avisa a ambos", func(t *testing.T) {
+		var approved [2]int64
+		followRepo := &mockFollowRepo{approveRequestFunc: func(ctx context.Context, requesterID, targetID int64) error {
+			approved = [2]int64{requesterID, targetID}
+			return nil
+		}}
+		var timelineOwners []int64
+		timelineRepo := &mockTimelineRepo{addToTimelineFunc: func(ctx context.Context, userID int64, tweet *model.TweetWithUser) error {
+			timelineOwners = append(timelineOwners, userID)
+			return nil
+		}}
+		tweetRepo := &mockTweetRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+			return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 10, UserID: userID}}}, nil
+		}}
+		events := map[int64]string{}
+		eventRepo := &mockEventRepo{publishToUsersFunc: func(ctx context.Context, userIDs []int64, e *model.Event) error {
+			for _, id := range userIDs {
+				events[id] = e.Type
+			}
+			return nil
+		}}
+		service := NewFollowService(followRepo, &mockUserRepo{}, timelineRepo, tweetRepo, eventRepo, nil)
+
+		if err := service.ApproveFollowRequest(ctx, 2, 1); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if approved != [2]int64{1, 2} {
+			t.Errorf("esperaba aprobar el pedido de 1 a 2, obtuve: %v", approved)
+		}
+		if len(timelineOwners) != 1 || timelineOwners[0] != 1 {
+			t.Errorf("esperaba cargar el timeline del solicitante, obtuve: %v", timelineOwners)
+		}
+		if events[2] != model.EventTypeFollow || events[1] != model.EventTypeFollowAccepted {
+			t.Errorf("esperaba eventos follow y follow_accepted, obtuve: %v", events)
+		}
+	})
+
+	t.Run("pedido inexistente", func(t *testing.T) {
+		followRepo := &mockFollowRepo{approveRequestFunc: func(ctx context.Context, requesterID, targetID int64) error {
+			return errors.New("follow request not found")
+		}}
+		service := NewFollowService(followRepo, &mockUserRepo{}, nil, &mockTweetRepo{}, nil, nil)
+		if err := service.ApproveFollowRequest(ctx, 2, 1); err == nil {
+			t.Error("esperaba error por pedido inexistente")
+		}
+	})
+}
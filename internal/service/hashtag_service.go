@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
+)
+
+type hashtagService struct {
+	tweetRepo    repository.TweetRepository
+	likeRepo     repository.LikeRepository
+	bookmarkRepo repository.BookmarkRepository
+	trendRepo    repository.TrendRepository
+}
+
+// NewHashtagService crea una nueva instancia del servicio de hashtags y tendencias
+func NewHashtagService(
+	tweetRepo repository.TweetRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+	trendRepo repository.TrendRepository,
+) HashtagService {
+	return &hashtagService{
+		tweetRepo:    tweetRepo,
+		likeRepo:     likeRepo,
+		bookmarkRepo: bookmarkRepo,
+		trendRepo:    trendRepo,
+	}
+}
+
+func (s *hashtagService) GetHashtagTweets(ctx context.Context, viewerID int64, tag string, page model.PageQuery) (*model.TweetPage, error) {
+	tag = normalizeHashtag(tag)
+	if !hashtagTagPattern.MatchString(tag) {
+		return nil, ErrInvalidHashtag
+	}
+
+	tweets, err := s.tweetRepo.GetByHashtag(ctx, viewerID, tag, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hashtag tweets: %w", err)
+	}
+
+	responses := toTweetResponses(tweets)
+	applyLikes(ctx, s.likeRepo, viewerID, responses)
+	applyBookmarks(ctx, s.bookmarkRepo, viewerID, responses)
+
+	return newTweetPage(page, tweets, responses), nil
+}
+
+func (s *hashtagService) GetTrends(ctx context.Context, windowName string, limit int) ([]*model.Trend, error) {
+	window, ok := model.GetTrendWindow(windowName)
+	if !ok {
+		return nil, ErrInvalidTrendWindow
+	}
+
+	trends := []*model.Trend{}
+	if s.trendRepo == nil {
+		return trends, nil
+	}
+
+	found, err := s.trendRepo.GetTrending(ctx, window, time.Now(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting trends: %w", err)
+	}
+
+	return append(trends, found...), nil
+}
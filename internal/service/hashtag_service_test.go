@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	t.Run("posiciones en caracteres y letras de cualquier idioma", func(t *testing.T) {
+		hashtags := extractHashtags("¡Vamos #Año_Nuevo! #café2024")
+		if len(hashtags) != 2 {
+			t.Fatalf("esperaba 2 hashtags, obtuve %d", len(hashtags))
+		}
+		if hashtags[0].Tag != "Año_Nuevo" || hashtags[0].Start != 7 || hashtags[0].End != 17 {
+			t.Errorf("hashtag inesperado: %+v", hashtags[0])
+		}
+		if hashtags[1].Tag != "café2024" || hashtags[1].Start != 19 || hashtags[1].End != 28 {
+			t.Errorf("hashtag inesperado: %+v", hashtags[1])
+		}
+	})
+
+	t.Run("ignora números, anclas de URL y # sueltos", func(t *testing.T) {
+		hashtags := extractHashtags("soy el #1 https://example.com/#seccion a#b # nada ##doble")
+		if len(hashtags) != 0 {
+			t.Errorf("no esperaba hashtags, obtuve %+v", hashtags)
+		}
+	})
+
+	t.Run("normaliza y no repite", func(t *testing.T) {
+		tags := hashtagTags("#Go #go #GO #golang")
+		if strings.Join(tags, ",") != "go,golang" {
+			t.Errorf("esperaba go y golang, obtuve %v", tags)
+		}
+	})
+}
+
+func TestTweetService_Hashtags(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "testuser"}, nil
+	}}
+
+	t.Run("crear indexa los hashtags y los suma a las tendencias", func(t *testing.T) {
+		var stored []string
+		tweetRepo := &mockTweetRepo{createFunc: func(ctx context.Context, tweet *model.Tweet) error {
+			stored = tweet.Hashtags
+			return nil
+		}}
+		var recorded []string
+		trendRepo := &mockTrendRepo{recordFunc: func(ctx context.Context, hashtags []string, at time.Time) error {
+			recorded = hashtags
+			return nil
+		}}
+
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, trendRepo, nil, nil, 280, time.Hour)
+		resp, err := service.CreateTweet(ctx, 1, "hola #Go y #go")
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if strings.Join(stored, ",") != "go" || strings.Join(recorded, ",") != "go" {
+			t.Errorf("esperaba indexar y registrar go, obtuve %v y %v", stored, recorded)
+		}
+		if len(resp.Entities.Hashtags) != 2 || resp.Entities.Hashtags[1].Start != 11 {
+			t.Errorf("esperaba los hashtags en las entidades, obtuve %+v", resp.Entities.Hashtags)
+		}
+	})
+
+	t.Run("editar solo suma los hashtags nuevos", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{
+				ID:        id,
+				UserID:    1,
+				Content:   "hola #go",
+				CreatedAt: time.Now(),
+			}}, nil
+		}}
+		var recorded []string
+		trendRepo := &mockTrendRepo{recordFunc: func(ctx context.Context, hashtags []string, at time.Time) error {
+			recorded = append(recorded, hashtags...)
+			return nil
+		}}
+
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, trendRepo, nil, nil, 280, time.Hour)
+		if _, err := service.UpdateTweet(ctx, 1, 9, "hola #Go y #redis"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if strings.Join(recorded, ",") != "redis" {
+			t.Errorf("esperaba registrar solo redis, obtuve %v", recorded)
+		}
+	})
+}
+
+func TestHashtagService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normaliza el hashtag de la consulta", func(t *testing.T) {
+		var queried string
+		tweetRepo := &mockTweetRepo{getByHashtagFunc: func(ctx context.Context, viewerID int64, tag string, page model.PageQuery) ([]*model.TweetWithUser, error) {
+			queried = tag
+			return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 3, Content: "#Go"}}}, nil
+		}}
+
+		service := NewHashtagService(tweetRepo, &mockLikeRepo{}, nil, &mockTrendRepo{})
+		page, err := service.GetHashtagTweets(ctx, 0, "#GO", model.PageQuery{Limit: 20})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if queried != "go" || len(page.Tweets) != 1 {
+			t.Errorf("esperaba consultar go, obtuve %q con %d tweets", queried, len(page.Tweets))
+		}
+	})
+
+	t.Run("hashtag inválido", func(t *testing.T) {
+		service := NewHashtagService(&mockTweetRepo{}, &mockLikeRepo{}, nil, &mockTrendRepo{})
+		_, err := service.GetHashtagTweets(ctx, 0, "no-vale", model.PageQuery{Limit: 20})
+		if !errors.Is(err, ErrInvalidHashtag) {
+			t.Errorf("esperaba ErrInvalidHashtag, obtuve: %v", err)
+		}
+	})
+
+	t.Run("ventana de tendencias", func(t *testing.T) {
+		var window model.TrendWindow
+		trendRepo := &mockTrendRepo{getTrendingFunc: func(ctx context.Context, w model.TrendWindow, now time.Time, limit int) ([]*model.Trend, error) {
+			window = w
+			return []*model.Trend{{Hashtag: "go", Score: 3.5, TweetCount: 4}}, nil
+		}}
+
+		service := NewHashtagService(&mockTweetRepo{}, &mockLikeRepo{}, nil, trendRepo)
+		trends, err := service.GetTrends(ctx, "day", 10)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if window.Name != model.TrendWindowDay.Name || len(trends) != 1 {
+			t.Errorf("esperaba la ventana day con 1 tendencia, obtuve %q y %+v", window.Name, trends)
+		}
+
+		if _, err := service.GetTrends(ctx, "week", 10); !errors.Is(err, ErrInvalidTrendWindow) {
+			t.Errorf("esperaba ErrInvalidTrendWindow, obtuve: %v", err)
+		}
+	})
+}
@@ -3,34 +3,172 @@ package service
 import (
 	"context"
 	"microx/internal/model"
+	"time"
 )
 
 // UserService define las operaciones de negocio para usuarios
 type UserService interface {
 	CreateUser(ctx context.Context, username, email string) (*model.User, error)
 	GetUser(ctx context.Context, userID int64) (*model.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+	GetUsers(ctx context.Context, userIDs []int64) ([]*model.User, error)
+	UpdateProfile(ctx context.Context, userID int64, req *model.UpdateProfileRequest) (*model.User, error)
 	GetUserStats(ctx context.Context, userID int64) (*model.UserStats, error)
 }
 
+// AccountService define la baja de cuentas y la exportación de los datos de los usuarios
+type AccountService interface {
+	// DeleteAccount da de baja la cuenta; se borra definitivamente al vencer el período de gracia
+	DeleteAccount(ctx context.Context, userID int64) error
+	// PurgeDeletedAccounts borra definitivamente las cuentas dadas de baja antes de la fecha dada
+	PurgeDeletedAccounts(ctx context.Context, before time.Time) (int, error)
+	// RequestExport registra un pedido de exportación, que se genera en segundo plano
+	RequestExport(ctx context.Context, userID int64) (*model.DataExport, error)
+	GetExport(ctx context.Context, userID, exportID int64) (*model.DataExport, error)
+	// GetExportFile devuelve la ruta del archivo de una exportación lista y el nombre de descarga
+	GetExportFile(ctx context.Context, userID, exportID int64) (string, string, error)
+	// ProcessNextExport genera la exportación pendiente más antigua; devuelve false si no había
+	ProcessNextExport(ctx context.Context) (bool, error)
+	// PurgeExpiredExports borra las exportaciones vencidas y sus archivos
+	PurgeExpiredExports(ctx context.Context, now time.Time) (int, error)
+}
+
+// AuthService define las operaciones de negocio para autenticación
+type AuthService interface {
+	Register(ctx context.Context, username, email, password string) (*model.User, error)
+	Login(ctx context.Context, username, password string) (*model.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.AuthTokens, error)
+	Logout(ctx context.Context, accessToken string) error
+}
+
+// APITokenService define las operaciones de negocio para tokens de API personales
+type APITokenService interface {
+	CreateToken(ctx context.Context, userID int64, name string, scopes []string) (*model.CreateAPITokenResponse, error)
+	ListTokens(ctx context.Context, userID int64) ([]*model.APIToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID int64) error
+}
+
 // TweetService define las operaciones de negocio para tweets
 type TweetService interface {
 	CreateTweet(ctx context.Context, userID int64, content string) (*model.TweetResponse, error)
-	GetTweet(ctx context.Context, tweetID int64) (*model.TweetResponse, error)
-	GetUserTweets(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetResponse, error)
+	ReplyToTweet(ctx context.Context, userID, inReplyToTweetID int64, content string) (*model.TweetResponse, error)
+	QuoteTweet(ctx context.Context, userID, quotedTweetID int64, content string) (*model.TweetResponse, error)
+	Retweet(ctx context.Context, userID, tweetID int64) (*model.TweetResponse, error)
+	Unretweet(ctx context.Context, userID, tweetID int64) error
+	GetTweet(ctx context.Context, viewerID, tweetID int64) (*model.TweetResponse, error)
+	GetUserTweets(ctx context.Context, viewerID, userID int64, page model.PageQuery) (*model.TweetPage, error)
+	DeleteTweet(ctx context.Context, userID, tweetID int64) error
+	UpdateTweet(ctx context.Context, userID, tweetID int64, content string) (*model.TweetResponse, error)
+	GetTweetHistory(ctx context.Context, viewerID, tweetID int64) ([]*model.TweetRevision, error)
+	GetThread(ctx context.Context, viewerID, tweetID int64, limit, offset int) (*model.ThreadResponse, error)
+}
+
+// SearchService define las operaciones de negocio para búsquedas
+type SearchService interface {
+	SearchTweets(ctx context.Context, viewerID int64, q string, page model.PageQuery) (*model.TweetPage, error)
+	SearchUsers(ctx context.Context, q string, limit int) ([]*model.User, error)
+}
+
+// LikeService define las operaciones de negocio para likes
+type LikeService interface {
+	LikeTweet(ctx context.Context, userID, tweetID int64) error
+	UnlikeTweet(ctx context.Context, userID, tweetID int64) error
+	GetLikers(ctx context.Context, viewerID, tweetID int64, limit, offset int) ([]*model.User, error)
+}
+
+// BookmarkService define las operaciones de negocio para tweets guardados
+type BookmarkService interface {
+	BookmarkTweet(ctx context.Context, userID, tweetID int64) error
+	RemoveBookmark(ctx context.Context, userID, tweetID int64) error
+	// GetBookmarks obtiene una página de los tweets guardados, del guardado más reciente al más antiguo
+	GetBookmarks(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error)
 }
 
 // FollowService define las operaciones de negocio para follows
 type FollowService interface {
-	FollowUser(ctx context.Context, followerID, followingID int64) error
+	// FollowUser sigue al usuario, o le envía un pedido de follow si su cuenta es protegida.
+	// Devuelve model.FollowStatusFollowing o model.FollowStatusPending.
+	FollowUser(ctx context.Context, followerID, followingID int64) (string, error)
+	// UnfollowUser deja de seguir al usuario, o cancela el pedido pendiente
 	UnfollowUser(ctx context.Context, followerID, followingID int64) error
-	GetFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error)
-	GetFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error)
+	GetFollowers(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	GetFollowing(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
 	IsFollowing(ctx context.Context, followerID, followingID int64) (bool, error)
+	// GetFollowRequests devuelve los usuarios que pidieron seguir al usuario y esperan aprobación
+	GetFollowRequests(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	ApproveFollowRequest(ctx context.Context, userID, requesterID int64) error
+	RejectFollowRequest(ctx context.Context, userID, requesterID int64) error
+}
+
+// BlockService define las operaciones de negocio para bloqueos
+type BlockService interface {
+	// BlockUser bloquea al usuario y elimina los follows y pedidos de follow entre ambos
+	BlockUser(ctx context.Context, blockerID, blockedID int64) error
+	UnblockUser(ctx context.Context, blockerID, blockedID int64) error
+}
+
+// MuteService define las operaciones de negocio para cuentas y palabras silenciadas
+type MuteService interface {
+	// MuteUser silencia la cuenta hasta expiresAt, o sin vencimiento si es nil
+	MuteUser(ctx context.Context, userID, mutedUserID int64, expiresAt *time.Time) (*model.MutedUser, error)
+	UnmuteUser(ctx context.Context, userID, mutedUserID int64) error
+	GetMutedUsers(ctx context.Context, userID int64) ([]*model.MutedUser, error)
+	// MuteWord silencia una palabra, frase o hashtag; se compara sin distinguir mayúsculas
+	MuteWord(ctx context.Context, userID int64, phrase string) (*model.MutedWord, error)
+	UnmuteWord(ctx context.Context, userID, wordID int64) error
+	GetMutedWords(ctx context.Context, userID int64) ([]*model.MutedWord, error)
+}
+
+// ListService define las operaciones de negocio para listas. Las listas privadas solo las ve su
+// dueño: para el resto se comportan como si no existieran.
+type ListService interface {
+	CreateList(ctx context.Context, ownerID int64, req *model.CreateListRequest) (*model.List, error)
+	GetList(ctx context.Context, viewerID, listID int64) (*model.List, error)
+	UpdateList(ctx context.Context, ownerID, listID int64, req *model.UpdateListRequest) (*model.List, error)
+	DeleteList(ctx context.Context, ownerID, listID int64) error
+	// GetUserLists obtiene las listas de userID; las privadas solo si las pide su dueño
+	GetUserLists(ctx context.Context, viewerID, userID int64) ([]*model.List, error)
+	GetSubscribedLists(ctx context.Context, userID int64) ([]*model.List, error)
+	AddMember(ctx context.Context, ownerID, listID, userID int64) error
+	RemoveMember(ctx context.Context, ownerID, listID, userID int64) error
+	GetMembers(ctx context.Context, viewerID, listID int64, page model.PageQuery) (*model.UserPage, error)
+	Subscribe(ctx context.Context, userID, listID int64) error
+	Unsubscribe(ctx context.Context, userID, listID int64) error
+	// GetListTimeline obtiene una página de los tweets de los miembros de la lista que viewerID puede ver
+	GetListTimeline(ctx context.Context, viewerID, listID int64, page model.PageQuery) (*model.TweetPage, error)
+}
+
+// FanoutService define la distribución de tweets a los timelines de los seguidores
+type FanoutService interface {
+	// Dispatch encola la distribución de un tweet recién publicado
+	Dispatch(ctx context.Context, tweet *model.TweetWithUser) error
+	// ProcessJob distribuye el tweet de un job a todos los seguidores de su autor
+	ProcessJob(ctx context.Context, job *model.FanoutJob) error
+}
+
+// EventService define la entrega de eventos en tiempo real a cada usuario
+type EventService interface {
+	// Subscribe entrega los eventos en tiempo real del usuario, con el tweet ya hidratado,
+	// hasta que se cancele ctx
+	Subscribe(ctx context.Context, userID int64) (<-chan *model.EventMessage, error)
+}
+
+// HashtagService define las operaciones de negocio para hashtags y tendencias
+type HashtagService interface {
+	// GetHashtagTweets devuelve los tweets que usan el hashtag, con o sin # y sin distinguir mayúsculas
+	GetHashtagTweets(ctx context.Context, viewerID int64, tag string, page model.PageQuery) (*model.TweetPage, error)
+	// GetTrends devuelve los hashtags en tendencia de la ventana dada (hour o day)
+	GetTrends(ctx context.Context, window string, limit int) ([]*model.Trend, error)
 }
 
 // TimelineService define las operaciones de negocio para timeline
 type TimelineService interface {
-	GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetResponse, error)
+	GetTimeline(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error)
+	// GetMentions devuelve los tweets que mencionan al usuario, incluso de cuentas que no sigue
+	GetMentions(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error)
+	// StreamTimeline envía los tweets que llegan al timeline hasta que se cancele ctx. Si since no
+	// es nil, primero envía los tweets posteriores a ese cursor que el cliente no recibió.
+	StreamTimeline(ctx context.Context, userID int64, since *model.Cursor) (<-chan *model.TweetResponse, error)
 	RefreshTimeline(ctx context.Context, userID int64) error
 	PreloadAllTimelines(ctx context.Context) error
 }
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+)
+
+type likeService struct {
+	likeRepo   repository.LikeRepository
+	tweetRepo  repository.TweetRepository
+	userRepo   repository.UserRepository
+	followRepo repository.FollowRepository
+	blockRepo  repository.BlockRepository
+	eventRepo  repository.EventRepository
+}
+
+// NewLikeService crea una nueva instancia del servicio de likes
+func NewLikeService(
+	likeRepo repository.LikeRepository,
+	tweetRepo repository.TweetRepository,
+	userRepo repository.UserRepository,
+	followRepo repository.FollowRepository,
+	blockRepo repository.BlockRepository,
+	eventRepo repository.EventRepository,
+) LikeService {
+	return &likeService{
+		likeRepo:   likeRepo,
+		tweetRepo:  tweetRepo,
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		blockRepo:  blockRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+// LikeTweet da like a un tweet que el usuario puede ver: no a los de quien lo bloqueó ni a los de
+// una cuenta protegida que no sigue
+func (s *likeService) LikeTweet(ctx context.Context, userID, tweetID int64) error {
+	tweet, err := s.resolveVisibleTweet(ctx, userID, tweetID)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.likeRepo.Create(ctx, &model.Like{UserID: userID, TweetID: tweet.ID})
+	if err != nil {
+		return fmt.Errorf("error liking tweet: %w", err)
+	}
+
+	if !created {
+		return ErrAlreadyLiked
+	}
+
+	// Avisar al autor del tweet
+	publishEvent(ctx, s.eventRepo, tweet.UserID, &model.Event{
+		Channel: model.EventChannelNotifications,
+		Type:    model.EventTypeLike,
+		ActorID: userID,
+		TweetID: tweet.ID,
+	})
+
+	return nil
+}
+
+func (s *likeService) UnlikeTweet(ctx context.Context, userID, tweetID int64) error {
+	tweetID, err := s.resolveTweetID(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+
+	err = s.likeRepo.Delete(ctx, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("error unliking tweet: %w", err)
+	}
+
+	return nil
+}
+
+func (s *likeService) GetLikers(ctx context.Context, viewerID, tweetID int64, limit, offset int) ([]*model.User, error) {
+	tweet, err := s.resolveVisibleTweet(ctx, viewerID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.likeRepo.GetLikers(ctx, tweet.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting likers: %w", err)
+	}
+
+	return users, nil
+}
+
+// resolveTweetID verifica que el tweet existe. Los likes sobre un retweet se aplican al tweet original.
+func (s *likeService) resolveTweetID(ctx context.Context, tweetID int64) (int64, error) {
+	tweet, err := s.resolveTweet(ctx, tweetID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tweet.ID, nil
+}
+
+// resolveTweet obtiene el tweet al que se aplica un like: el propio tweet o, si es un retweet, el original
+func (s *likeService) resolveTweet(ctx context.Context, tweetID int64) (*model.TweetWithUser, error) {
+	return resolveOriginalTweet(ctx, s.tweetRepo, tweetID)
+}
+
+// resolveVisibleTweet obtiene el tweet original como resolveTweet y verifica que viewerID puede
+// ver los tweets de su autor
+func (s *likeService) resolveVisibleTweet(ctx context.Context, viewerID, tweetID int64) (*model.TweetWithUser, error) {
+	tweet, err := s.resolveTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, tweet.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, viewerID, author); err != nil {
+		return nil, err
+	}
+
+	return tweet, nil
+}
+
+// resolveOriginalTweet obtiene el tweet dado o, si es un retweet, el original. Los likes y los
+// guardados de un retweet se aplican al tweet original.
+func resolveOriginalTweet(ctx context.Context, tweetRepo repository.TweetRepository, tweetID int64) (*model.TweetWithUser, error) {
+	tweet, err := tweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	if tweet.RetweetOfTweetID == nil {
+		return tweet, nil
+	}
+	if tweet.RetweetedTweet != nil {
+		return tweet.RetweetedTweet, nil
+	}
+
+	original, err := tweetRepo.GetByID(ctx, *tweet.RetweetOfTweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting original tweet: %w", err)
+	}
+
+	return original, nil
+}
+
+// applyLikes completa like_count y liked_by_me de las respuestas (y de los tweets que embeben)
+// con dos consultas por página en lugar de una por tweet. viewerID 0 indica un usuario anónimo.
+func applyLikes(ctx context.Context, likeRepo repository.LikeRepository, viewerID int64, responses []*model.TweetResponse) {
+	if likeRepo == nil || len(responses) == 0 {
+		return
+	}
+
+	var all []*model.TweetResponse
+	for _, response := range responses {
+		all = append(all, response)
+		if response.RetweetedTweet != nil {
+			all = append(all, response.RetweetedTweet)
+		}
+		if response.QuotedTweet != nil {
+			all = append(all, response.QuotedTweet)
+		}
+	}
+
+	tweetIDs := make([]int64, 0, len(all))
+	for _, response := range all {
+		tweetIDs = append(tweetIDs, response.ID)
+	}
+
+	counts, err := likeRepo.GetLikeCounts(ctx, tweetIDs)
+	if err != nil {
+		fmt.Printf("Warning: error getting like counts: %v\n", err)
+		return
+	}
+
+	var liked map[int64]bool
+	if viewerID > 0 {
+		liked, err = likeRepo.GetLikedTweetIDs(ctx, viewerID, tweetIDs)
+		if err != nil {
+			fmt.Printf("Warning: error getting liked tweets: %v\n", err)
+		}
+	}
+
+	for _, response := range all {
+		response.LikeCount = counts[response.ID]
+		response.LikedByMe = liked[response.ID]
+	}
+}
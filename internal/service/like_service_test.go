@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+)
+
+func TestLikeService_LikeTweet(t *testing.T) {
+	ctx := context.Background()
+	originalID := int64(7)
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		if id == originalID {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id}}, nil
+		}
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, RetweetOfTweetID: &originalID}}, nil
+	}}
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "autor", Protected: id == 9}, nil
+	}}
+
+	t.Run("like sobre un retweet se aplica al original", func(t *testing.T) {
+		var liked int64
+		likeRepo := &mockLikeRepo{createFunc: func(ctx context.Context, like *model.Like) (bool, error) {
+			liked = like.TweetID
+			return true, nil
+		}}
+		service := NewLikeService(likeRepo, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil)
+		err := service.LikeTweet(ctx, 1, 50)
+		if err != nil || liked != originalID {
+			t.Errorf("esperaba like sobre el tweet %d, obtuve err: %v, tweet: %d", originalID, err, liked)
+		}
+	})
+
+	t.Run("like duplicado", func(t *testing.T) {
+		likeRepo := &mockLikeRepo{createFunc: func(ctx context.Context, like *model.Like) (bool, error) {
+			return false, nil
+		}}
+		service := NewLikeService(likeRepo, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil)
+		err := service.LikeTweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrAlreadyLiked) {
+			t.Errorf("esperaba ErrAlreadyLiked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el autor bloqueó al usuario", func(t *testing.T) {
+		likeRepo := &mockLikeRepo{createFunc: func(ctx context.Context, like *model.Like) (bool, error) {
+			t.Error("no esperaba registrar el like")
+			return true, nil
+		}}
+		authorRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+		}}
+		service := NewLikeService(likeRepo, authorRepo, userRepo, &mockFollowRepo{}, newMockBlockRepo([2]int64{2, 1}), nil)
+		if err := service.LikeTweet(ctx, 1, originalID); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+
+	t.Run("cuenta protegida que no sigue", func(t *testing.T) {
+		protectedRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 9}}, nil
+		}}
+		service := NewLikeService(&mockLikeRepo{}, protectedRepo, userRepo, &mockFollowRepo{}, nil, nil)
+		if err := service.LikeTweet(ctx, 1, originalID); !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+
+	t.Run("tweet no existe", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return nil, errors.New("no existe")
+		}}
+		service := NewLikeService(&mockLikeRepo{}, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil)
+		err := service.LikeTweet(ctx, 1, originalID)
+		if err == nil {
+			t.Error("esperaba error por tweet inexistente")
+		}
+	})
+}
+
+func TestLikeService_GetLikers(t *testing.T) {
+	ctx := context.Background()
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+	}}
+
+	t.Run("cuenta protegida que no sigue", func(t *testing.T) {
+		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "autor", Protected: true}, nil
+		}}
+		service := NewLikeService(&mockLikeRepo{}, tweetRepo, userRepo, &mockFollowRepo{}, nil, nil)
+		if _, err := service.GetLikers(ctx, 1, 7, 20, 0); !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el autor bloqueó al visitante", func(t *testing.T) {
+		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "autor"}, nil
+		}}
+		service := NewLikeService(&mockLikeRepo{}, tweetRepo, userRepo, &mockFollowRepo{}, newMockBlockRepo([2]int64{2, 1}), nil)
+		if _, err := service.GetLikers(ctx, 1, 7, 20, 0); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+		if _, err := service.GetLikers(ctx, 3, 7, 20, 0); err != nil {
+			t.Errorf("error inesperado para otro visitante: %v", err)
+		}
+	})
+}
+
+func TestApplyLikes(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	likeRepo := &mockLikeRepo{
+		getLikeCountsFunc: func(ctx context.Context, tweetIDs []int64) (map[int64]int64, error) {
+			calls++
+			return map[int64]int64{1: 3, 2: 5}, nil
+		},
+		getLikedTweetIDsFunc: func(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+			calls++
+			return map[int64]bool{2: true}, nil
+		},
+	}
+	responses := []*model.TweetResponse{
+		{ID: 1},
+		{ID: 3, RetweetedTweet: &model.TweetResponse{ID: 2}},
+	}
+
+	applyLikes(ctx, likeRepo, 9, responses)
+
+	if calls != 2 {
+		t.Errorf("esperaba 2 consultas por página, obtuve %d", calls)
+	}
+	if responses[0].LikeCount != 3 || responses[0].LikedByMe {
+		t.Errorf("respuesta inesperada para el tweet 1: %+v", responses[0])
+	}
+	if responses[1].RetweetedTweet.LikeCount != 5 || !responses[1].RetweetedTweet.LikedByMe {
+		t.Errorf("respuesta inesperada para el tweet retuiteado: %+v", responses[1].RetweetedTweet)
+	}
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type listService struct {
+	listRepo repository.ListRepository
+	// listTimelineRepo guarda un timeline cacheado por lista, que alimenta el fan-out
+	listTimelineRepo repository.TimelineRepository
+	tweetRepo        repository.TweetRepository
+	userRepo         repository.UserRepository
+	followRepo       repository.FollowRepository
+	likeRepo         repository.LikeRepository
+	bookmarkRepo     repository.BookmarkRepository
+	blockRepo        repository.BlockRepository
+	muteRepo         repository.MuteRepository
+	hydrator         *tweetHydrator
+}
+
+// NewListService crea una nueva instancia del servicio de listas
+func NewListService(
+	listRepo repository.ListRepository,
+	listTimelineRepo repository.TimelineRepository,
+	tweetRepo repository.TweetRepository,
+	userRepo repository.UserRepository,
+	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+	tweetCache repository.TweetCacheRepository,
+	blockRepo repository.BlockRepository,
+	muteRepo repository.MuteRepository,
+) ListService {
+	return &listService{
+		listRepo:         listRepo,
+		listTimelineRepo: listTimelineRepo,
+		tweetRepo:        tweetRepo,
+		userRepo:         userRepo,
+		followRepo:       followRepo,
+		likeRepo:         likeRepo,
+		bookmarkRepo:     bookmarkRepo,
+		blockRepo:        blockRepo,
+		muteRepo:         muteRepo,
+		hydrator:         newTweetHydrator(tweetCache, tweetRepo),
+	}
+}
+
+func (s *listService) CreateList(ctx context.Context, ownerID int64, req *model.CreateListRequest) (*model.List, error) {
+	list := &model.List{OwnerID: ownerID, Private: req.Private}
+	if err := setListFields(list, &req.Name, &req.Description); err != nil {
+		return nil, err
+	}
+
+	lists, err := s.listRepo.GetByOwner(ctx, ownerID, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user lists: %w", err)
+	}
+	if len(lists) >= model.MaxListsPerUser {
+		return nil, fmt.Errorf("%w: cannot create more than %d lists", ErrInvalidList, model.MaxListsPerUser)
+	}
+
+	if err := s.listRepo.Create(ctx, list); err != nil {
+		return nil, fmt.Errorf("error creating list: %w", err)
+	}
+
+	return list, nil
+}
+
+func (s *listService) GetList(ctx context.Context, viewerID, listID int64) (*model.List, error) {
+	return s.getVisibleList(ctx, viewerID, listID)
+}
+
+func (s *listService) UpdateList(ctx context.Context, ownerID, listID int64, req *model.UpdateListRequest) (*model.List, error) {
+	list, err := s.getOwnedList(ctx, ownerID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setListFields(list, req.Name, req.Description); err != nil {
+		return nil, err
+	}
+	if req.Private != nil {
+		list.Private = *req.Private
+	}
+
+	if err := s.listRepo.Update(ctx, list); err != nil {
+		return nil, fmt.Errorf("error updating list: %w", err)
+	}
+
+	return list, nil
+}
+
+// DeleteList elimina la lista con sus miembros y suscripciones, y su timeline cacheado
+func (s *listService) DeleteList(ctx context.Context, ownerID, listID int64) error {
+	if _, err := s.getOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+
+	err := s.listRepo.Delete(ctx, listID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrListNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting list: %w", err)
+	}
+
+	s.invalidateTimeline(ctx, listID)
+	return nil
+}
+
+func (s *listService) GetUserLists(ctx context.Context, viewerID, userID int64) ([]*model.List, error) {
+	lists, err := s.listRepo.GetByOwner(ctx, userID, viewerID == userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (s *listService) GetSubscribedLists(ctx context.Context, userID int64) ([]*model.List, error) {
+	lists, err := s.listRepo.GetSubscribed(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting subscribed lists: %w", err)
+	}
+
+	return lists, nil
+}
+
+// AddMember agrega la cuenta a la lista sin seguirla. No se puede agregar a quien bloqueó al dueño
+// o fue bloqueado por él.
+func (s *listService) AddMember(ctx context.Context, ownerID, listID, userID int64) error {
+	list, err := s.getOwnedList(ctx, ownerID, listID)
+	if err != nil {
+		return err
+	}
+	if list.MemberCount >= model.MaxListMembers {
+		return fmt.Errorf("%w: a list cannot have more than %d members", ErrInvalidList, model.MaxListMembers)
+	}
+
+	_, err = s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidList, err)
+	}
+
+	if err := checkNotBlocked(ctx, s.blockRepo, ownerID, userID); err != nil {
+		return err
+	}
+
+	added, err := s.listRepo.AddMember(ctx, listID, userID)
+	if err != nil {
+		return fmt.Errorf("error adding list member: %w", err)
+	}
+	if !added {
+		return ErrAlreadyListMember
+	}
+
+	// El timeline cacheado no tiene los tweets anteriores del nuevo miembro; se reconstruye al leerlo
+	s.invalidateTimeline(ctx, listID)
+	return nil
+}
+
+func (s *listService) RemoveMember(ctx context.Context, ownerID, listID, userID int64) error {
+	if _, err := s.getOwnedList(ctx, ownerID, listID); err != nil {
+		return err
+	}
+
+	err := s.listRepo.RemoveMember(ctx, listID, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrListMemberNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error removing list member: %w", err)
+	}
+
+	s.invalidateTimeline(ctx, listID)
+	return nil
+}
+
+func (s *listService) GetMembers(ctx context.Context, viewerID, listID int64, page model.PageQuery) (*model.UserPage, error) {
+	if _, err := s.getVisibleList(ctx, viewerID, listID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.listRepo.GetMembers(ctx, listID, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list members: %w", err)
+	}
+
+	return members, nil
+}
+
+// Subscribe suscribe al usuario a una lista pública de otro usuario
+func (s *listService) Subscribe(ctx context.Context, userID, listID int64) error {
+	list, err := s.getVisibleList(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+	if list.OwnerID == userID {
+		return fmt.Errorf("%w: cannot subscribe to your own list", ErrInvalidList)
+	}
+
+	if err := checkNotBlocked(ctx, s.blockRepo, userID, list.OwnerID); err != nil {
+		return err
+	}
+
+	subscribed, err := s.listRepo.Subscribe(ctx, listID, userID)
+	if err != nil {
+		return fmt.Errorf("error subscribing to list: %w", err)
+	}
+	if !subscribed {
+		return ErrAlreadySubscribed
+	}
+
+	return nil
+}
+
+func (s *listService) Unsubscribe(ctx context.Context, userID, listID int64) error {
+	err := s.listRepo.Unsubscribe(ctx, listID, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrNotSubscribed
+	}
+	if err != nil {
+		return fmt.Errorf("error unsubscribing from list: %w", err)
+	}
+
+	return nil
+}
+
+// GetListTimeline lee el timeline de la lista igual que el timeline personal: desde la caché si
+// está, completando desde MySQL lo que falte. El timeline cacheado es el mismo para todos los
+// lectores, así que lo que cada uno no puede o no quiere ver se descarta al leer.
+func (s *listService) GetListTimeline(ctx context.Context, viewerID, listID int64, page model.PageQuery) (*model.TweetPage, error) {
+	if _, err := s.getVisibleList(ctx, viewerID, listID); err != nil {
+		return nil, err
+	}
+
+	filter := &timelineFilter{hiddenUsers: make(map[int64]bool)}
+	if viewerID > 0 {
+		filter = loadTimelineFilter(ctx, s.blockRepo, s.muteRepo, viewerID)
+	}
+	if err := s.hideProtectedMembers(ctx, viewerID, listID, filter); err != nil {
+		return nil, err
+	}
+
+	read := func(ctx context.Context, page model.PageQuery) (*model.TweetPage, error) {
+		return s.getListTimelinePage(ctx, viewerID, listID, page, filter)
+	}
+
+	result, err := read(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return fillTimelinePage(ctx, page, filter, result, read), nil
+}
+
+// hideProtectedMembers agrega al filtro los miembros con la cuenta protegida que viewerID no sigue
+func (s *listService) hideProtectedMembers(ctx context.Context, viewerID, listID int64, filter *timelineFilter) error {
+	memberIDs, err := s.listRepo.GetProtectedMemberIDs(ctx, listID)
+	if err != nil {
+		return fmt.Errorf("error getting protected list members: %w", err)
+	}
+
+	return filter.hideUnfollowed(ctx, s.followRepo, viewerID, memberIDs)
+}
+
+// getListTimelinePage lee una página del timeline de la lista desde la caché o, si no está
+// cacheado, desde MySQL, y le aplica el filtro
+func (s *listService) getListTimelinePage(ctx context.Context, viewerID, listID int64, page model.PageQuery, filter *timelineFilter) (*model.TweetPage, error) {
+	entries, err := s.listTimelineRepo.GetTimeline(ctx, listID, page)
+	if err != nil {
+		fmt.Printf("Warning: error getting list timeline from cache: %v\n", err)
+		return s.getListTimelineFromDatabase(ctx, viewerID, listID, page, filter)
+	}
+
+	if len(entries) == 0 {
+		return s.getListTimelineFromDatabase(ctx, viewerID, listID, page, filter)
+	}
+
+	tweetIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		tweetIDs[i] = entry.ID
+	}
+
+	tweets, err := s.hydrator.Hydrate(ctx, tweetIDs)
+	if err != nil {
+		fmt.Printf("Warning: error hydrating list timeline: %v\n", err)
+		return s.getListTimelineFromDatabase(ctx, viewerID, listID, page, filter)
+	}
+
+	// El timeline cacheado está recortado, así que si no llena la página se completa desde MySQL a
+	// partir del tweet cacheado más antiguo, y esos tweets también se cachean
+	if len(tweets) < page.Limit {
+		older := model.PageQuery{Limit: page.Limit - len(tweets), Before: entries[len(entries)-1], Since: page.Since}
+		olderTweets, err := s.tweetRepo.GetListTimeline(ctx, listID, older)
+		if err != nil {
+			fmt.Printf("Warning: error getting older list timeline tweets from database: %v\n", err)
+		} else {
+			s.cacheTimeline(ctx, listID, olderTweets)
+			tweets = append(tweets, olderTweets...)
+		}
+	}
+
+	result := toTimelinePage(ctx, s.likeRepo, s.bookmarkRepo, viewerID, page, tweets, filter)
+
+	// Si se borraron tweets de una página completa, la página queda corta pero puede haber más
+	if result.NextCursor == nil && len(entries) >= page.Limit {
+		result.NextCursor = entries[len(entries)-1]
+	}
+
+	return result, nil
+}
+
+// getListTimelineFromDatabase obtiene el timeline de la lista desde la base de datos. Como en el
+// timeline personal, solo se cachea la primera página.
+func (s *listService) getListTimelineFromDatabase(ctx context.Context, viewerID, listID int64, page model.PageQuery, filter *timelineFilter) (*model.TweetPage, error) {
+	tweets, err := s.tweetRepo.GetListTimeline(ctx, listID, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting list timeline from database: %w", err)
+	}
+
+	if page.Before == nil && page.Since == nil {
+		s.cacheTimeline(ctx, listID, tweets)
+	}
+
+	return toTimelinePage(ctx, s.likeRepo, s.bookmarkRepo, viewerID, page, tweets, filter), nil
+}
+
+// cacheTimeline agrega al timeline cacheado de la lista tweets leídos desde la base de datos
+func (s *listService) cacheTimeline(ctx context.Context, listID int64, tweets []*model.TweetWithUser) {
+	if len(tweets) == 0 {
+		return
+	}
+
+	s.hydrator.Prime(ctx, tweets)
+	for _, tweet := range tweets {
+		if err := s.listTimelineRepo.AddToTimeline(ctx, listID, tweet); err != nil {
+			fmt.Printf("Warning: error caching list timeline: %v\n", err)
+			return
+		}
+	}
+}
+
+// invalidateTimeline descarta el timeline cacheado de la lista, que se reconstruye en la próxima lectura
+func (s *listService) invalidateTimeline(ctx context.Context, listID int64) {
+	if err := s.listTimelineRepo.InvalidateTimeline(ctx, listID); err != nil {
+		fmt.Printf("Warning: error invalidating list %d timeline: %v\n", listID, err)
+	}
+}
+
+// getVisibleList obtiene la lista si viewerID puede verla. Una lista privada de otro usuario se
+// informa como inexistente.
+func (s *listService) getVisibleList(ctx context.Context, viewerID, listID int64) (*model.List, error) {
+	list, err := s.listRepo.GetByID(ctx, listID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrListNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting list: %w", err)
+	}
+
+	if list.Private && list.OwnerID != viewerID {
+		return nil, ErrListNotFound
+	}
+
+	return list, nil
+}
+
+// getOwnedList obtiene la lista si ownerID es su dueño
+func (s *listService) getOwnedList(ctx context.Context, ownerID, listID int64) (*model.List, error) {
+	list, err := s.getVisibleList(ctx, ownerID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.OwnerID != ownerID {
+		return nil, ErrNotListOwner
+	}
+
+	return list, nil
+}
+
+// setListFields valida y asigna el nombre y la descripción de la lista; los nil no se modifican
+func setListFields(list *model.List, name, description *string) error {
+	if name != nil {
+		value := strings.TrimSpace(*name)
+		if value == "" {
+			return fmt.Errorf("%w: name cannot be empty", ErrInvalidList)
+		}
+		if utf8.RuneCountInString(value) > model.MaxListNameLength {
+			return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidList, model.MaxListNameLength)
+		}
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("%w: name cannot contain control characters", ErrInvalidList)
+		}
+		list.Name = value
+	}
+
+	if description != nil {
+		value := strings.TrimSpace(*description)
+		if utf8.RuneCountInString(value) > model.MaxListDescriptionLength {
+			return fmt.Errorf("%w: description must be at most %d characters", ErrInvalidList, model.MaxListDescriptionLength)
+		}
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("%w: description cannot contain control characters", ErrInvalidList)
+		}
+		list.Description = value
+	}
+
+	return nil
+}
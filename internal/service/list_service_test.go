@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListService_CreateList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("crear una lista", func(t *testing.T) {
+		service := NewListService(newMockListRepo(), &mockTimelineRepo{}, &mockTweetRepo{}, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil)
+		list, err := service.CreateList(ctx, 1, &model.CreateListRequest{Name: "  Gophers ", Description: "Go", Private: true})
+		if err != nil || list.ID == 0 || list.Name != "Gophers" || !list.Private || list.OwnerID != 1 {
+			t.Errorf("esperaba la lista creada, obtuve err: %v, list: %+v", err, list)
+		}
+	})
+
+	t.Run("nombre inválido", func(t *testing.T) {
+		service := NewListService(newMockListRepo(), &mockTimelineRepo{}, &mockTweetRepo{}, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil)
+		for _, name := range []string{"   ", strings.Repeat("a", model.MaxListNameLength+1)} {
+			if _, err := service.CreateList(ctx, 1, &model.CreateListRequest{Name: name}); !errors.Is(err, ErrInvalidList) {
+				t.Errorf("esperaba ErrInvalidList para %q, obtuve: %v", name, err)
+			}
+		}
+	})
+}
+
+func TestListService_Access(t *testing.T) {
+	ctx := context.Background()
+	newService := func() ListService {
+		listRepo := newMockListRepo(
+			&model.List{ID: 1, OwnerID: 1, Name: "pública"},
+			&model.List{ID: 2, OwnerID: 1, Name: "privada", Private: true},
+		)
+		return NewListService(listRepo, &mockTimelineRepo{}, &mockTweetRepo{}, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, newMockBlockRepo([2]int64{1, 3}), nil)
+	}
+
+	t.Run("una lista privada solo la ve su dueño", func(t *testing.T) {
+		service := newService()
+		if _, err := service.GetList(ctx, 1, 2); err != nil {
+			t.Errorf("error inesperado para el dueño: %v", err)
+		}
+		if _, err := service.GetList(ctx, 2, 2); !errors.Is(err, ErrListNotFound) {
+			t.Errorf("esperaba ErrListNotFound, obtuve: %v", err)
+		}
+		if _, err := service.GetListTimeline(ctx, 0, 2, model.PageQuery{Limit: 20}); !errors.Is(err, ErrListNotFound) {
+			t.Errorf("esperaba ErrListNotFound sin autenticación, obtuve: %v", err)
+		}
+	})
+
+	t.Run("solo el dueño modifica la lista", func(t *testing.T) {
+		service := newService()
+		name := "otra"
+		if _, err := service.UpdateList(ctx, 2, 1, &model.UpdateListRequest{Name: &name}); !errors.Is(err, ErrNotListOwner) {
+			t.Errorf("esperaba ErrNotListOwner, obtuve: %v", err)
+		}
+		if err := service.AddMember(ctx, 2, 1, 4); !errors.Is(err, ErrNotListOwner) {
+			t.Errorf("esperaba ErrNotListOwner, obtuve: %v", err)
+		}
+	})
+
+	t.Run("suscripciones", func(t *testing.T) {
+		service := newService()
+		if err := service.Subscribe(ctx, 2, 1); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if err := service.Subscribe(ctx, 2, 1); !errors.Is(err, ErrAlreadySubscribed) {
+			t.Errorf("esperaba ErrAlreadySubscribed, obtuve: %v", err)
+		}
+		if err := service.Subscribe(ctx, 1, 1); !errors.Is(err, ErrInvalidList) {
+			t.Errorf("esperaba ErrInvalidList al suscribirse a la propia lista, obtuve: %v", err)
+		}
+		if err := service.Subscribe(ctx, 2, 2); !errors.Is(err, ErrListNotFound) {
+			t.Errorf("esperaba ErrListNotFound en una lista privada, obtuve: %v", err)
+		}
+		if err := service.Subscribe(ctx, 3, 1); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked para el bloqueado, obtuve: %v", err)
+		}
+	})
+
+	t.Run("miembros", func(t *testing.T) {
+		service := newService()
+		if err := service.AddMember(ctx, 1, 1, 4); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if err := service.AddMember(ctx, 1, 1, 4); !errors.Is(err, ErrAlreadyListMember) {
+			t.Errorf("esperaba ErrAlreadyListMember, obtuve: %v", err)
+		}
+		if err := service.AddMember(ctx, 1, 1, 3); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+		if err := service.RemoveMember(ctx, 1, 1, 5); !errors.Is(err, ErrListMemberNotFound) {
+			t.Errorf("esperaba ErrListMemberNotFound, obtuve: %v", err)
+		}
+	})
+}
+
+func TestListService_GetListTimeline(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(id, userID int64) *model.TweetWithUser {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: userID, CreatedAt: base.Add(time.Duration(id) * time.Minute)}}
+	}
+
+	// La lista tiene a 2, con la cuenta protegida, y a 3
+	tweets := []*model.TweetWithUser{at(5, 2), at(4, 3), at(3, 2), at(2, 3), at(1, 3)}
+	listRepo := newMockListRepo(&model.List{ID: 1, OwnerID: 1, Name: "lista"})
+	listRepo.members[1] = []int64{2, 3}
+	listRepo.protected[2] = true
+
+	tweetRepo := &mockTweetRepo{getListTimelineFunc: func(ctx context.Context, listID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return pageOf(tweets, page), nil
+	}}
+	// 5 sigue a 2; 4 no
+	followRepo := &mockFollowRepo{getFollowedAmongFunc: func(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error) {
+		if followerID == 5 {
+			return []int64{2}, nil
+		}
+		return nil, nil
+	}}
+	service := NewListService(listRepo, &mockTimelineRepo{}, tweetRepo, &mockUserRepo{}, followRepo, nil, nil, nil, nil, nil)
+
+	t.Run("oculta las cuentas protegidas que el lector no sigue y completa la página", func(t *testing.T) {
+		resp, err := service.GetListTimeline(ctx, 4, 1, model.PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(resp, 4, 2, 1) {
+			t.Errorf("esperaba los tweets 4, 2 y 1, obtuve %+v", resp.Tweets)
+		}
+	})
+
+	t.Run("un seguidor de la cuenta protegida ve sus tweets", func(t *testing.T) {
+		resp, err := service.GetListTimeline(ctx, 5, 1, model.PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(resp, 5, 4, 3) {
+			t.Errorf("esperaba los tweets 5, 4 y 3, obtuve %+v", resp.Tweets)
+		}
+	})
+
+	t.Run("sin autenticación tampoco se ven", func(t *testing.T) {
+		resp, err := service.GetListTimeline(ctx, 0, 1, model.PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(resp, 4, 2, 1) {
+			t.Errorf("esperaba los tweets 4, 2 y 1, obtuve %+v", resp.Tweets)
+		}
+	})
+}
@@ -1,13 +1,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"microx/internal/model"
+	"microx/internal/repository"
+	"time"
 )
 
 type mockUserRepo struct {
-	getByIDFunc func(ctx context.Context, id int64) (*model.User, error)
-	createFunc  func(ctx context.Context, user *model.User) error
+	getByIDFunc        func(ctx context.Context, id int64) (*model.User, error)
+	getByUsernameFunc  func(ctx context.Context, username string) (*model.User, error)
+	getByUsernamesFunc func(ctx context.Context, usernames []string) ([]*model.User, error)
+	getByIDsFunc       func(ctx context.Context, ids []int64) ([]*model.User, error)
+	searchFunc         func(ctx context.Context, prefix string, limit int) ([]*model.User, error)
+	createFunc         func(ctx context.Context, user *model.User) error
+	updateProfileFunc  func(ctx context.Context, user *model.User) error
+	softDeleteFunc     func(ctx context.Context, userID int64, deletedAt time.Time) error
+	deletedBeforeFunc  func(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	deleteFunc         func(ctx context.Context, userID int64) error
 }
 
 func (m *mockUserRepo) GetByID(ctx context.Context, id int64) (*model.User, error) {
@@ -16,46 +30,178 @@ func (m *mockUserRepo) GetByID(ctx context.Context, id int64) (*model.User, erro
 	}
 	return nil, nil
 }
+func (m *mockUserRepo) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	if m.getByUsernameFunc != nil {
+		return m.getByUsernameFunc(ctx, username)
+	}
+	return nil, errors.New("user not found")
+}
+func (m *mockUserRepo) GetByUsernames(ctx context.Context, usernames []string) ([]*model.User, error) {
+	if m.getByUsernamesFunc != nil {
+		return m.getByUsernamesFunc(ctx, usernames)
+	}
+	return nil, nil
+}
+func (m *mockUserRepo) GetByIDs(ctx context.Context, ids []int64) ([]*model.User, error) {
+	if m.getByIDsFunc != nil {
+		return m.getByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
+func (m *mockUserRepo) SearchByUsernamePrefix(ctx context.Context, prefix string, limit int) ([]*model.User, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, prefix, limit)
+	}
+	return nil, nil
+}
 func (m *mockUserRepo) Create(ctx context.Context, user *model.User) error {
 	if m.createFunc != nil {
 		return m.createFunc(ctx, user)
 	}
 	return nil
 }
+func (m *mockUserRepo) UpdateProfile(ctx context.Context, user *model.User) error {
+	if m.updateProfileFunc != nil {
+		return m.updateProfileFunc(ctx, user)
+	}
+	return nil
+}
+func (m *mockUserRepo) SoftDelete(ctx context.Context, userID int64, deletedAt time.Time) error {
+	if m.softDeleteFunc != nil {
+		return m.softDeleteFunc(ctx, userID, deletedAt)
+	}
+	return nil
+}
+func (m *mockUserRepo) GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	if m.deletedBeforeFunc != nil {
+		return m.deletedBeforeFunc(ctx, before, limit)
+	}
+	return nil, nil
+}
+func (m *mockUserRepo) Delete(ctx context.Context, userID int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, userID)
+	}
+	return nil
+}
 func (m *mockUserRepo) GetStats(ctx context.Context, userID int64) (*model.UserStats, error) {
 	return nil, nil
 }
 func (m *mockUserRepo) GetAllUsers(ctx context.Context) ([]*model.User, error) { return nil, nil }
 
 type mockTimelineRepo struct {
-	getTimelineFunc func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error)
+	getTimelineFunc        func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error)
+	addToTimelineFunc      func(ctx context.Context, userID int64, tweet *model.TweetWithUser) error
+	removeFromTimelineFunc func(ctx context.Context, userID int64, tweetID int64) error
+	addToMultipleFunc      func(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error
+	removeTweetsFunc       func(ctx context.Context, userIDs []int64, tweetIDs []int64) error
 }
 
-func (m *mockTimelineRepo) GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+func (m *mockTimelineRepo) GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
 	if m.getTimelineFunc != nil {
-		return m.getTimelineFunc(ctx, userID, limit, offset)
+		return m.getTimelineFunc(ctx, userID, page)
 	}
 	return nil, nil
 }
 func (m *mockTimelineRepo) AddToTimeline(ctx context.Context, userID int64, tweet *model.TweetWithUser) error {
+	if m.addToTimelineFunc != nil {
+		return m.addToTimelineFunc(ctx, userID, tweet)
+	}
 	return nil
 }
 func (m *mockTimelineRepo) RemoveFromTimeline(ctx context.Context, userID int64, tweetID int64) error {
+	if m.removeFromTimelineFunc != nil {
+		return m.removeFromTimelineFunc(ctx, userID, tweetID)
+	}
+	return nil
+}
+func (m *mockTimelineRepo) RemoveTweetsFromTimelines(ctx context.Context, userIDs []int64, tweetIDs []int64) error {
+	if m.removeTweetsFunc != nil {
+		return m.removeTweetsFunc(ctx, userIDs, tweetIDs)
+	}
 	return nil
 }
 func (m *mockTimelineRepo) InvalidateTimeline(ctx context.Context, userID int64) error { return nil }
 func (m *mockTimelineRepo) AddToMultipleTimelines(ctx context.Context, followerIDs []int64, tweet *model.TweetWithUser) error {
+	if m.addToMultipleFunc != nil {
+		return m.addToMultipleFunc(ctx, followerIDs, tweet)
+	}
+	return nil
+}
+
+type mockEventRepo struct {
+	publishToUsersFunc  func(ctx context.Context, userIDs []int64, event *model.Event) error
+	publishToAuthorFunc func(ctx context.Context, authorID int64, event *model.Event) error
+	subscribeFunc       func(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error)
+}
+
+func (m *mockEventRepo) PublishToUsers(ctx context.Context, userIDs []int64, event *model.Event) error {
+	if m.publishToUsersFunc != nil {
+		return m.publishToUsersFunc(ctx, userIDs, event)
+	}
+	return nil
+}
+func (m *mockEventRepo) PublishToAuthor(ctx context.Context, authorID int64, event *model.Event) error {
+	if m.publishToAuthorFunc != nil {
+		return m.publishToAuthorFunc(ctx, authorID, event)
+	}
+	return nil
+}
+func (m *mockEventRepo) Subscribe(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+	if m.subscribeFunc != nil {
+		return m.subscribeFunc(ctx, userID, authorIDs)
+	}
+	events := make(chan *model.Event)
+	close(events)
+	return events, nil
+}
+
+type mockTweetCache struct {
+	getManyFunc func(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error)
+	setManyFunc func(ctx context.Context, tweets []*model.TweetWithUser) error
+	deleteFunc  func(ctx context.Context, ids ...int64) error
+}
+
+func (m *mockTweetCache) GetMany(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error) {
+	if m.getManyFunc != nil {
+		return m.getManyFunc(ctx, ids)
+	}
+	return map[int64]*model.TweetWithUser{}, nil
+}
+func (m *mockTweetCache) SetMany(ctx context.Context, tweets []*model.TweetWithUser) error {
+	if m.setManyFunc != nil {
+		return m.setManyFunc(ctx, tweets)
+	}
+	return nil
+}
+func (m *mockTweetCache) Delete(ctx context.Context, ids ...int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, ids...)
+	}
 	return nil
 }
 
 type mockTweetRepo struct {
-	getTimelineFunc func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error)
-	createFunc      func(ctx context.Context, tweet *model.Tweet) error
+	getTimelineFunc  func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	createFunc       func(ctx context.Context, tweet *model.Tweet) error
+	getByIDFunc      func(ctx context.Context, id int64) (*model.TweetWithUser, error)
+	getByIDsFunc     func(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error)
+	getMentionsFunc  func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	getByHashtagFunc func(ctx context.Context, viewerID int64, tag string, page model.PageQuery) ([]*model.TweetWithUser, error)
+	deleteFunc       func(ctx context.Context, id int64) error
+	updateFunc       func(ctx context.Context, tweet *model.Tweet) error
+	getRepliesFunc   func(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error)
+	getRetweetFunc   func(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error)
+	getByUserIDsFunc func(ctx context.Context, userIDs []int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	getByUserIDFunc  func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
+	getIDsByUserFunc func(ctx context.Context, userID int64) ([]int64, error)
+
+	getListTimelineFunc func(ctx context.Context, listID int64, page model.PageQuery) ([]*model.TweetWithUser, error)
 }
 
-func (m *mockTweetRepo) GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+func (m *mockTweetRepo) GetTimeline(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
 	if m.getTimelineFunc != nil {
-		return m.getTimelineFunc(ctx, userID, limit, offset)
+		return m.getTimelineFunc(ctx, userID, page)
 	}
 	return nil, nil
 }
@@ -66,8 +212,609 @@ func (m *mockTweetRepo) Create(ctx context.Context, tweet *model.Tweet) error {
 	return nil
 }
 func (m *mockTweetRepo) GetByID(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+	if m.getByIDFunc != nil {
+		return m.getByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetByIDs(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+	if m.getByIDsFunc != nil {
+		return m.getByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.getByUserIDFunc != nil {
+		return m.getByUserIDFunc(ctx, userID, page)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetIDsByUserID(ctx context.Context, userID int64) ([]int64, error) {
+	if m.getIDsByUserFunc != nil {
+		return m.getIDsByUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetListTimeline(ctx context.Context, listID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.getListTimelineFunc != nil {
+		return m.getListTimelineFunc(ctx, listID, page)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetByUserIDs(ctx context.Context, userIDs []int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.getByUserIDsFunc != nil {
+		return m.getByUserIDsFunc(ctx, userIDs, page)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetMentions(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.getMentionsFunc != nil {
+		return m.getMentionsFunc(ctx, userID, page)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetByHashtag(ctx context.Context, viewerID int64, tag string, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.getByHashtagFunc != nil {
+		return m.getByHashtagFunc(ctx, viewerID, tag, page)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) Delete(ctx context.Context, id int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, id)
+	}
+	return nil
+}
+func (m *mockTweetRepo) Update(ctx context.Context, tweet *model.Tweet) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(ctx, tweet)
+	}
+	return nil
+}
+func (m *mockTweetRepo) GetRevisions(ctx context.Context, tweetID int64) ([]*model.TweetRevision, error) {
+	return nil, nil
+}
+func (m *mockTweetRepo) GetReplies(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+	if m.getRepliesFunc != nil {
+		return m.getRepliesFunc(ctx, viewerID, conversationID, limit, offset)
+	}
+	return nil, nil
+}
+func (m *mockTweetRepo) GetRetweet(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error) {
+	if m.getRetweetFunc != nil {
+		return m.getRetweetFunc(ctx, userID, tweetID)
+	}
+	return nil, errors.New("retweet not found")
+}
+func (m *mockTweetRepo) GetRetweets(ctx context.Context, tweetID int64) ([]*model.TweetWithUser, error) {
+	return nil, nil
+}
+
+type mockLikeRepo struct {
+	createFunc           func(ctx context.Context, like *model.Like) (bool, error)
+	getLikeCountsFunc    func(ctx context.Context, tweetIDs []int64) (map[int64]int64, error)
+	getLikedTweetIDsFunc func(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error)
+	getByUserIDFunc      func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Like, error)
+}
+
+func (m *mockLikeRepo) Create(ctx context.Context, like *model.Like) (bool, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, like)
+	}
+	return true, nil
+}
+func (m *mockLikeRepo) Delete(ctx context.Context, userID, tweetID int64) error { return nil }
+func (m *mockLikeRepo) GetLikers(ctx context.Context, tweetID int64, limit, offset int) ([]*model.User, error) {
 	return nil, nil
 }
-func (m *mockTweetRepo) GetByUserID(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+func (m *mockLikeRepo) GetLikeCounts(ctx context.Context, tweetIDs []int64) (map[int64]int64, error) {
+	if m.getLikeCountsFunc != nil {
+		return m.getLikeCountsFunc(ctx, tweetIDs)
+	}
+	return map[int64]int64{}, nil
+}
+func (m *mockLikeRepo) GetLikedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+	if m.getLikedTweetIDsFunc != nil {
+		return m.getLikedTweetIDsFunc(ctx, userID, tweetIDs)
+	}
+	return map[int64]bool{}, nil
+}
+func (m *mockLikeRepo) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Like, error) {
+	if m.getByUserIDFunc != nil {
+		return m.getByUserIDFunc(ctx, userID, page)
+	}
 	return nil, nil
 }
+
+// mockBookmarkRepo guarda los bookmarks en memoria, en el orden en que se crean
+type mockBookmarkRepo struct {
+	bookmarks       []*model.Bookmark
+	deletedByAuthor [][2]int64
+}
+
+func (m *mockBookmarkRepo) Create(ctx context.Context, bookmark *model.Bookmark) (bool, error) {
+	for _, existing := range m.bookmarks {
+		if existing.UserID == bookmark.UserID && existing.TweetID == bookmark.TweetID {
+			return false, nil
+		}
+	}
+	bookmark.ID = int64(len(m.bookmarks) + 1)
+	m.bookmarks = append(m.bookmarks, bookmark)
+	return true, nil
+}
+func (m *mockBookmarkRepo) Delete(ctx context.Context, userID, tweetID int64) error {
+	for i, bookmark := range m.bookmarks {
+		if bookmark.UserID == userID && bookmark.TweetID == tweetID {
+			m.bookmarks = append(m.bookmarks[:i], m.bookmarks[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("bookmark %w: %d", repository.ErrNotFound, tweetID)
+}
+func (m *mockBookmarkRepo) DeleteByAuthor(ctx context.Context, userID, authorID int64) error {
+	m.deletedByAuthor = append(m.deletedByAuthor, [2]int64{userID, authorID})
+	return nil
+}
+func (m *mockBookmarkRepo) GetByUserID(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Bookmark, error) {
+	var bookmarks []*model.Bookmark
+	for i := len(m.bookmarks) - 1; i >= 0 && len(bookmarks) < page.Limit; i-- {
+		if m.bookmarks[i].UserID == userID {
+			bookmarks = append(bookmarks, m.bookmarks[i])
+		}
+	}
+	return bookmarks, nil
+}
+func (m *mockBookmarkRepo) GetBookmarkedTweetIDs(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+	bookmarked := map[int64]bool{}
+	for _, bookmark := range m.bookmarks {
+		if bookmark.UserID == userID {
+			bookmarked[bookmark.TweetID] = true
+		}
+	}
+	return bookmarked, nil
+}
+
+type mockSessionRepo struct {
+	userIDs map[string]int64
+	revoked []string
+}
+
+func newMockSessionRepo() *mockSessionRepo {
+	return &mockSessionRepo{userIDs: make(map[string]int64)}
+}
+
+func (m *mockSessionRepo) Create(ctx context.Context, userID int64, tokens *model.AuthTokens, accessTTL, refreshTTL time.Duration) error {
+	m.userIDs[tokens.AccessToken] = userID
+	m.userIDs[tokens.RefreshToken] = userID
+	return nil
+}
+func (m *mockSessionRepo) GetUserIDByAccessToken(ctx context.Context, accessToken string) (int64, error) {
+	return m.getUserID(accessToken)
+}
+func (m *mockSessionRepo) RevokeByAccessToken(ctx context.Context, accessToken string) error {
+	delete(m.userIDs, accessToken)
+	m.revoked = append(m.revoked, accessToken)
+	return nil
+}
+func (m *mockSessionRepo) ConsumeRefreshToken(ctx context.Context, refreshToken string) (int64, error) {
+	userID, ok := m.userIDs[refreshToken]
+	if !ok {
+		return 0, fmt.Errorf("session %w", repository.ErrNotFound)
+	}
+	delete(m.userIDs, refreshToken)
+	m.revoked = append(m.revoked, refreshToken)
+	return userID, nil
+}
+func (m *mockSessionRepo) getUserID(token string) (int64, error) {
+	userID, ok := m.userIDs[token]
+	if !ok {
+		return 0, errors.New("session not found")
+	}
+	return userID, nil
+}
+
+type mockAPITokenRepo struct {
+	createFunc func(ctx context.Context, apiToken *model.APIToken, token string) error
+	deleteFunc func(ctx context.Context, userID, tokenID int64) error
+}
+
+func (m *mockAPITokenRepo) Create(ctx context.Context, apiToken *model.APIToken, token string) error {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, apiToken, token)
+	}
+	return nil
+}
+func (m *mockAPITokenRepo) GetByToken(ctx context.Context, token string) (*model.APIToken, error) {
+	return nil, errors.New("api token not found")
+}
+func (m *mockAPITokenRepo) GetByUserID(ctx context.Context, userID int64) ([]*model.APIToken, error) {
+	return nil, nil
+}
+func (m *mockAPITokenRepo) Delete(ctx context.Context, userID, tokenID int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, userID, tokenID)
+	}
+	return nil
+}
+func (m *mockAPITokenRepo) UpdateLastUsed(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	return nil
+}
+
+type mockTrendRepo struct {
+	recordFunc      func(ctx context.Context, hashtags []string, at time.Time) error
+	getTrendingFunc func(ctx context.Context, window model.TrendWindow, now time.Time, limit int) ([]*model.Trend, error)
+}
+
+func (m *mockTrendRepo) Record(ctx context.Context, hashtags []string, at time.Time) error {
+	if m.recordFunc != nil {
+		return m.recordFunc(ctx, hashtags, at)
+	}
+	return nil
+}
+func (m *mockTrendRepo) GetTrending(ctx context.Context, window model.TrendWindow, now time.Time, limit int) ([]*model.Trend, error) {
+	if m.getTrendingFunc != nil {
+		return m.getTrendingFunc(ctx, window, now, limit)
+	}
+	return nil, nil
+}
+
+type mockSearchRepo struct {
+	searchTweetsFunc func(ctx context.Context, viewerID int64, query *model.SearchQuery, page model.PageQuery) ([]*model.TweetWithUser, error)
+}
+
+func (m *mockSearchRepo) SearchTweets(ctx context.Context, viewerID int64, query *model.SearchQuery, page model.PageQuery) ([]*model.TweetWithUser, error) {
+	if m.searchTweetsFunc != nil {
+		return m.searchTweetsFunc(ctx, viewerID, query, page)
+	}
+	return nil, nil
+}
+
+// mockBlockRepo guarda los bloqueos en memoria, como pares {bloqueador, bloqueado}
+type mockBlockRepo struct {
+	blocks map[[2]int64]bool
+}
+
+func newMockBlockRepo(blocks ...[2]int64) *mockBlockRepo {
+	m := &mockBlockRepo{blocks: map[[2]int64]bool{}}
+	for _, block := range blocks {
+		m.blocks[block] = true
+	}
+	return m
+}
+
+func (m *mockBlockRepo) Create(ctx context.Context, block *model.Block) (bool, error) {
+	key := [2]int64{block.BlockerID, block.BlockedID}
+	if m.blocks[key] {
+		return false, nil
+	}
+	m.blocks[key] = true
+	return true, nil
+}
+func (m *mockBlockRepo) Delete(ctx context.Context, blockerID, blockedID int64) error {
+	key := [2]int64{blockerID, blockedID}
+	if !m.blocks[key] {
+		return errors.New("block not found")
+	}
+	delete(m.blocks, key)
+	return nil
+}
+func (m *mockBlockRepo) Exists(ctx context.Context, blockerID, blockedID int64) (bool, error) {
+	return m.blocks[[2]int64{blockerID, blockedID}], nil
+}
+func (m *mockBlockRepo) GetBlockedBetween(ctx context.Context, userID int64, otherIDs []int64) ([]int64, error) {
+	var blocked []int64
+	for _, otherID := range otherIDs {
+		if m.blocks[[2]int64{userID, otherID}] || m.blocks[[2]int64{otherID, userID}] {
+			blocked = append(blocked, otherID)
+		}
+	}
+	return blocked, nil
+}
+func (m *mockBlockRepo) GetBlockerIDs(ctx context.Context, blockedID int64) ([]int64, error) {
+	var blockerIDs []int64
+	for block := range m.blocks {
+		if block[1] == blockedID {
+			blockerIDs = append(blockerIDs, block[0])
+		}
+	}
+	return blockerIDs, nil
+}
+
+// mockMuteRepo guarda las cuentas y palabras silenciadas en memoria
+type mockMuteRepo struct {
+	users  []*model.MutedUser
+	words  []*model.MutedWord
+	nextID int64
+}
+
+func (m *mockMuteRepo) MuteUser(ctx context.Context, mute *model.MutedUser) error {
+	for _, existing := range m.users {
+		if existing.UserID == mute.UserID && existing.MutedUserID == mute.MutedUserID {
+			existing.ExpiresAt = mute.ExpiresAt
+			return nil
+		}
+	}
+	mute.CreatedAt = time.Now()
+	m.users = append(m.users, mute)
+	return nil
+}
+func (m *mockMuteRepo) UnmuteUser(ctx context.Context, userID, mutedUserID int64) error {
+	for i, mute := range m.users {
+		if mute.UserID == userID && mute.MutedUserID == mutedUserID {
+			m.users = append(m.users[:i], m.users[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+func (m *mockMuteRepo) GetMutedUsers(ctx context.Context, userID int64, now time.Time) ([]*model.MutedUser, error) {
+	var mutes []*model.MutedUser
+	for _, mute := range m.users {
+		if mute.UserID == userID && (mute.ExpiresAt == nil || mute.ExpiresAt.After(now)) {
+			mutes = append(mutes, mute)
+		}
+	}
+	return mutes, nil
+}
+func (m *mockMuteRepo) AddWord(ctx context.Context, word *model.MutedWord) (bool, error) {
+	for _, existing := range m.words {
+		if existing.UserID == word.UserID && existing.Phrase == word.Phrase {
+			return false, nil
+		}
+	}
+	m.nextID++
+	word.ID = m.nextID
+	word.CreatedAt = time.Now()
+	m.words = append(m.words, word)
+	return true, nil
+}
+func (m *mockMuteRepo) DeleteWord(ctx context.Context, userID, wordID int64) error {
+	for i, word := range m.words {
+		if word.UserID == userID && word.ID == wordID {
+			m.words = append(m.words[:i], m.words[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+func (m *mockMuteRepo) GetWords(ctx context.Context, userID int64) ([]*model.MutedWord, error) {
+	var words []*model.MutedWord
+	for _, word := range m.words {
+		if word.UserID == userID {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+// mockListRepo guarda las listas, sus miembros y sus suscriptores en memoria. protected indica qué
+// usuarios tienen la cuenta protegida.
+type mockListRepo struct {
+	lists         map[int64]*model.List
+	members       map[int64][]int64
+	subscriptions map[[2]int64]bool
+	protected     map[int64]bool
+	nextID        int64
+}
+
+func newMockListRepo(lists ...*model.List) *mockListRepo {
+	m := &mockListRepo{
+		lists:         make(map[int64]*model.List),
+		members:       make(map[int64][]int64),
+		subscriptions: make(map[[2]int64]bool),
+		protected:     make(map[int64]bool),
+	}
+	for _, list := range lists {
+		m.lists[list.ID] = list
+		m.nextID = max(m.nextID, list.ID)
+	}
+	return m
+}
+
+func (m *mockListRepo) Create(ctx context.Context, list *model.List) error {
+	m.nextID++
+	list.ID = m.nextID
+	m.lists[list.ID] = list
+	return nil
+}
+func (m *mockListRepo) GetByID(ctx context.Context, id int64) (*model.List, error) {
+	list, ok := m.lists[id]
+	if !ok {
+		return nil, fmt.Errorf("list %w: %d", repository.ErrNotFound, id)
+	}
+	copied := *list
+	copied.MemberCount = int64(len(m.members[id]))
+	return &copied, nil
+}
+func (m *mockListRepo) Update(ctx context.Context, list *model.List) error {
+	m.lists[list.ID] = list
+	return nil
+}
+func (m *mockListRepo) Delete(ctx context.Context, id int64) error {
+	if _, ok := m.lists[id]; !ok {
+		return fmt.Errorf("list %w: %d", repository.ErrNotFound, id)
+	}
+	delete(m.lists, id)
+	delete(m.members, id)
+	return nil
+}
+func (m *mockListRepo) GetByOwner(ctx context.Context, ownerID int64, includePrivate bool) ([]*model.List, error) {
+	var lists []*model.List
+	for _, list := range m.lists {
+		if list.OwnerID == ownerID && (includePrivate || !list.Private) {
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+func (m *mockListRepo) GetSubscribed(ctx context.Context, userID int64) ([]*model.List, error) {
+	var lists []*model.List
+	for key := range m.subscriptions {
+		if list := m.lists[key[0]]; key[1] == userID && list != nil && !list.Private {
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+func (m *mockListRepo) AddMember(ctx context.Context, listID, userID int64) (bool, error) {
+	for _, id := range m.members[listID] {
+		if id == userID {
+			return false, nil
+		}
+	}
+	m.members[listID] = append(m.members[listID], userID)
+	return true, nil
+}
+func (m *mockListRepo) RemoveMember(ctx context.Context, listID, userID int64) error {
+	for i, id := range m.members[listID] {
+		if id == userID {
+			m.members[listID] = append(m.members[listID][:i], m.members[listID][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("list member %w: %d", repository.ErrNotFound, userID)
+}
+func (m *mockListRepo) GetMembers(ctx context.Context, listID int64, page model.PageQuery) (*model.UserPage, error) {
+	result := &model.UserPage{}
+	for _, id := range m.members[listID] {
+		result.Users = append(result.Users, &model.User{ID: id})
+	}
+	return result, nil
+}
+func (m *mockListRepo) GetProtectedMemberIDs(ctx context.Context, listID int64) ([]int64, error) {
+	var ids []int64
+	for _, id := range m.members[listID] {
+		if m.protected[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+func (m *mockListRepo) GetListIDsByMember(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	for listID, members := range m.members {
+		for _, id := range members {
+			if id == userID {
+				ids = append(ids, listID)
+			}
+		}
+	}
+	return ids, nil
+}
+func (m *mockListRepo) Subscribe(ctx context.Context, listID, userID int64) (bool, error) {
+	key := [2]int64{listID, userID}
+	if m.subscriptions[key] {
+		return false, nil
+	}
+	m.subscriptions[key] = true
+	return true, nil
+}
+func (m *mockListRepo) Unsubscribe(ctx context.Context, listID, userID int64) error {
+	key := [2]int64{listID, userID}
+	if !m.subscriptions[key] {
+		return fmt.Errorf("list subscription %w: %d", repository.ErrNotFound, listID)
+	}
+	delete(m.subscriptions, key)
+	return nil
+}
+
+// mockDataExportRepo guarda las exportaciones en memoria
+type mockDataExportRepo struct {
+	exports map[int64]*model.DataExport
+	nextID  int64
+}
+
+func newMockDataExportRepo() *mockDataExportRepo {
+	return &mockDataExportRepo{exports: map[int64]*model.DataExport{}}
+}
+
+func (m *mockDataExportRepo) Create(ctx context.Context, export *model.DataExport) error {
+	m.nextID++
+	export.ID = m.nextID
+	export.Status = model.DataExportPending
+	export.CreatedAt = time.Now()
+	m.exports[export.ID] = export
+	return nil
+}
+func (m *mockDataExportRepo) GetByID(ctx context.Context, id int64) (*model.DataExport, error) {
+	export, ok := m.exports[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return export, nil
+}
+func (m *mockDataExportRepo) GetByUserID(ctx context.Context, userID int64) ([]*model.DataExport, error) {
+	var exports []*model.DataExport
+	for id := int64(1); id <= m.nextID; id++ {
+		if export, ok := m.exports[id]; ok && export.UserID == userID {
+			exports = append(exports, export)
+		}
+	}
+	return exports, nil
+}
+func (m *mockDataExportRepo) ClaimPending(ctx context.Context) (*model.DataExport, error) {
+	for id := int64(1); id <= m.nextID; id++ {
+		if export, ok := m.exports[id]; ok && export.Status == model.DataExportPending {
+			export.Status = model.DataExportProcessing
+			return export, nil
+		}
+	}
+	return nil, nil
+}
+func (m *mockDataExportRepo) MarkReady(ctx context.Context, id int64, fileName string, completedAt, expiresAt time.Time) error {
+	export := m.exports[id]
+	export.Status = model.DataExportReady
+	export.FileName = fileName
+	export.CompletedAt = &completedAt
+	export.ExpiresAt = &expiresAt
+	return nil
+}
+func (m *mockDataExportRepo) MarkFailed(ctx context.Context, id int64, reason string, completedAt time.Time) error {
+	export := m.exports[id]
+	export.Status = model.DataExportFailed
+	export.Error = reason
+	export.CompletedAt = &completedAt
+	return nil
+}
+func (m *mockDataExportRepo) GetExpired(ctx context.Context, now time.Time, limit int) ([]*model.DataExport, error) {
+	var exports []*model.DataExport
+	for _, export := range m.exports {
+		if export.ExpiresAt != nil && export.ExpiresAt.Before(now) {
+			exports = append(exports, export)
+		}
+	}
+	return exports, nil
+}
+func (m *mockDataExportRepo) Delete(ctx context.Context, id int64) error {
+	delete(m.exports, id)
+	return nil
+}
+
+// mockExportStorage guarda los archivos en memoria; un archivo existe recién al cerrarlo
+type mockExportStorage struct {
+	files map[string][]byte
+}
+
+func newMockExportStorage() *mockExportStorage {
+	return &mockExportStorage{files: map[string][]byte{}}
+}
+
+func (m *mockExportStorage) Create(name string) (io.WriteCloser, error) {
+	return &mockExportFile{storage: m, name: name}, nil
+}
+func (m *mockExportStorage) Path(name string) string { return "/exports/" + name }
+func (m *mockExportStorage) Delete(name string) error {
+	delete(m.files, name)
+	return nil
+}
+
+type mockExportFile struct {
+	bytes.Buffer
+	storage *mockExportStorage
+	name    string
+}
+
+func (f *mockExportFile) Close() error {
+	f.storage.files[f.name] = f.Bytes()
+	return nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type muteService struct {
+	muteRepo repository.MuteRepository
+	userRepo repository.UserRepository
+}
+
+// NewMuteService crea una nueva instancia del servicio de cuentas y palabras silenciadas
+func NewMuteService(muteRepo repository.MuteRepository, userRepo repository.UserRepository) MuteService {
+	return &muteService{
+		muteRepo: muteRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *muteService) MuteUser(ctx context.Context, userID, mutedUserID int64, expiresAt *time.Time) (*model.MutedUser, error) {
+	if userID == mutedUserID {
+		return nil, fmt.Errorf("%w: user cannot mute themselves", ErrInvalidMute)
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidMute)
+	}
+
+	mutedUser, err := s.userRepo.GetByID(ctx, mutedUserID)
+	if err != nil {
+		return nil, fmt.Errorf("muted user not found: %w", err)
+	}
+
+	mute := &model.MutedUser{
+		UserID:      userID,
+		MutedUserID: mutedUserID,
+		User:        mutedUser,
+		ExpiresAt:   expiresAt,
+	}
+
+	err = s.muteRepo.MuteUser(ctx, mute)
+	if err != nil {
+		return nil, fmt.Errorf("error muting user: %w", err)
+	}
+
+	return mute, nil
+}
+
+func (s *muteService) UnmuteUser(ctx context.Context, userID, mutedUserID int64) error {
+	err := s.muteRepo.UnmuteUser(ctx, userID, mutedUserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrMuteNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error unmuting user: %w", err)
+	}
+
+	return nil
+}
+
+// GetMutedUsers devuelve las cuentas silenciadas que todavía no vencieron
+func (s *muteService) GetMutedUsers(ctx context.Context, userID int64) ([]*model.MutedUser, error) {
+	mutes, err := s.muteRepo.GetMutedUsers(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error getting muted users: %w", err)
+	}
+
+	return mutes, nil
+}
+
+func (s *muteService) MuteWord(ctx context.Context, userID int64, phrase string) (*model.MutedWord, error) {
+	phrase, err := normalizeMutedPhrase(phrase)
+	if err != nil {
+		return nil, err
+	}
+
+	word := &model.MutedWord{UserID: userID, Phrase: phrase}
+	created, err := s.muteRepo.AddWord(ctx, word)
+	if err != nil {
+		return nil, fmt.Errorf("error muting word: %w", err)
+	}
+	if !created {
+		return nil, ErrAlreadyMuted
+	}
+
+	return word, nil
+}
+
+func (s *muteService) UnmuteWord(ctx context.Context, userID, wordID int64) error {
+	err := s.muteRepo.DeleteWord(ctx, userID, wordID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrMuteNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error unmuting word: %w", err)
+	}
+
+	return nil
+}
+
+func (s *muteService) GetMutedWords(ctx context.Context, userID int64) ([]*model.MutedWord, error) {
+	words, err := s.muteRepo.GetWords(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting muted words: %w", err)
+	}
+
+	return words, nil
+}
+
+// normalizeMutedPhrase pasa la frase a minúsculas y reduce los espacios a uno solo entre palabras,
+// para que se compare igual que el texto de los tweets en containsMutedPhrase
+func normalizeMutedPhrase(phrase string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+	if strings.TrimLeft(normalized, "#@") == "" {
+		return "", fmt.Errorf("%w: phrase cannot be empty", ErrInvalidMute)
+	}
+	if utf8.RuneCountInString(normalized) > model.MaxMutedPhraseLength {
+		return "", fmt.Errorf("%w: phrase cannot exceed %d characters", ErrInvalidMute, model.MaxMutedPhraseLength)
+	}
+
+	return normalized, nil
+}
+
+// containsMutedPhrase indica si text (ya en minúsculas y con los espacios normalizados) contiene la
+// frase como palabras completas: "go" coincide con "me gusta go" y con "#go", pero no con "gol";
+// "#go" solo coincide con el hashtag
+func containsMutedPhrase(text, phrase string) bool {
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], phrase)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(phrase)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isMutedWordRune(before)) && (end == len(text) || !isMutedWordRune(after)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+// isMutedWordRune indica si r forma parte de una palabra, para delimitar las coincidencias
+func isMutedWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"testing"
+	"time"
+)
+
+func TestMuteService_MuteUser(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "user"}, nil
+	}}
+
+	t.Run("silenciar con vencimiento", func(t *testing.T) {
+		muteRepo := &mockMuteRepo{}
+		service := NewMuteService(muteRepo, userRepo)
+		expiresAt := time.Now().Add(time.Hour)
+		mute, err := service.MuteUser(ctx, 1, 2, &expiresAt)
+		if err != nil || mute.MutedUserID != 2 || mute.User == nil || len(muteRepo.users) != 1 {
+			t.Errorf("esperaba silenciar la cuenta 2, obtuve err: %v, mute: %+v", err, mute)
+		}
+	})
+
+	t.Run("los silencios vencidos no se listan", func(t *testing.T) {
+		expired := time.Now().Add(-time.Minute)
+		muteRepo := &mockMuteRepo{users: []*model.MutedUser{
+			{UserID: 1, MutedUserID: 2, ExpiresAt: &expired},
+			{UserID: 1, MutedUserID: 3},
+		}}
+		service := NewMuteService(muteRepo, userRepo)
+		mutes, err := service.GetMutedUsers(ctx, 1)
+		if err != nil || len(mutes) != 1 || mutes[0].MutedUserID != 3 {
+			t.Errorf("esperaba solo la cuenta 3, obtuve err: %v, mutes: %+v", err, mutes)
+		}
+	})
+
+	t.Run("vencimiento en el pasado", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, userRepo)
+		past := time.Now().Add(-time.Hour)
+		if _, err := service.MuteUser(ctx, 1, 2, &past); !errors.Is(err, ErrInvalidMute) {
+			t.Errorf("esperaba ErrInvalidMute, obtuve: %v", err)
+		}
+	})
+
+	t.Run("silenciarse a sí mismo", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, userRepo)
+		if _, err := service.MuteUser(ctx, 1, 1, nil); !errors.Is(err, ErrInvalidMute) {
+			t.Errorf("esperaba ErrInvalidMute, obtuve: %v", err)
+		}
+	})
+
+	t.Run("dejar de silenciar una cuenta no silenciada", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, userRepo)
+		if err := service.UnmuteUser(ctx, 1, 2); !errors.Is(err, ErrMuteNotFound) {
+			t.Errorf("esperaba ErrMuteNotFound, obtuve: %v", err)
+		}
+	})
+}
+
+func TestMuteService_MuteWord(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("normaliza la frase", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, &mockUserRepo{})
+		word, err := service.MuteWord(ctx, 1, "  Game   OF Thrones ")
+		if err != nil || word.Phrase != "game of thrones" {
+			t.Errorf("esperaba la frase normalizada, obtuve err: %v, word: %+v", err, word)
+		}
+	})
+
+	t.Run("palabra ya silenciada", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, &mockUserRepo{})
+		if _, err := service.MuteWord(ctx, 1, "spoiler"); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if _, err := service.MuteWord(ctx, 1, "SPOILER"); !errors.Is(err, ErrAlreadyMuted) {
+			t.Errorf("esperaba ErrAlreadyMuted, obtuve: %v", err)
+		}
+	})
+
+	t.Run("frase vacía", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, &mockUserRepo{})
+		for _, phrase := range []string{"   ", "#"} {
+			if _, err := service.MuteWord(ctx, 1, phrase); !errors.Is(err, ErrInvalidMute) {
+				t.Errorf("esperaba ErrInvalidMute para %q, obtuve: %v", phrase, err)
+			}
+		}
+	})
+
+	t.Run("eliminar una palabra inexistente", func(t *testing.T) {
+		service := NewMuteService(&mockMuteRepo{}, &mockUserRepo{})
+		if err := service.UnmuteWord(ctx, 1, 9); !errors.Is(err, ErrMuteNotFound) {
+			t.Errorf("esperaba ErrMuteNotFound, obtuve: %v", err)
+		}
+	})
+}
+
+func TestContainsMutedPhrase(t *testing.T) {
+	cases := []struct {
+		text   string
+		phrase string
+		want   bool
+	}{
+		{"me gusta go", "go", true},
+		{"aprendiendo #go hoy", "go", true},
+		{"qué gol", "go", false},
+		{"aprendiendo #golang", "#go", false},
+		{"aprendiendo #go", "#go", true},
+		{"sin spoilers de game of thrones", "game of thrones", true},
+		{"game of thronesss", "game of thrones", false},
+		{"el año: ñandú", "ñandú", true},
+	}
+	for _, tc := range cases {
+		if got := containsMutedPhrase(tc.text, tc.phrase); got != tc.want {
+			t.Errorf("containsMutedPhrase(%q, %q) = %v, esperaba %v", tc.text, tc.phrase, got, tc.want)
+		}
+	}
+}
+
+func TestTimelineService_GetTimeline_Mutes(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(id int64, userID int64, content string) *model.TweetWithUser {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: userID, Content: content, CreatedAt: base.Add(time.Duration(id) * time.Minute)}}
+	}
+
+	// Del más reciente al más antiguo; la cuenta 4 está silenciada y "spoiler" también
+	tweets := []*model.TweetWithUser{
+		at(6, 2, "hola"),
+		at(5, 4, "de una cuenta silenciada"),
+		at(4, 3, "¡SPOILER del final!"),
+		at(3, 2, "otro tweet"),
+		at(2, 3, "y otro más"),
+		at(1, 2, "el primero"),
+	}
+	muteRepo := &mockMuteRepo{
+		users: []*model.MutedUser{{UserID: 1, MutedUserID: 4}},
+		words: []*model.MutedWord{{ID: 1, UserID: 1, Phrase: "spoiler"}},
+	}
+
+	t.Run("desde MySQL completa la página", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+			return pageOf(tweets, page), nil
+		}}
+		service := NewTimelineService(&mockTimelineRepo{}, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, muteRepo, 0)
+
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(resp, 6, 3, 2) {
+			t.Errorf("esperaba los tweets 6, 3 y 2, obtuve %+v", resp.Tweets)
+		}
+		if resp.NextCursor == nil || resp.NextCursor.ID != 2 {
+			t.Errorf("esperaba el cursor siguiente en el tweet 2, obtuve %+v", resp.NextCursor)
+		}
+	})
+
+	t.Run("desde la caché completa la página", func(t *testing.T) {
+		timelineRepo := &mockTimelineRepo{getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+			return entriesOf(pageOf(tweets, page)), nil
+		}}
+		service := NewTimelineService(timelineRepo, tweetsByID(tweets), &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, muteRepo, 0)
+
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 3})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if !hasTweetIDs(resp, 6, 3, 2) {
+			t.Errorf("esperaba los tweets 6, 3 y 2, obtuve %+v", resp.Tweets)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSearchQueryLength es la longitud máxima de una búsqueda, en caracteres
+const maxSearchQueryLength = 256
+
+// searchDateLayout es el formato de las fechas de los filtros since: y until:
+const searchDateLayout = "2006-01-02"
+
+// searchTokenPattern separa una búsqueda en frases entre comillas y palabras sueltas
+var searchTokenPattern = regexp.MustCompile(`"([^"]*)"|(\S+)`)
+
+// searchUsernamePattern valida el username del filtro from: y el prefijo de la búsqueda de usuarios
+var searchUsernamePattern = regexp.MustCompile(`^\w{1,50}$`)
+
+type searchService struct {
+	searchRepo   repository.SearchRepository
+	userRepo     repository.UserRepository
+	likeRepo     repository.LikeRepository
+	bookmarkRepo repository.BookmarkRepository
+}
+
+// NewSearchService crea una nueva instancia del servicio de búsqueda
+func NewSearchService(
+	searchRepo repository.SearchRepository,
+	userRepo repository.UserRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+) SearchService {
+	return &searchService{
+		searchRepo:   searchRepo,
+		userRepo:     userRepo,
+		likeRepo:     likeRepo,
+		bookmarkRepo: bookmarkRepo,
+	}
+}
+
+func (s *searchService) SearchTweets(ctx context.Context, viewerID int64, q string, page model.PageQuery) (*model.TweetPage, error) {
+	query, err := parseSearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, err := s.searchRepo.SearchTweets(ctx, viewerID, query, page)
+	if err != nil {
+		return nil, fmt.Errorf("error searching tweets: %w", err)
+	}
+
+	responses := toTweetResponses(tweets)
+	applyLikes(ctx, s.likeRepo, viewerID, responses)
+	applyBookmarks(ctx, s.bookmarkRepo, viewerID, responses)
+
+	return newTweetPage(page, tweets, responses), nil
+}
+
+// SearchUsers busca usuarios por prefijo del username, para autocompletar. Acepta el prefijo con o
+// sin @.
+func (s *searchService) SearchUsers(ctx context.Context, q string, limit int) ([]*model.User, error) {
+	prefix := strings.TrimPrefix(strings.TrimSpace(q), "@")
+	if !searchUsernamePattern.MatchString(prefix) {
+		return nil, ErrInvalidSearch
+	}
+
+	users, err := s.userRepo.SearchByUsernamePrefix(ctx, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching users: %w", err)
+	}
+
+	return append([]*model.User{}, users...), nil
+}
+
+// parseSearchQuery interpreta una búsqueda: "frases exactas", palabras sueltas, #hashtags y los
+// filtros from:username, since:AAAA-MM-DD y until:AAAA-MM-DD. Las fechas se toman en UTC y until
+// es exclusivo. Debe quedar al menos algo que buscar además de las fechas.
+func parseSearchQuery(q string) (*model.SearchQuery, error) {
+	q = strings.TrimSpace(q)
+	if q == "" || utf8.RuneCountInString(q) > maxSearchQueryLength {
+		return nil, ErrInvalidSearch
+	}
+
+	query := &model.SearchQuery{}
+	for _, match := range searchTokenPattern.FindAllStringSubmatch(q, -1) {
+		if match[2] == "" {
+			// Frase entre comillas; dentro de ella no se interpretan filtros
+			if words := searchWords(match[1]); len(words) > 0 {
+				query.Phrases = append(query.Phrases, strings.Join(words, " "))
+			}
+			continue
+		}
+
+		token := match[2]
+		operator, value, _ := strings.Cut(token, ":")
+		switch strings.ToLower(operator) {
+		case "from":
+			username := strings.TrimPrefix(value, "@")
+			if !searchUsernamePattern.MatchString(username) {
+				return nil, ErrInvalidSearch
+			}
+			query.From = username
+			continue
+		case "since", "until":
+			date, err := time.ParseInLocation(searchDateLayout, value, time.UTC)
+			if err != nil {
+				return nil, ErrInvalidSearch
+			}
+			if strings.EqualFold(operator, "since") {
+				query.Since = &date
+			} else {
+				query.Until = &date
+			}
+			continue
+		}
+
+		if strings.HasPrefix(token, "#") {
+			if tag := normalizeHashtag(token); hashtagTagPattern.MatchString(tag) {
+				query.Hashtags = append(query.Hashtags, tag)
+				continue
+			}
+		}
+
+		query.Terms = append(query.Terms, searchWords(token)...)
+	}
+
+	if len(query.Terms) == 0 && len(query.Phrases) == 0 && query.From == "" && len(query.Hashtags) == 0 {
+		return nil, ErrInvalidSearch
+	}
+	if query.Since != nil && query.Until != nil && !query.Since.Before(*query.Until) {
+		return nil, ErrInvalidSearch
+	}
+
+	return query, nil
+}
+
+// searchWords separa un texto en palabras formadas por letras, números y guiones bajos, descartando
+// la puntuación y los operadores del índice de búsqueda
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '_'
+	})
+}
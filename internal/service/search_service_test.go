@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"microx/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Run("palabras, frases y filtros", func(t *testing.T) {
+		query, err := parseSearchQuery(`moto "planta de mandarina" from:@Axel #Barrio since:2024-01-01 until:2024-02-01 ¡hola!`)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if strings.Join(query.Terms, ",") != "moto,hola" {
+			t.Errorf("esperaba las palabras moto y hola, obtuve %v", query.Terms)
+		}
+		if len(query.Phrases) != 1 || query.Phrases[0] != "planta de mandarina" {
+			t.Errorf("esperaba la frase, obtuve %v", query.Phrases)
+		}
+		if query.From != "Axel" {
+			t.Errorf("esperaba from Axel, obtuve %q", query.From)
+		}
+		if len(query.Hashtags) != 1 || query.Hashtags[0] != "barrio" {
+			t.Errorf("esperaba el hashtag barrio, obtuve %v", query.Hashtags)
+		}
+		if !query.Since.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || !query.Until.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("fechas inesperadas: %v, %v", query.Since, query.Until)
+		}
+	})
+
+	t.Run("descarta los operadores del índice", func(t *testing.T) {
+		query, err := parseSearchQuery(`+moto -auto* "(hola)"`)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if strings.Join(query.Terms, ",") != "moto,auto" || strings.Join(query.Phrases, ",") != "hola" {
+			t.Errorf("esperaba moto, auto y la frase hola, obtuve %v y %v", query.Terms, query.Phrases)
+		}
+	})
+
+	invalid := map[string]string{
+		"vacía":            "   ",
+		"solo fechas":      "since:2024-01-01",
+		"fecha inválida":   "moto since:ayer",
+		"rango vacío":      "moto since:2024-02-01 until:2024-01-01",
+		"usuario inválido": "from:no-vale",
+		"solo puntuación":  `!!! ""`,
+		"demasiado larga":  strings.Repeat("a", maxSearchQueryLength+1),
+	}
+	for name, q := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseSearchQuery(q); !errors.Is(err, ErrInvalidSearch) {
+				t.Errorf("esperaba ErrInvalidSearch para %q, obtuve: %v", q, err)
+			}
+		})
+	}
+}
+
+func TestSearchService_SearchTweets(t *testing.T) {
+	ctx := context.Background()
+
+	var received *model.SearchQuery
+	searchRepo := &mockSearchRepo{searchTweetsFunc: func(ctx context.Context, viewerID int64, query *model.SearchQuery, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		received = query
+		return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 4, UserID: 1, Content: "me encanta la moto", CreatedAt: time.Now()}}}, nil
+	}}
+	likeRepo := &mockLikeRepo{getLikedTweetIDsFunc: func(ctx context.Context, userID int64, tweetIDs []int64) (map[int64]bool, error) {
+		return map[int64]bool{4: true}, nil
+	}}
+
+	service := NewSearchService(searchRepo, &mockUserRepo{}, likeRepo, nil)
+	page, err := service.SearchTweets(ctx, 2, "moto from:axel", model.PageQuery{Limit: 20})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if received == nil || received.From != "axel" || strings.Join(received.Terms, ",") != "moto" {
+		t.Errorf("consulta inesperada: %+v", received)
+	}
+	if len(page.Tweets) != 1 || !page.Tweets[0].LikedByMe {
+		t.Errorf("esperaba el tweet 4 con liked_by_me, obtuve %+v", page.Tweets)
+	}
+}
+
+func TestSearchService_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("busca por prefijo sin @", func(t *testing.T) {
+		var prefix string
+		userRepo := &mockUserRepo{searchFunc: func(ctx context.Context, p string, limit int) ([]*model.User, error) {
+			prefix = p
+			return []*model.User{{ID: 4, Username: "axel"}}, nil
+		}}
+
+		service := NewSearchService(&mockSearchRepo{}, userRepo, &mockLikeRepo{}, nil)
+		users, err := service.SearchUsers(ctx, " @ax", 10)
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if prefix != "ax" || len(users) != 1 {
+			t.Errorf("esperaba buscar ax, obtuve %q con %+v", prefix, users)
+		}
+	})
+
+	t.Run("sin resultados devuelve una lista vacía", func(t *testing.T) {
+		service := NewSearchService(&mockSearchRepo{}, &mockUserRepo{}, &mockLikeRepo{}, nil)
+		users, err := service.SearchUsers(ctx, "zz", 10)
+		if err != nil || users == nil || len(users) != 0 {
+			t.Errorf("esperaba una lista vacía, obtuve err: %v, users: %+v", err, users)
+		}
+	})
+
+	t.Run("prefijo inválido", func(t *testing.T) {
+		service := NewSearchService(&mockSearchRepo{}, &mockUserRepo{}, &mockLikeRepo{}, nil)
+		for _, q := range []string{"", "@", "a%", "no vale"} {
+			if _, err := service.SearchUsers(ctx, q, 10); !errors.Is(err, ErrInvalidSearch) {
+				t.Errorf("esperaba ErrInvalidSearch para %q, obtuve: %v", q, err)
+			}
+		}
+	})
+}
@@ -5,13 +5,36 @@ import (
 	"fmt"
 	"microx/internal/model"
 	"microx/internal/repository"
+	"sort"
+	"strings"
+	"time"
 )
 
+// streamReplayLimit es la cantidad máxima de tweets perdidos que se reenvían al reconectar un stream
+const streamReplayLimit = 100
+
+// streamDedupSize es la cantidad de tweets enviados que recuerda un stream para no repetirlos. Alcanza
+// para cubrir el reenvío al reconectar y los reintentos del fan-out, sin crecer mientras dure la conexión.
+const streamDedupSize = 2 * streamReplayLimit
+
+// timelineFillRounds es la cantidad máxima de lecturas adicionales con las que se completa una
+// página del timeline cuando el filtro (bloqueos y silenciados) descarta tweets
+const timelineFillRounds = 5
+
 type timelineService struct {
 	timelineRepo repository.TimelineRepository
 	tweetRepo    repository.TweetRepository
 	userRepo     repository.UserRepository
 	followRepo   repository.FollowRepository
+	likeRepo     repository.LikeRepository
+	bookmarkRepo repository.BookmarkRepository
+	eventRepo    repository.EventRepository
+	blockRepo    repository.BlockRepository
+	muteRepo     repository.MuteRepository
+	hydrator     *tweetHydrator
+	// fanoutThreshold es la cantidad de seguidores a partir de la cual los tweets de una
+	// cuenta se mezclan al leer el timeline en lugar de distribuirse al escribir
+	fanoutThreshold int
 }
 
 // NewTimelineService crea una nueva instancia del servicio de timeline
@@ -20,49 +43,238 @@ func NewTimelineService(
 	tweetRepo repository.TweetRepository,
 	userRepo repository.UserRepository,
 	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+	tweetCache repository.TweetCacheRepository,
+	eventRepo repository.EventRepository,
+	blockRepo repository.BlockRepository,
+	muteRepo repository.MuteRepository,
+	fanoutThreshold int,
 ) TimelineService {
 	return &timelineService{
-		timelineRepo: timelineRepo,
-		tweetRepo:    tweetRepo,
-		userRepo:     userRepo,
-		followRepo:   followRepo,
+		timelineRepo:    timelineRepo,
+		tweetRepo:       tweetRepo,
+		userRepo:        userRepo,
+		followRepo:      followRepo,
+		likeRepo:        likeRepo,
+		bookmarkRepo:    bookmarkRepo,
+		eventRepo:       eventRepo,
+		blockRepo:       blockRepo,
+		muteRepo:        muteRepo,
+		hydrator:        newTweetHydrator(tweetCache, tweetRepo),
+		fanoutThreshold: fanoutThreshold,
 	}
 }
 
-func (s *timelineService) GetTimeline(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetResponse, error) {
+func (s *timelineService) GetTimeline(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error) {
 	// Verificar que el usuario existe
 	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
+	filter := loadTimelineFilter(ctx, s.blockRepo, s.muteRepo, userID)
+	read := func(ctx context.Context, page model.PageQuery) (*model.TweetPage, error) {
+		return s.getTimelinePage(ctx, userID, page, filter)
+	}
+
+	result, err := read(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return fillTimelinePage(ctx, page, filter, result, read), nil
+}
+
+// fillTimelinePage completa con las páginas siguientes, leídas con read, una página que quedó corta
+// porque el filtro descartó tweets, con hasta timelineFillRounds lecturas adicionales. Si aun así no
+// se llena, se devuelve corta y con el cursor para continuar.
+func fillTimelinePage(ctx context.Context, page model.PageQuery, filter *timelineFilter, result *model.TweetPage, read func(context.Context, model.PageQuery) (*model.TweetPage, error)) *model.TweetPage {
+	if filter.empty() {
+		return result
+	}
+
+	seen := make(map[int64]bool, len(result.Tweets))
+	for _, tweet := range result.Tweets {
+		seen[tweet.ID] = true
+	}
+
+	for round := 0; round < timelineFillRounds && len(result.Tweets) < page.Limit && result.NextCursor != nil; round++ {
+		next := model.PageQuery{Limit: page.Limit - len(result.Tweets), Before: result.NextCursor, Since: page.Since}
+		more, err := read(ctx, next)
+		if err != nil {
+			fmt.Printf("Warning: error filling timeline page: %v\n", err)
+			break
+		}
+
+		for _, tweet := range more.Tweets {
+			if !seen[tweet.ID] {
+				seen[tweet.ID] = true
+				result.Tweets = append(result.Tweets, tweet)
+			}
+		}
+		result.NextCursor = more.NextCursor
+	}
+
+	return result
+}
+
+// getTimelinePage lee una página del timeline desde la caché o, si no está cacheado, desde MySQL,
+// y le aplica el filtro
+func (s *timelineService) getTimelinePage(ctx context.Context, userID int64, page model.PageQuery, filter *timelineFilter) (*model.TweetPage, error) {
 	// Intentar obtener timeline desde cache (Redis)
-	tweets, err := s.timelineRepo.GetTimeline(ctx, userID, limit, offset)
+	entries, err := s.timelineRepo.GetTimeline(ctx, userID, page)
 	if err != nil {
 		// Si hay error en cache, obtener desde base de datos
 		fmt.Printf("Warning: error getting timeline from cache: %v\n", err)
-		return s.getTimelineFromDatabase(ctx, userID, limit, offset)
+		return s.getTimelineFromDatabase(ctx, userID, page, filter)
 	}
 
 	// Si no hay tweets en cache, obtener desde base de datos
-	if len(tweets) == 0 {
-		return s.getTimelineFromDatabase(ctx, userID, limit, offset)
+	if len(entries) == 0 {
+		return s.getTimelineFromDatabase(ctx, userID, page, filter)
+	}
+
+	// El timeline cacheado solo guarda IDs; el contenido se arma desde la caché de tweets
+	tweetIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		tweetIDs[i] = entry.ID
+	}
+
+	tweets, err := s.hydrator.Hydrate(ctx, tweetIDs)
+	if err != nil {
+		fmt.Printf("Warning: error hydrating timeline: %v\n", err)
+		return s.getTimelineFromDatabase(ctx, userID, page, filter)
 	}
 
+	// El timeline cacheado está recortado (o se reconstruyó solo en parte), así que si no llena
+	// la página se completa desde MySQL a partir del tweet cacheado más antiguo
+	if len(tweets) < page.Limit {
+		older := s.getOlderFromDatabase(ctx, userID, page.Limit-len(tweets), page.Since, entries[len(entries)-1])
+		tweets = append(tweets, older...)
+	}
+
+	tweets = s.mergePulledTweets(ctx, userID, tweets, page)
+
 	// Convertir a respuesta
-	var responses []*model.TweetResponse
-	for _, tweet := range tweets {
-		response := &model.TweetResponse{
-			ID:        tweet.ID,
-			Content:   tweet.Content,
-			UserID:    tweet.UserID,
-			Username:  tweet.Username,
-			CreatedAt: tweet.CreatedAt,
+	result := toTimelinePage(ctx, s.likeRepo, s.bookmarkRepo, userID, page, tweets, filter)
+
+	// Si se borraron tweets de una página completa, la página queda corta pero puede haber más
+	if result.NextCursor == nil && len(entries) >= page.Limit {
+		result.NextCursor = entries[len(entries)-1]
+	}
+
+	return result, nil
+}
+
+func (s *timelineService) StreamTimeline(ctx context.Context, userID int64, since *model.Cursor) (<-chan *model.TweetResponse, error) {
+	if s.eventRepo == nil {
+		return nil, ErrStreamUnavailable
+	}
+
+	// Verificar que el usuario existe
+	_, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Los tweets de las cuentas sobre el umbral no llegan al timeline, se publican en el canal del autor
+	var authorIDs []int64
+	if s.fanoutThreshold > 0 {
+		authorIDs, err = s.followRepo.GetFollowingIDsOverThreshold(ctx, userID, int64(s.fanoutThreshold))
+		if err != nil {
+			fmt.Printf("Warning: error getting high-follower accounts: %v\n", err)
 		}
-		responses = append(responses, response)
 	}
 
-	return responses, nil
+	// Suscribirse antes de reenviar los tweets perdidos para no dejar un hueco entre ambos
+	events, err := s.eventRepo.Subscribe(ctx, userID, authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error subscribing to timeline: %w", err)
+	}
+
+	filter := loadTimelineFilter(ctx, s.blockRepo, s.muteRepo, userID)
+
+	tweets := make(chan *model.TweetResponse)
+	go func() {
+		defer close(tweets)
+
+		// Un mismo tweet puede llegar por el reenvío y por el canal, o repetirse si se reintenta el fan-out.
+		// Solo se recuerdan los últimos streamDedupSize enviados.
+		sent := make(map[int64]bool)
+		var sentOrder []int64
+		send := func(tweet *model.TweetResponse) bool {
+			if sent[tweet.ID] {
+				return true
+			}
+			sent[tweet.ID] = true
+			sentOrder = append(sentOrder, tweet.ID)
+			if len(sentOrder) > streamDedupSize {
+				delete(sent, sentOrder[0])
+				sentOrder = sentOrder[1:]
+			}
+
+			select {
+			case tweets <- tweet:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if since != nil {
+			missed, err := s.GetTimeline(ctx, userID, model.PageQuery{Limit: streamReplayLimit, Since: since})
+			if err != nil {
+				fmt.Printf("Warning: error getting missed timeline tweets: %v\n", err)
+			} else {
+				// Del más antiguo al más reciente, en el mismo orden en que se reciben en vivo
+				for i := len(missed.Tweets) - 1; i >= 0; i-- {
+					if !send(missed.Tweets[i]) {
+						return
+					}
+				}
+			}
+		}
+
+		for event := range events {
+			if event.Channel != model.EventChannelTimeline {
+				continue
+			}
+
+			hydrated, err := s.hydrator.Hydrate(ctx, []int64{event.TweetID})
+			if err != nil {
+				fmt.Printf("Warning: error hydrating tweet %d: %v\n", event.TweetID, err)
+				continue
+			}
+			hydrated = filter.apply(hydrated)
+			if len(hydrated) == 0 {
+				continue // El tweet se borró antes de enviarse, o el filtro lo descarta
+			}
+
+			responses := toTweetResponses(hydrated)
+			applyLikes(ctx, s.likeRepo, userID, responses)
+			applyBookmarks(ctx, s.bookmarkRepo, userID, responses)
+			if !send(responses[0]) {
+				return
+			}
+		}
+	}()
+
+	return tweets, nil
+}
+
+// GetMentions se lee siempre desde la base de datos: las menciones no se distribuyen a los timelines
+func (s *timelineService) GetMentions(ctx context.Context, userID int64, page model.PageQuery) (*model.TweetPage, error) {
+	tweets, err := s.tweetRepo.GetMentions(ctx, userID, page)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mentions: %w", err)
+	}
+
+	responses := toTweetResponses(tweets)
+	applyLikes(ctx, s.likeRepo, userID, responses)
+	applyBookmarks(ctx, s.bookmarkRepo, userID, responses)
+
+	return newTweetPage(page, tweets, responses), nil
 }
 
 func (s *timelineService) RefreshTimeline(ctx context.Context, userID int64) error {
@@ -79,12 +291,13 @@ func (s *timelineService) RefreshTimeline(ctx context.Context, userID int64) err
 	}
 
 	// Obtener timeline desde base de datos
-	tweets, err := s.tweetRepo.GetTimeline(ctx, userID, 100, 0) // Obtener primeros 100 tweets
+	tweets, err := s.tweetRepo.GetTimeline(ctx, userID, model.PageQuery{Limit: 100}) // Obtener primeros 100 tweets
 	if err != nil {
 		return fmt.Errorf("error getting timeline from database: %w", err)
 	}
 
 	// Reconstruir timeline en Redis
+	s.hydrator.Prime(ctx, tweets)
 	for _, tweet := range tweets {
 		err := s.timelineRepo.AddToTimeline(ctx, userID, tweet)
 		if err != nil {
@@ -95,36 +308,77 @@ func (s *timelineService) RefreshTimeline(ctx context.Context, userID int64) err
 	return nil
 }
 
-// getTimelineFromDatabase obtiene el timeline desde la base de datos y lo cachea
-func (s *timelineService) getTimelineFromDatabase(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetResponse, error) {
+// mergePulledTweets mezcla la página del timeline cacheado con la misma página de los tweets de las
+// cuentas seguidas que superan el umbral de seguidores, que no se distribuyen al escribir
+func (s *timelineService) mergePulledTweets(ctx context.Context, userID int64, tweets []*model.TweetWithUser, page model.PageQuery) []*model.TweetWithUser {
+	if s.fanoutThreshold <= 0 {
+		return tweets
+	}
+
+	accountIDs, err := s.followRepo.GetFollowingIDsOverThreshold(ctx, userID, int64(s.fanoutThreshold))
+	if err != nil {
+		fmt.Printf("Warning: error getting high-follower accounts: %v\n", err)
+		return tweets
+	}
+
+	if len(accountIDs) == 0 {
+		return tweets
+	}
+
+	pulled, err := s.tweetRepo.GetByUserIDs(ctx, accountIDs, page)
+	if err != nil {
+		fmt.Printf("Warning: error getting high-follower accounts tweets: %v\n", err)
+		return tweets
+	}
+
+	merged := mergeTweets(tweets, pulled)
+	if len(merged) > page.Limit {
+		merged = merged[:page.Limit]
+	}
+
+	return merged
+}
+
+// getTimelineFromDatabase obtiene el timeline desde la base de datos. Solo se cachea la primera
+// página: una página más antigua dejaría un hueco entre ella y los tweets ya cacheados.
+func (s *timelineService) getTimelineFromDatabase(ctx context.Context, userID int64, page model.PageQuery, filter *timelineFilter) (*model.TweetPage, error) {
 	// Obtener timeline desde base de datos
-	tweets, err := s.tweetRepo.GetTimeline(ctx, userID, limit, offset)
+	tweets, err := s.tweetRepo.GetTimeline(ctx, userID, page)
 	if err != nil {
 		return nil, fmt.Errorf("error getting timeline from database: %w", err)
 	}
 
-	// Cachear tweets en Redis
-	for _, tweet := range tweets {
-		err := s.timelineRepo.AddToTimeline(ctx, userID, tweet)
-		if err != nil {
-			// Continuar con el siguiente tweet en lugar de fallar completamente
-		}
+	if page.Before == nil && page.Since == nil {
+		s.cacheTimeline(ctx, userID, tweets)
 	}
 
 	// Convertir a respuesta
-	var responses []*model.TweetResponse
+	return toTimelinePage(ctx, s.likeRepo, s.bookmarkRepo, userID, page, tweets, filter), nil
+}
+
+// getOlderFromDatabase completa una página desde la base de datos con los tweets anteriores al
+// tweet cacheado más antiguo. Estos sí se cachean, porque continúan justo donde termina la caché.
+func (s *timelineService) getOlderFromDatabase(ctx context.Context, userID int64, limit int, since, oldest *model.Cursor) []*model.TweetWithUser {
+	page := model.PageQuery{Limit: limit, Before: oldest, Since: since}
+	tweets, err := s.tweetRepo.GetTimeline(ctx, userID, page)
+	if err != nil {
+		fmt.Printf("Warning: error getting older timeline tweets from database: %v\n", err)
+		return nil
+	}
+
+	s.cacheTimeline(ctx, userID, tweets)
+	return tweets
+}
+
+// cacheTimeline agrega al timeline cacheado tweets leídos desde la base de datos
+func (s *timelineService) cacheTimeline(ctx context.Context, userID int64, tweets []*model.TweetWithUser) {
+	s.hydrator.Prime(ctx, tweets)
 	for _, tweet := range tweets {
-		response := &model.TweetResponse{
-			ID:        tweet.ID,
-			Content:   tweet.Content,
-			UserID:    tweet.UserID,
-			Username:  tweet.Username,
-			CreatedAt: tweet.CreatedAt,
+		err := s.timelineRepo.AddToTimeline(ctx, userID, tweet)
+		if err != nil {
+			// Continuar con el siguiente tweet en lugar de fallar completamente
 		}
-		responses = append(responses, response)
 	}
-
-	return responses, nil
 }
 
 // PreloadAllTimelines carga todos los timelines de todos los usuarios al iniciar la aplicación
@@ -140,19 +394,28 @@ func (s *timelineService) PreloadAllTimelines(ctx context.Context) error {
 	fmt.Printf("📊 Procesando %d usuarios...\n", len(users))
 
 	for _, user := range users {
+		// Las cuentas que superan el umbral no se distribuyen, se mezclan al leer
+		if s.fanoutThreshold > 0 {
+			count, err := s.followRepo.CountFollowers(ctx, user.ID)
+			if err == nil && count > int64(s.fanoutThreshold) {
+				continue
+			}
+		}
+
 		// Obtener seguidores del usuario
-		followers, err := s.followRepo.GetFollowers(ctx, user.ID, 1000, 0)
+		followersPage, err := s.followRepo.GetFollowers(ctx, user.ID, model.PageQuery{Limit: 1000})
 		if err != nil {
 			fmt.Printf("⚠️ Error getting followers for user %d: %v\n", user.ID, err)
 			continue
 		}
 
+		followers := followersPage.Users
 		if len(followers) == 0 {
 			continue // Usuario sin seguidores
 		}
 
 		// Obtener tweets del usuario
-		tweets, err := s.tweetRepo.GetByUserID(ctx, user.ID, 1000, 0)
+		tweets, err := s.tweetRepo.GetByUserID(ctx, user.ID, model.PageQuery{Limit: 1000})
 		if err != nil {
 			fmt.Printf("⚠️ Error getting tweets for user %d: %v\n", user.ID, err)
 			continue
@@ -181,3 +444,159 @@ func (s *timelineService) PreloadAllTimelines(ctx context.Context) error {
 	fmt.Println("🎉 Pre-carga de timelines completada")
 	return nil
 }
+
+// toTimelinePage convierte una página del timeline en respuestas sin repetidos, sin los tweets que
+// descarta el filtro y con sus likes. Los cursores se calculan sobre los tweets leídos, antes de
+// descartar ninguno.
+func toTimelinePage(ctx context.Context, likeRepo repository.LikeRepository, bookmarkRepo repository.BookmarkRepository, userID int64, page model.PageQuery, tweets []*model.TweetWithUser, filter *timelineFilter) *model.TweetPage {
+	responses := toTweetResponses(uniqueTweets(filter.apply(tweets)))
+	applyLikes(ctx, likeRepo, userID, responses)
+	applyBookmarks(ctx, bookmarkRepo, userID, responses)
+	return newTweetPage(page, tweets, responses)
+}
+
+// timelineFilter descarta del timeline los tweets que el usuario no puede ver (de cuentas que lo
+// bloquearon) o no quiere ver (de cuentas silenciadas o con palabras silenciadas). Los follows se
+// eliminan al bloquear, pero esos tweets todavía pueden llegar como retweets o citas de otros.
+type timelineFilter struct {
+	hiddenUsers map[int64]bool
+	mutedWords  []string
+}
+
+// loadTimelineFilter arma el filtro de los timelines que lee userID. Si falla la lectura de alguna
+// de sus partes, se filtra con el resto.
+func loadTimelineFilter(ctx context.Context, blockRepo repository.BlockRepository, muteRepo repository.MuteRepository, userID int64) *timelineFilter {
+	filter := &timelineFilter{hiddenUsers: make(map[int64]bool)}
+
+	if blockRepo != nil {
+		blockerIDs, err := blockRepo.GetBlockerIDs(ctx, userID)
+		if err != nil {
+			fmt.Printf("Warning: error getting blockers: %v\n", err)
+		}
+		for _, id := range blockerIDs {
+			filter.hiddenUsers[id] = true
+		}
+	}
+
+	if muteRepo != nil {
+		mutes, err := muteRepo.GetMutedUsers(ctx, userID, time.Now())
+		if err != nil {
+			fmt.Printf("Warning: error getting muted users: %v\n", err)
+		}
+		for _, mute := range mutes {
+			filter.hiddenUsers[mute.MutedUserID] = true
+		}
+
+		words, err := muteRepo.GetWords(ctx, userID)
+		if err != nil {
+			fmt.Printf("Warning: error getting muted words: %v\n", err)
+		}
+		for _, word := range words {
+			filter.mutedWords = append(filter.mutedWords, word.Phrase)
+		}
+	}
+
+	return filter
+}
+
+// hideUnfollowed agrega al filtro las cuentas protegidas de protectedIDs que viewerID no sigue, con
+// una sola consulta
+func (f *timelineFilter) hideUnfollowed(ctx context.Context, followRepo repository.FollowRepository, viewerID int64, protectedIDs []int64) error {
+	followed := make(map[int64]bool)
+	if viewerID > 0 && len(protectedIDs) > 0 {
+		followedIDs, err := followRepo.GetFollowedAmong(ctx, viewerID, protectedIDs)
+		if err != nil {
+			return fmt.Errorf("error checking follow relationships: %w", err)
+		}
+		for _, id := range followedIDs {
+			followed[id] = true
+		}
+	}
+
+	for _, id := range protectedIDs {
+		if id != viewerID && !followed[id] {
+			f.hiddenUsers[id] = true
+		}
+	}
+
+	return nil
+}
+
+// empty indica si el filtro no descarta ningún tweet
+func (f *timelineFilter) empty() bool {
+	return len(f.hiddenUsers) == 0 && len(f.mutedWords) == 0
+}
+
+// apply devuelve los tweets que el filtro no descarta
+func (f *timelineFilter) apply(tweets []*model.TweetWithUser) []*model.TweetWithUser {
+	if f.empty() {
+		return tweets
+	}
+
+	visible := make([]*model.TweetWithUser, 0, len(tweets))
+	for _, tweet := range tweets {
+		if !f.hides(tweet) && !f.hides(tweet.RetweetedTweet) && !f.hides(tweet.QuotedTweet) {
+			visible = append(visible, tweet)
+		}
+	}
+	return visible
+}
+
+// hides indica si el tweet es de una cuenta oculta o contiene una palabra silenciada
+func (f *timelineFilter) hides(tweet *model.TweetWithUser) bool {
+	if tweet == nil {
+		return false
+	}
+	if f.hiddenUsers[tweet.UserID] {
+		return true
+	}
+	if len(f.mutedWords) == 0 || tweet.Content == "" {
+		return false
+	}
+
+	content := strings.Join(strings.Fields(strings.ToLower(tweet.Content)), " ")
+	for _, phrase := range f.mutedWords {
+		if containsMutedPhrase(content, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTweets descarta las apariciones repetidas de un mismo tweet original (por ejemplo, cuando
+// varios usuarios seguidos lo retuitearon), conservando la más reciente
+func uniqueTweets(tweets []*model.TweetWithUser) []*model.TweetWithUser {
+	seen := make(map[int64]bool, len(tweets))
+	unique := make([]*model.TweetWithUser, 0, len(tweets))
+	for _, tweet := range tweets {
+		originalID := tweet.OriginalID()
+		if seen[originalID] {
+			continue
+		}
+		seen[originalID] = true
+		unique = append(unique, tweet)
+	}
+	return unique
+}
+
+// mergeTweets combina dos listas de tweets sin repetir ninguno, ordenadas del más reciente al más antiguo
+func mergeTweets(a, b []*model.TweetWithUser) []*model.TweetWithUser {
+	seen := make(map[int64]bool, len(a)+len(b))
+	merged := make([]*model.TweetWithUser, 0, len(a)+len(b))
+	for _, tweet := range append(append([]*model.TweetWithUser{}, a...), b...) {
+		if seen[tweet.ID] {
+			continue
+		}
+		seen[tweet.ID] = true
+		merged = append(merged, tweet)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].CreatedAt.Equal(merged[j].CreatedAt) {
+			return merged[i].CreatedAt.After(merged[j].CreatedAt)
+		}
+		return merged[i].ID > merged[j].ID
+	})
+
+	return merged
+}
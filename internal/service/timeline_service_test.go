@@ -5,6 +5,7 @@ import (
 	"errors"
 	"microx/internal/model"
 	"testing"
+	"time"
 )
 
 func TestTimelineService_GetTimeline(t *testing.T) {
@@ -12,50 +13,325 @@ func TestTimelineService_GetTimeline(t *testing.T) {
 
 	t.Run("éxito desde caché", func(t *testing.T) {
 		timelineRepo := &mockTimelineRepo{
-			getTimelineFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
-				return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 1, Content: "cacheado"}}}, nil
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+				return []*model.Cursor{{ID: 1}}, nil
 			},
 		}
-		service := NewTimelineService(timelineRepo, &mockTweetRepo{}, &mockUserRepo{}, &mockFollowRepo{})
-		resp, err := service.GetTimeline(ctx, 1, 10, 0)
-		if err != nil || len(resp) != 1 || resp[0].ID != 1 {
+		tweetCache := &mockTweetCache{getManyFunc: func(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error) {
+			return map[int64]*model.TweetWithUser{1: {Tweet: model.Tweet{ID: 1, Content: "cacheado"}}}, nil
+		}}
+		tweetRepo := &mockTweetRepo{getByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+			t.Errorf("no esperaba leer tweets desde la base de datos, obtuve %v", ids)
+			return nil, nil
+		}}
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, tweetCache, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10})
+		if err != nil || len(resp.Tweets) != 1 || resp.Tweets[0].Content != "cacheado" {
 			t.Errorf("esperaba éxito desde caché, obtuve err: %v, resp: %+v", err, resp)
 		}
 	})
 
+	t.Run("hidrata desde la base de datos los tweets que no están en caché", func(t *testing.T) {
+		originalID := int64(1)
+		timelineRepo := &mockTimelineRepo{
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+				return []*model.Cursor{{ID: 3}, {ID: 2}}, nil
+			},
+		}
+		var cached []int64
+		tweetCache := &mockTweetCache{
+			getManyFunc: func(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error) {
+				// El tweet original está cacheado con un contenido editado
+				return map[int64]*model.TweetWithUser{1: {Tweet: model.Tweet{ID: 1, Content: "editado"}}}, nil
+			},
+			setManyFunc: func(ctx context.Context, tweets []*model.TweetWithUser) error {
+				for _, tweet := range tweets {
+					if tweet.RetweetedTweet != nil {
+						t.Error("no esperaba cachear el tweet retuiteado embebido")
+					}
+					cached = append(cached, tweet.ID)
+				}
+				return nil
+			},
+		}
+		tweetRepo := &mockTweetRepo{getByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+			// Tweet 2 fue borrado; tweet 3 es un retweet de 1
+			return []*model.TweetWithUser{{
+				Tweet:          model.Tweet{ID: 3, RetweetOfTweetID: &originalID},
+				RetweetedTweet: &model.TweetWithUser{Tweet: model.Tweet{ID: 1, Content: "original"}},
+			}}, nil
+		}}
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, tweetCache, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 2})
+		if err != nil || len(resp.Tweets) != 1 || resp.Tweets[0].RetweetedTweet == nil || resp.Tweets[0].RetweetedTweet.Content != "editado" {
+			t.Fatalf("esperaba el retweet con el original editado, obtuve err: %v, resp: %+v", err, resp)
+		}
+		if len(cached) != 1 || cached[0] != 3 {
+			t.Errorf("esperaba cachear el tweet 3, obtuve %v", cached)
+		}
+		if resp.NextCursor == nil || resp.NextCursor.ID != 2 {
+			t.Errorf("esperaba cursor siguiente en el tweet 2 aunque fue borrado, obtuve %+v", resp.NextCursor)
+		}
+	})
+
+	t.Run("completa desde la base de datos al pasar la ventana cacheada", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		cachedTweets := []*model.TweetWithUser{
+			{Tweet: model.Tweet{ID: 5, CreatedAt: base.Add(5 * time.Minute)}},
+			{Tweet: model.Tweet{ID: 4, CreatedAt: base.Add(4 * time.Minute)}},
+		}
+		var recached []int64
+		timelineRepo := &mockTimelineRepo{
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+				return entriesOf(cachedTweets), nil
+			},
+			addToTimelineFunc: func(ctx context.Context, userID int64, tweet *model.TweetWithUser) error {
+				recached = append(recached, tweet.ID)
+				return nil
+			},
+		}
+		tweetRepo := tweetsByID(cachedTweets)
+		tweetRepo.getTimelineFunc = func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+			if page.Before == nil || page.Before.ID != 4 || page.Limit != 2 {
+				t.Errorf("esperaba leer 2 tweets anteriores al 4, obtuve %+v", page)
+			}
+			return []*model.TweetWithUser{
+				{Tweet: model.Tweet{ID: 3, CreatedAt: base.Add(3 * time.Minute)}},
+				{Tweet: model.Tweet{ID: 2, CreatedAt: base.Add(2 * time.Minute)}},
+			}, nil
+		}
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 4})
+		if err != nil || !hasTweetIDs(resp, 5, 4, 3, 2) || resp.NextCursor == nil || resp.NextCursor.ID != 2 {
+			t.Errorf("esperaba tweets 5, 4, 3, 2 con cursor siguiente, obtuve err: %v, resp: %+v", err, resp)
+		}
+		if len(recached) != 2 {
+			t.Errorf("esperaba cachear los 2 tweets leídos desde la base de datos, obtuve %v", recached)
+		}
+	})
+
+	t.Run("una página antigua desde la base de datos no se cachea", func(t *testing.T) {
+		timelineRepo := &mockTimelineRepo{
+			addToTimelineFunc: func(ctx context.Context, userID int64, tweet *model.TweetWithUser) error {
+				t.Error("no esperaba cachear una página que no es la primera")
+				return nil
+			},
+		}
+		tweetRepo := &mockTweetRepo{
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+				return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 1}}}, nil
+			},
+		}
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10, Before: &model.Cursor{ID: 50}})
+		if err != nil || !hasTweetIDs(resp, 1) {
+			t.Errorf("esperaba el tweet 1 desde la base de datos, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
 	t.Run("fallback a base de datos", func(t *testing.T) {
 		timelineRepo := &mockTimelineRepo{
-			getTimelineFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
 				return nil, errors.New("fallo cache")
 			},
 		}
 		tweetRepo := &mockTweetRepo{
-			getTimelineFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
 				return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 2, Content: "db"}}}, nil
 			},
 		}
-		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{})
-		resp, err := service.GetTimeline(ctx, 1, 10, 0)
-		if err != nil || len(resp) != 1 || resp[0].ID != 2 {
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10})
+		if err != nil || len(resp.Tweets) != 1 || resp.Tweets[0].ID != 2 {
 			t.Errorf("esperaba fallback a base de datos, obtuve err: %v, resp: %+v", err, resp)
 		}
 	})
 
+	t.Run("retweets del mismo tweet aparecen una sola vez", func(t *testing.T) {
+		originalID := int64(1)
+		timeline := []*model.TweetWithUser{
+			{Tweet: model.Tweet{ID: 3, UserID: 4, RetweetOfTweetID: &originalID}},
+			{Tweet: model.Tweet{ID: 2, UserID: 5, RetweetOfTweetID: &originalID}},
+			{Tweet: model.Tweet{ID: 1, UserID: 6}},
+		}
+		timelineRepo := &mockTimelineRepo{
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+				return entriesOf(timeline), nil
+			},
+		}
+		service := NewTimelineService(timelineRepo, tweetsByID(timeline), &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10})
+		if err != nil || len(resp.Tweets) != 1 || resp.Tweets[0].ID != 3 {
+			t.Errorf("esperaba un único tweet (el retweet más reciente), obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
 	t.Run("error total", func(t *testing.T) {
 		timelineRepo := &mockTimelineRepo{
-			getTimelineFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
 				return nil, errors.New("fallo cache")
 			},
 		}
 		tweetRepo := &mockTweetRepo{
-			getTimelineFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+			getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
 				return nil, errors.New("fallo db")
 			},
 		}
-		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{})
-		_, err := service.GetTimeline(ctx, 1, 10, 0)
+		service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		_, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 10})
 		if err == nil {
 			t.Error("esperaba error total")
 		}
 	})
 }
+
+func TestTimelineService_GetTimeline_Hybrid(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(id int64, minutes int, userID int64) *model.TweetWithUser {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: userID, CreatedAt: base.Add(time.Duration(minutes) * time.Minute)}}
+	}
+
+	// Timeline cacheado (push) y tweets de una cuenta sobre el umbral (pull)
+	cached := []*model.TweetWithUser{at(5, 50, 2), at(3, 30, 2), at(1, 10, 2)}
+	timelineRepo := &mockTimelineRepo{
+		getTimelineFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.Cursor, error) {
+			return entriesOf(pageOf(cached, page)), nil
+		},
+	}
+	tweetRepo := tweetsByID(cached)
+	tweetRepo.getByUserIDsFunc = func(ctx context.Context, userIDs []int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		if len(userIDs) != 1 || userIDs[0] != 9 {
+			t.Errorf("esperaba leer la cuenta 9, obtuve %v", userIDs)
+		}
+		return pageOf([]*model.TweetWithUser{at(6, 60, 9), at(4, 40, 9), at(2, 20, 9)}, page), nil
+	}
+	followRepo := &mockFollowRepo{
+		getFollowingIDsOverThresholdFunc: func(ctx context.Context, userID int64, minFollowers int64) ([]int64, error) {
+			return []int64{9}, nil
+		},
+	}
+	service := NewTimelineService(timelineRepo, tweetRepo, &mockUserRepo{}, followRepo, nil, nil, nil, nil, nil, nil, 100)
+
+	var next *model.Cursor
+	t.Run("mezcla ordenada en la primera página", func(t *testing.T) {
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 3})
+		if err != nil || !hasTweetIDs(resp, 6, 5, 4) || resp.NextCursor == nil {
+			t.Fatalf("esperaba tweets 6, 5, 4 con cursor siguiente, obtuve err: %v, resp: %+v", err, resp)
+		}
+		next = resp.NextCursor
+	})
+
+	t.Run("mezcla ordenada en la segunda página", func(t *testing.T) {
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 3, Before: next})
+		if err != nil || !hasTweetIDs(resp, 3, 2, 1) {
+			t.Errorf("esperaba tweets 3, 2, 1, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
+	t.Run("solo los tweets nuevos desde un cursor", func(t *testing.T) {
+		resp, err := service.GetTimeline(ctx, 1, model.PageQuery{Limit: 3, Since: model.NewCursor(at(4, 40, 9).CreatedAt, 4)})
+		if err != nil || !hasTweetIDs(resp, 6, 5) || resp.NextCursor != nil {
+			t.Errorf("esperaba tweets 6, 5 sin cursor siguiente, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+}
+
+// pageOf simula la paginación por cursor de los repositorios sobre una lista ya ordenada
+func pageOf(tweets []*model.TweetWithUser, page model.PageQuery) []*model.TweetWithUser {
+	var result []*model.TweetWithUser
+	for _, tweet := range tweets {
+		if len(result) == page.Limit {
+			break
+		}
+		if page.Contains(model.NewCursor(tweet.CreatedAt, tweet.ID)) {
+			result = append(result, tweet)
+		}
+	}
+	return result
+}
+
+// entriesOf devuelve las entradas que guarda el timeline cacheado para los tweets dados
+func entriesOf(tweets []*model.TweetWithUser) []*model.Cursor {
+	entries := make([]*model.Cursor, len(tweets))
+	for i, tweet := range tweets {
+		entries[i] = model.NewCursor(tweet.CreatedAt, tweet.ID)
+	}
+	return entries
+}
+
+// tweetsByID devuelve un repositorio cuyo GetByIDs responde con los tweets dados
+func tweetsByID(tweets []*model.TweetWithUser) *mockTweetRepo {
+	return &mockTweetRepo{getByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+		var found []*model.TweetWithUser
+		for _, tweet := range tweets {
+			for _, id := range ids {
+				if tweet.ID == id {
+					found = append(found, tweet)
+				}
+			}
+		}
+		return found, nil
+	}}
+}
+
+func hasTweetIDs(page *model.TweetPage, ids ...int64) bool {
+	if page == nil || len(page.Tweets) != len(ids) {
+		return false
+	}
+	for i, id := range ids {
+		if page.Tweets[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTimelineService_StreamTimeline(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tweet := func(id int64) *model.TweetWithUser {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, CreatedAt: base.Add(time.Duration(id) * time.Minute)}}
+	}
+
+	t.Run("reenvía lo perdido y luego los tweets en vivo sin repetir", func(t *testing.T) {
+		live := make(chan *model.Event, 3)
+		live <- &model.Event{Channel: model.EventChannelTimeline, TweetID: 3, CreatedAt: tweet(3).CreatedAt}
+		live <- &model.Event{Channel: model.EventChannelNotifications, TweetID: 5, CreatedAt: tweet(5).CreatedAt}
+		live <- &model.Event{Channel: model.EventChannelTimeline, TweetID: 4, CreatedAt: tweet(4).CreatedAt}
+		close(live)
+		events := &mockEventRepo{subscribeFunc: func(ctx context.Context, userID int64, authorIDs []int64) (<-chan *model.Event, error) {
+			return live, nil
+		}}
+
+		tweetRepo := tweetsByID([]*model.TweetWithUser{tweet(2), tweet(3), tweet(4), tweet(5)})
+		tweetRepo.getTimelineFunc = func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+			if page.Since == nil || page.Since.ID != 1 {
+				t.Errorf("esperaba reenviar desde el tweet 1, obtuve %+v", page.Since)
+			}
+			return []*model.TweetWithUser{tweet(3), tweet(2)}, nil
+		}
+		service := NewTimelineService(&mockTimelineRepo{}, tweetRepo, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, events, nil, nil, 0)
+
+		stream, err := service.StreamTimeline(ctx, 1, model.NewCursor(tweet(1).CreatedAt, 1))
+		if err != nil {
+			t.Fatalf("esperaba éxito, obtuve %v", err)
+		}
+
+		var ids []int64
+		for received := range stream {
+			ids = append(ids, received.ID)
+		}
+		if len(ids) != 3 || ids[0] != 2 || ids[1] != 3 || ids[2] != 4 {
+			t.Errorf("esperaba tweets 2, 3, 4, obtuve %v", ids)
+		}
+	})
+
+	t.Run("sin canal de eventos", func(t *testing.T) {
+		service := NewTimelineService(&mockTimelineRepo{}, &mockTweetRepo{}, &mockUserRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, 0)
+		_, err := service.StreamTimeline(ctx, 1, nil)
+		if !errors.Is(err, ErrStreamUnavailable) {
+			t.Errorf("esperaba ErrStreamUnavailable, obtuve %v", err)
+		}
+	})
+}
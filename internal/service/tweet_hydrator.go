@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"microx/internal/model"
+	"microx/internal/repository"
+)
+
+// tweetHydrator arma tweets completos a partir de sus IDs. Primero los busca en la caché de
+// tweets y solo lee desde MySQL, en una única consulta, los que no estén cacheados.
+//
+// En la caché cada tweet se guarda sin el tweet que retuitea o cita; esa referencia se resuelve
+// al hidratar con el mismo mecanismo. Así, editar un tweet solo requiere invalidar su propia
+// clave para que el cambio se vea también en sus retweets y citas.
+type tweetHydrator struct {
+	cache     repository.TweetCacheRepository
+	tweetRepo repository.TweetRepository
+}
+
+func newTweetHydrator(cache repository.TweetCacheRepository, tweetRepo repository.TweetRepository) *tweetHydrator {
+	return &tweetHydrator{cache: cache, tweetRepo: tweetRepo}
+}
+
+// Hydrate devuelve los tweets en el mismo orden que ids, omitiendo los que ya no existen
+func (h *tweetHydrator) Hydrate(ctx context.Context, ids []int64) ([]*model.TweetWithUser, error) {
+	found, err := h.load(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolver los tweets retuiteados o citados que no se cargaron en la primera pasada
+	var referencedIDs []int64
+	for _, tweet := range found {
+		for _, refID := range []*int64{tweet.RetweetOfTweetID, tweet.QuotedTweetID} {
+			if refID != nil && found[*refID] == nil {
+				referencedIDs = append(referencedIDs, *refID)
+			}
+		}
+	}
+
+	referenced, err := h.load(ctx, referencedIDs)
+	if err != nil {
+		return nil, err
+	}
+	for id, tweet := range referenced {
+		found[id] = tweet
+	}
+
+	tweets := make([]*model.TweetWithUser, 0, len(ids))
+	for _, id := range ids {
+		cached, ok := found[id]
+		if !ok {
+			continue
+		}
+
+		tweet := *cached
+		if tweet.RetweetOfTweetID != nil {
+			tweet.RetweetedTweet = found[*tweet.RetweetOfTweetID]
+		}
+		if tweet.QuotedTweetID != nil {
+			tweet.QuotedTweet = found[*tweet.QuotedTweetID]
+		}
+		tweets = append(tweets, &tweet)
+	}
+
+	return tweets, nil
+}
+
+// Prime cachea tweets ya leídos desde la base de datos para las próximas hidrataciones
+func (h *tweetHydrator) Prime(ctx context.Context, tweets []*model.TweetWithUser) {
+	if h.cache == nil {
+		return
+	}
+
+	if err := h.cache.SetMany(ctx, withoutReferences(tweets)); err != nil {
+		fmt.Printf("Warning: error caching tweets: %v\n", err)
+	}
+}
+
+// Invalidate elimina tweets de la caché, por ejemplo al editarlos o borrarlos
+func (h *tweetHydrator) Invalidate(ctx context.Context, ids ...int64) {
+	if h.cache == nil {
+		return
+	}
+
+	if err := h.cache.Delete(ctx, ids...); err != nil {
+		fmt.Printf("Warning: error invalidating cached tweets: %v\n", err)
+	}
+}
+
+// load obtiene los tweets indexados por ID, desde la caché o, si faltan, desde la base de datos
+func (h *tweetHydrator) load(ctx context.Context, ids []int64) (map[int64]*model.TweetWithUser, error) {
+	found := make(map[int64]*model.TweetWithUser, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	if h.cache != nil {
+		cached, err := h.cache.GetMany(ctx, ids)
+		if err != nil {
+			// Si la caché falla se leen todos los tweets desde la base de datos
+			fmt.Printf("Warning: error getting cached tweets: %v\n", err)
+		}
+		for id, tweet := range cached {
+			found[id] = tweet
+		}
+	}
+
+	var missing []int64
+	for _, id := range ids {
+		if found[id] == nil {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return found, nil
+	}
+
+	tweets, err := h.tweetRepo.GetByIDs(ctx, missing)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweets: %w", err)
+	}
+
+	tweets = withoutReferences(tweets)
+	for _, tweet := range tweets {
+		found[tweet.ID] = tweet
+	}
+	h.Prime(ctx, tweets)
+
+	return found, nil
+}
+
+// withoutReferences copia los tweets sin el tweet retuiteado o citado embebido
+func withoutReferences(tweets []*model.TweetWithUser) []*model.TweetWithUser {
+	result := make([]*model.TweetWithUser, 0, len(tweets))
+	for _, tweet := range tweets {
+		copied := *tweet
+		copied.RetweetedTweet = nil
+		copied.QuotedTweet = nil
+		result = append(result, &copied)
+	}
+	return result
+}
@@ -2,18 +2,32 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"microx/internal/model"
 	"microx/internal/repository"
 	"strings"
+	"time"
 )
 
+// followerBatchSize es la cantidad de seguidores que se leen por página al recorrer
+// todos los seguidores de un usuario
+const followerBatchSize = 1000
+
 type tweetService struct {
 	tweetRepo    repository.TweetRepository
 	userRepo     repository.UserRepository
 	timelineRepo repository.TimelineRepository
 	followRepo   repository.FollowRepository
+	likeRepo     repository.LikeRepository
+	bookmarkRepo repository.BookmarkRepository
+	hydrator     *tweetHydrator
+	eventRepo    repository.EventRepository
+	trendRepo    repository.TrendRepository
+	blockRepo    repository.BlockRepository
+	fanout       FanoutService
 	maxLength    int
+	editWindow   time.Duration
 }
 
 func NewTweetService(
@@ -21,128 +35,685 @@ func NewTweetService(
 	userRepo repository.UserRepository,
 	timelineRepo repository.TimelineRepository,
 	followRepo repository.FollowRepository,
+	likeRepo repository.LikeRepository,
+	bookmarkRepo repository.BookmarkRepository,
+	tweetCache repository.TweetCacheRepository,
+	eventRepo repository.EventRepository,
+	trendRepo repository.TrendRepository,
+	blockRepo repository.BlockRepository,
+	fanout FanoutService,
 	maxLength int,
+	editWindow time.Duration,
 ) TweetService {
 	return &tweetService{
 		tweetRepo:    tweetRepo,
 		userRepo:     userRepo,
 		timelineRepo: timelineRepo,
 		followRepo:   followRepo,
+		likeRepo:     likeRepo,
+		bookmarkRepo: bookmarkRepo,
+		hydrator:     newTweetHydrator(tweetCache, tweetRepo),
+		eventRepo:    eventRepo,
+		trendRepo:    trendRepo,
+		blockRepo:    blockRepo,
+		fanout:       fanout,
 		maxLength:    maxLength,
+		editWindow:   editWindow,
 	}
 }
 
 // Métodos con receiver (s *tweetService)
 func (s *tweetService) CreateTweet(ctx context.Context, userID int64, content string) (*model.TweetResponse, error) {
-	// Validar contenido
-	content = strings.TrimSpace(content)
-	if content == "" {
-		return nil, fmt.Errorf("tweet content cannot be empty")
+	return s.createTweet(ctx, userID, content, nil, nil)
+}
+
+func (s *tweetService) ReplyToTweet(ctx context.Context, userID, inReplyToTweetID int64, content string) (*model.TweetResponse, error) {
+	// Verificar que el tweet respondido existe
+	parent, err := s.tweetRepo.GetByID(ctx, inReplyToTweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting replied tweet: %w", err)
 	}
 
-	if len(content) > s.maxLength {
-		return nil, fmt.Errorf("tweet content exceeds maximum length of %d characters", s.maxLength)
+	// Solo se puede responder a los tweets que el usuario puede ver
+	author, err := s.userRepo.GetByID(ctx, parent.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, userID, author); err != nil {
+		return nil, err
 	}
 
-	// Verificar que el usuario existe
-	user, err := s.userRepo.GetByID(ctx, userID)
+	return s.createTweet(ctx, userID, content, parent, nil)
+}
+
+func (s *tweetService) QuoteTweet(ctx context.Context, userID, quotedTweetID int64, content string) (*model.TweetResponse, error) {
+	quoted, err := s.getShareableTweet(ctx, userID, quotedTweetID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, err
+	}
+
+	return s.createTweet(ctx, userID, content, nil, quoted)
+}
+
+func (s *tweetService) Retweet(ctx context.Context, userID, tweetID int64) (*model.TweetResponse, error) {
+	original, err := s.getShareableTweet(ctx, userID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar que el usuario no lo haya retuiteado antes
+	if _, err := s.tweetRepo.GetRetweet(ctx, userID, original.ID); err == nil {
+		return nil, ErrAlreadyRetweeted
+	}
+
+	// Un retweet no tiene contenido propio, solo referencia al original
+	tweet := &model.TweetWithUser{
+		Tweet: model.Tweet{
+			UserID:           userID,
+			RetweetOfTweetID: &original.ID,
+		},
+		RetweetedTweet: original,
+	}
+
+	err = s.publishTweet(ctx, tweet)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, original.UserID, model.EventTypeRetweet, tweet)
+
+	return toTweetResponse(tweet), nil
+}
+
+func (s *tweetService) Unretweet(ctx context.Context, userID, tweetID int64) error {
+	retweet, err := s.tweetRepo.GetRetweet(ctx, userID, tweetID)
+	if err != nil {
+		return fmt.Errorf("error getting retweet: %w", err)
+	}
+
+	err = s.tweetRepo.Delete(ctx, retweet.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting retweet: %w", err)
+	}
+	s.hydrator.Invalidate(ctx, retweet.ID)
+
+	// Remover el retweet de los timelines de los seguidores
+	if s.timelineRepo != nil {
+		err = s.removeFromFollowerTimelines(ctx, userID, retweet.ID)
+		if err != nil {
+			fmt.Printf("Warning: error removing from timelines: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// createTweet crea un tweet, que puede ser una respuesta (parent) o una cita (quoted), y lo distribuye a los seguidores
+func (s *tweetService) createTweet(ctx context.Context, userID int64, content string, parent, quoted *model.TweetWithUser) (*model.TweetResponse, error) {
+	// Validar contenido
+	content, err := s.validateContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, err := resolveMentions(ctx, s.userRepo, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// No se puede responder ni mencionar a un usuario con el que hay un bloqueo
+	counterparts := mentionedUserIDs(mentions)
+	if parent != nil {
+		counterparts = append(counterparts, parent.UserID)
+	}
+	if err := checkNotBlocked(ctx, s.blockRepo, userID, counterparts...); err != nil {
+		return nil, err
 	}
 
 	// Crear el tweet
-	tweet := &model.Tweet{
-		UserID:  userID,
-		Content: content,
+	tweet := &model.TweetWithUser{
+		Tweet: model.Tweet{
+			UserID:   userID,
+			Content:  content,
+			Mentions: mentions,
+			Hashtags: hashtagTags(content),
+		},
+	}
+
+	// Una respuesta pertenece a la conversación del tweet respondido
+	if parent != nil {
+		conversationID := parent.ID
+		if parent.ConversationID != nil {
+			conversationID = *parent.ConversationID
+		}
+		tweet.InReplyToTweetID = &parent.ID
+		tweet.ConversationID = &conversationID
+	}
+
+	if quoted != nil {
+		tweet.QuotedTweetID = &quoted.ID
+		tweet.QuotedTweet = quoted
 	}
 
-	err = s.tweetRepo.Create(ctx, tweet)
+	err = s.publishTweet(ctx, tweet)
 	if err != nil {
-		return nil, fmt.Errorf("error creating tweet: %w", err)
+		return nil, err
 	}
 
-	// Crear tweet con información del usuario para el timeline
-	tweetWithUser := &model.TweetWithUser{
-		Tweet:    *tweet,
-		Username: user.Username,
+	s.notifyMentions(ctx, tweet, mentionedUserIDs(mentions))
+	s.recordTrends(ctx, tweet.Hashtags, tweet.CreatedAt)
+
+	// Avisar al autor del tweet respondido o citado
+	if parent != nil {
+		s.notify(ctx, parent.UserID, model.EventTypeReply, tweet)
 	}
+	if quoted != nil {
+		s.notify(ctx, quoted.UserID, model.EventTypeQuote, tweet)
+	}
+
+	// Crear respuesta
+	return toTweetResponse(tweet), nil
+}
 
-	// Obtener seguidores del usuario
-	followers, err := s.followRepo.GetFollowers(ctx, userID, 1000, 0) // Obtener todos los seguidores
+// publishTweet persiste un tweet y encola su distribución a los timelines de los seguidores del autor
+func (s *tweetService) publishTweet(ctx context.Context, tweet *model.TweetWithUser) error {
+	// Verificar que el usuario existe
+	user, err := s.userRepo.GetByID(ctx, tweet.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting followers: %w", err)
+		return fmt.Errorf("user not found: %w", err)
 	}
 
-	// Extraer IDs de seguidores
-	var followerIDs []int64
-	for _, follower := range followers {
-		followerIDs = append(followerIDs, follower.ID)
+	err = s.tweetRepo.Create(ctx, &tweet.Tweet)
+	if err != nil {
+		return fmt.Errorf("error creating tweet: %w", err)
 	}
 
-	// Agregar tweet a los timelines de los seguidores (solo si TimelineRepo está disponible)
-	if s.timelineRepo != nil && len(followerIDs) > 0 {
-		err = s.timelineRepo.AddToMultipleTimelines(ctx, followerIDs, tweetWithUser)
+	// Completar información del usuario para el timeline
+	tweet.Username = user.Username
+	tweet.DisplayName = user.DisplayName
+	tweet.AvatarURL = user.AvatarURL
+
+	// La distribución a los timelines de los seguidores la realiza el servicio de fan-out
+	if s.fanout != nil {
+		err = s.fanout.Dispatch(ctx, tweet)
 		if err != nil {
-			fmt.Printf("Warning: error adding to timelines: %v\n", err)
+			fmt.Printf("Warning: error dispatching fanout for tweet %d: %v\n", tweet.ID, err)
 		}
 	}
 
-	// Crear respuesta
-	response := &model.TweetResponse{
-		ID:        tweet.ID,
-		Content:   tweet.Content,
-		UserID:    tweet.UserID,
-		Username:  user.Username,
+	return nil
+}
+
+// notify avisa a un usuario en su canal de notificaciones que otro interactuó con uno de sus tweets
+func (s *tweetService) notify(ctx context.Context, userID int64, eventType string, tweet *model.TweetWithUser) {
+	publishEvent(ctx, s.eventRepo, userID, &model.Event{
+		Channel:   model.EventChannelNotifications,
+		Type:      eventType,
+		ActorID:   tweet.UserID,
+		TweetID:   tweet.ID,
 		CreatedAt: tweet.CreatedAt,
+	})
+}
+
+// notifyMentions avisa en su canal de menciones a los usuarios dados que el tweet los menciona
+func (s *tweetService) notifyMentions(ctx context.Context, tweet *model.TweetWithUser, userIDs []int64) {
+	for _, userID := range userIDs {
+		publishEvent(ctx, s.eventRepo, userID, &model.Event{
+			Channel:   model.EventChannelMentions,
+			Type:      model.EventTypeMention,
+			ActorID:   tweet.UserID,
+			TweetID:   tweet.ID,
+			CreatedAt: tweet.CreatedAt,
+		})
+	}
+}
+
+// recordTrends suma los usos de hashtags a las tendencias. Es best-effort: un error solo se registra.
+func (s *tweetService) recordTrends(ctx context.Context, hashtags []string, at time.Time) {
+	if s.trendRepo == nil || len(hashtags) == 0 {
+		return
 	}
 
-	return response, nil
+	if err := s.trendRepo.Record(ctx, hashtags, at); err != nil {
+		fmt.Printf("Warning: error recording trends: %v\n", err)
+	}
+}
+
+// getOriginalTweet obtiene un tweet para retuitearlo o citarlo. Si es un retweet se usa el tweet original.
+func (s *tweetService) getOriginalTweet(ctx context.Context, tweetID int64) (*model.TweetWithUser, error) {
+	tweet, err := s.tweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	if tweet.RetweetOfTweetID != nil {
+		tweet, err = s.tweetRepo.GetByID(ctx, *tweet.RetweetOfTweetID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting original tweet: %w", err)
+		}
+	}
+
+	return tweet, nil
 }
 
-func (s *tweetService) GetTweet(ctx context.Context, tweetID int64) (*model.TweetResponse, error) {
+// getShareableTweet obtiene el tweet original que userID quiere retuitear o citar. Los tweets de
+// cuentas protegidas no se pueden compartir, ni siquiera sus seguidores: el fan-out los llevaría
+// a los timelines de usuarios que no los pueden ver.
+func (s *tweetService) getShareableTweet(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error) {
+	tweet, err := s.getOriginalTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tweet.UserID != userID {
+		if err := checkNotBlocked(ctx, s.blockRepo, userID, tweet.UserID); err != nil {
+			return nil, err
+		}
+
+		author, err := s.userRepo.GetByID(ctx, tweet.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting tweet author: %w", err)
+		}
+		if author.Protected {
+			return nil, ErrProtectedAccount
+		}
+	}
+
+	return tweet, nil
+}
+
+// checkCanView devuelve ErrBlocked si author bloqueó a viewerID, o ErrProtectedAccount si viewerID
+// no puede ver los tweets de author
+func checkCanView(ctx context.Context, blockRepo repository.BlockRepository, followRepo repository.FollowRepository, viewerID int64, author *model.User) error {
+	if blockRepo != nil && viewerID > 0 && viewerID != author.ID {
+		blocked, err := blockRepo.Exists(ctx, author.ID, viewerID)
+		if err != nil {
+			return fmt.Errorf("error checking block: %w", err)
+		}
+		if blocked {
+			return ErrBlocked
+		}
+	}
+
+	allowed, err := canViewTweets(ctx, followRepo, viewerID, author)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrProtectedAccount
+	}
+	return nil
+}
+
+func (s *tweetService) GetTweet(ctx context.Context, viewerID, tweetID int64) (*model.TweetResponse, error) {
 	// Obtener tweet con información del usuario (JOIN optimizado)
 	tweetWithUser, err := s.tweetRepo.GetByID(ctx, tweetID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting tweet: %w", err)
 	}
 
-	// Crear respuesta directamente desde TweetWithUser
-	response := &model.TweetResponse{
-		ID:        tweetWithUser.ID,
-		Content:   tweetWithUser.Content,
-		UserID:    tweetWithUser.UserID,
-		Username:  tweetWithUser.Username,
-		CreatedAt: tweetWithUser.CreatedAt,
+	author, err := s.userRepo.GetByID(ctx, tweetWithUser.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, viewerID, author); err != nil {
+		return nil, err
 	}
 
+	// Crear respuesta directamente desde TweetWithUser
+	response := toTweetResponse(tweetWithUser)
+	applyLikes(ctx, s.likeRepo, viewerID, []*model.TweetResponse{response})
+	applyBookmarks(ctx, s.bookmarkRepo, viewerID, []*model.TweetResponse{response})
+
 	return response, nil
 }
 
-func (s *tweetService) GetUserTweets(ctx context.Context, userID int64, limit, offset int) ([]*model.TweetResponse, error) {
-	// Verificar que el usuario existe
-	_, err := s.userRepo.GetByID(ctx, userID)
+func (s *tweetService) GetThread(ctx context.Context, viewerID, tweetID int64, limit, offset int) (*model.ThreadResponse, error) {
+	tweet, err := s.tweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	// Resolver el tweet raíz de la conversación. Si la raíz se borró, sus respuestas conservan el
+	// conversation_id y el hilo se devuelve sin raíz.
+	root := tweet
+	conversationID := tweet.ID
+	if tweet.ConversationID != nil {
+		conversationID = *tweet.ConversationID
+		root, err = s.tweetRepo.GetByID(ctx, conversationID)
+		if errors.Is(err, repository.ErrNotFound) {
+			root = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error getting conversation root: %w", err)
+		}
+	}
+
+	// La conversación se ve solo si se puede ver su raíz, o el tweet pedido si la raíz ya no
+	// existe; las respuestas se filtran por autor
+	visibleTweet := tweet
+	if root != nil {
+		visibleTweet = root
+	}
+	author, err := s.userRepo.GetByID(ctx, visibleTweet.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, viewerID, author); err != nil {
+		return nil, err
+	}
+
+	replies, err := s.tweetRepo.GetReplies(ctx, viewerID, conversationID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting thread replies: %w", err)
+	}
+
+	responses := toTweetResponses(replies)
+	if responses == nil {
+		responses = []*model.TweetResponse{}
+	}
+
+	var rootResponse *model.TweetResponse
+	annotated := responses
+	if root != nil {
+		rootResponse = toTweetResponse(root)
+		annotated = append([]*model.TweetResponse{rootResponse}, responses...)
+	}
+	applyLikes(ctx, s.likeRepo, viewerID, annotated)
+	applyBookmarks(ctx, s.bookmarkRepo, viewerID, annotated)
+
+	return &model.ThreadResponse{
+		Root:    rootResponse,
+		Replies: responses,
+	}, nil
+}
+
+func (s *tweetService) GetUserTweets(ctx context.Context, viewerID, userID int64, page model.PageQuery) (*model.TweetPage, error) {
+	// Verificar que el usuario existe y que el visitante puede ver sus tweets
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, viewerID, user); err != nil {
+		return nil, err
+	}
 
 	// Obtener tweets del usuario con información del usuario (JOIN optimizado)
-	tweetsWithUser, err := s.tweetRepo.GetByUserID(ctx, userID, limit, offset)
+	tweetsWithUser, err := s.tweetRepo.GetByUserID(ctx, userID, page)
 	if err != nil {
 		return nil, fmt.Errorf("error getting user tweets: %w", err)
 	}
 
 	// Convertir a respuesta directamente desde TweetWithUser
-	var responses []*model.TweetResponse
-	for _, tweetWithUser := range tweetsWithUser {
-		response := &model.TweetResponse{
-			ID:        tweetWithUser.ID,
-			Content:   tweetWithUser.Content,
-			UserID:    tweetWithUser.UserID,
-			Username:  tweetWithUser.Username,
-			CreatedAt: tweetWithUser.CreatedAt,
+	responses := toTweetResponses(tweetsWithUser)
+	applyLikes(ctx, s.likeRepo, viewerID, responses)
+	applyBookmarks(ctx, s.bookmarkRepo, viewerID, responses)
+
+	return newTweetPage(page, tweetsWithUser, responses), nil
+}
+
+func (s *tweetService) DeleteTweet(ctx context.Context, userID, tweetID int64) error {
+	// Verificar que el tweet existe
+	tweet, err := s.tweetRepo.GetByID(ctx, tweetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTweetNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	// Solo el autor puede eliminar el tweet
+	if tweet.UserID != userID {
+		return ErrNotTweetAuthor
+	}
+
+	// Los retweets se eliminan en cascada, por lo que hay que obtenerlos antes de borrar
+	retweets, err := s.tweetRepo.GetRetweets(ctx, tweetID)
+	if err != nil {
+		return fmt.Errorf("error getting retweets: %w", err)
+	}
+
+	err = s.tweetRepo.Delete(ctx, tweetID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTweetNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting tweet: %w", err)
+	}
+
+	deletedIDs := []int64{tweetID}
+	for _, retweet := range retweets {
+		deletedIDs = append(deletedIDs, retweet.ID)
+	}
+	s.hydrator.Invalidate(ctx, deletedIDs...)
+
+	// Remover el tweet y sus retweets de los timelines de los seguidores
+	if s.timelineRepo != nil {
+		err = s.removeFromFollowerTimelines(ctx, userID, tweetID)
+		if err != nil {
+			fmt.Printf("Warning: error removing from timelines: %v\n", err)
+		}
+
+		for _, retweet := range retweets {
+			err = s.removeFromFollowerTimelines(ctx, retweet.UserID, retweet.ID)
+			if err != nil {
+				fmt.Printf("Warning: error removing retweet from timelines: %v\n", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *tweetService) UpdateTweet(ctx context.Context, userID, tweetID int64, content string) (*model.TweetResponse, error) {
+	// Validar contenido
+	content, err := s.validateContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar que el tweet existe
+	tweetWithUser, err := s.tweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	// Solo el autor puede editar el tweet, y solo dentro de la ventana de edición
+	if tweetWithUser.UserID != userID {
+		return nil, ErrNotTweetAuthor
+	}
+
+	if tweetWithUser.RetweetOfTweetID != nil {
+		return nil, fmt.Errorf("retweets cannot be edited")
+	}
+
+	if time.Since(tweetWithUser.CreatedAt) > s.editWindow {
+		return nil, ErrEditWindowExpired
+	}
+
+	// Si el contenido no cambió no se genera una nueva revisión
+	if tweetWithUser.Content == content {
+		return toTweetResponse(tweetWithUser), nil
+	}
+
+	mentions, err := resolveMentions(ctx, s.userRepo, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo se avisa a quienes no estaban mencionados antes de la edición
+	previouslyMentioned := make(map[int64]bool)
+	for _, userID := range mentionedUserIDs(tweetWithUser.Mentions) {
+		previouslyMentioned[userID] = true
+	}
+	var newlyMentioned []int64
+	for _, userID := range mentionedUserIDs(mentions) {
+		if !previouslyMentioned[userID] {
+			newlyMentioned = append(newlyMentioned, userID)
+		}
+	}
+	if err := checkNotBlocked(ctx, s.blockRepo, userID, newlyMentioned...); err != nil {
+		return nil, err
+	}
+
+	// Tampoco se vuelven a sumar a las tendencias los hashtags que ya tenía
+	previousTags := make(map[string]bool)
+	for _, tag := range hashtagTags(tweetWithUser.Content) {
+		previousTags[tag] = true
+	}
+	hashtags := hashtagTags(content)
+	var newTags []string
+	for _, tag := range hashtags {
+		if !previousTags[tag] {
+			newTags = append(newTags, tag)
 		}
-		responses = append(responses, response)
 	}
 
-	return responses, nil
+	tweetWithUser.Content = content
+	tweetWithUser.Mentions = mentions
+	tweetWithUser.Hashtags = hashtags
+	err = s.tweetRepo.Update(ctx, &tweetWithUser.Tweet)
+	if err != nil {
+		return nil, fmt.Errorf("error updating tweet: %w", err)
+	}
+
+	s.notifyMentions(ctx, tweetWithUser, newlyMentioned)
+	s.recordTrends(ctx, newTags, time.Now())
+
+	// Los timelines solo guardan el ID, así que basta con invalidar la copia cacheada del tweet
+	// para que la edición se vea en todos ellos (y en sus retweets y citas)
+	s.hydrator.Invalidate(ctx, tweetID)
+
+	return toTweetResponse(tweetWithUser), nil
+}
+
+func (s *tweetService) GetTweetHistory(ctx context.Context, viewerID, tweetID int64) ([]*model.TweetRevision, error) {
+	// Verificar que el tweet existe y que el visitante puede ver sus tweets
+	tweet, err := s.tweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet: %w", err)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, tweet.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet author: %w", err)
+	}
+	if err := checkCanView(ctx, s.blockRepo, s.followRepo, viewerID, author); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.tweetRepo.GetRevisions(ctx, tweetID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tweet history: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// validateContent normaliza el contenido de un tweet y valida su longitud
+func (s *tweetService) validateContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", fmt.Errorf("tweet content cannot be empty")
+	}
+
+	if len(content) > s.maxLength {
+		return "", fmt.Errorf("tweet content exceeds maximum length of %d characters", s.maxLength)
+	}
+
+	return content, nil
+}
+
+// removeFromFollowerTimelines remueve un tweet del timeline de todos los seguidores del autor
+func (s *tweetService) removeFromFollowerTimelines(ctx context.Context, authorID, tweetID int64) error {
+	followerIDs, err := s.getAllFollowerIDs(ctx, authorID)
+	if err != nil {
+		return err
+	}
+
+	for _, followerID := range followerIDs {
+		err = s.timelineRepo.RemoveFromTimeline(ctx, followerID, tweetID)
+		if err != nil {
+			return fmt.Errorf("error removing tweet from timeline: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getAllFollowerIDs obtiene los IDs de todos los seguidores de un usuario paginando en lotes
+func (s *tweetService) getAllFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var followerIDs []int64
+	page := model.PageQuery{Limit: followerBatchSize}
+	for {
+		followers, err := s.followRepo.GetFollowers(ctx, userID, page)
+		if err != nil {
+			return nil, fmt.Errorf("error getting followers: %w", err)
+		}
+
+		for _, follower := range followers.Users {
+			followerIDs = append(followerIDs, follower.ID)
+		}
+
+		if followers.NextCursor == nil {
+			break
+		}
+		page.Before = followers.NextCursor
+	}
+
+	return followerIDs, nil
+}
+
+// newTweetPage arma una página a partir de los tweets leídos y sus respuestas. Una página
+// completa indica que puede haber más tweets, así que se incluye el cursor para continuar.
+func newTweetPage(page model.PageQuery, tweets []*model.TweetWithUser, responses []*model.TweetResponse) *model.TweetPage {
+	result := &model.TweetPage{
+		Tweets:      responses,
+		SinceCursor: page.Since,
+	}
+
+	if len(tweets) > 0 {
+		result.SinceCursor = model.NewCursor(tweets[0].CreatedAt, tweets[0].ID)
+		if len(tweets) >= page.Limit {
+			last := tweets[len(tweets)-1]
+			result.NextCursor = model.NewCursor(last.CreatedAt, last.ID)
+		}
+	}
+
+	return result
+}
+
+// toTweetResponse convierte un tweet con información del usuario en su respuesta
+func toTweetResponse(tweet *model.TweetWithUser) *model.TweetResponse {
+	if tweet == nil {
+		return nil
+	}
+
+	return &model.TweetResponse{
+		ID:       tweet.ID,
+		Content:  tweet.Content,
+		UserID:   tweet.UserID,
+		Username: tweet.Username,
+		User: &model.UserSummary{
+			ID:          tweet.UserID,
+			Username:    tweet.Username,
+			DisplayName: tweet.DisplayName,
+			AvatarURL:   tweet.AvatarURL,
+		},
+		InReplyToTweetID: tweet.InReplyToTweetID,
+		ConversationID:   tweet.ConversationID,
+		RetweetedTweet:   toTweetResponse(tweet.RetweetedTweet),
+		QuotedTweet:      toTweetResponse(tweet.QuotedTweet),
+		Entities:         toTweetEntities(tweet),
+		CreatedAt:        tweet.CreatedAt,
+		UpdatedAt:        tweet.UpdatedAt,
+	}
+}
+
+// toTweetResponses convierte una lista de tweets con información del usuario en respuestas
+func toTweetResponses(tweets []*model.TweetWithUser) []*model.TweetResponse {
+	var responses []*model.TweetResponse
+	for _, tweet := range tweets {
+		responses = append(responses, toTweetResponse(tweet))
+	}
+	return responses
 }
@@ -3,26 +3,105 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"microx/internal/model"
+	"microx/internal/repository"
 	"testing"
+	"time"
 )
 
 type mockFollowRepo struct {
-	getFollowersFunc func(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error)
+	getFollowersFunc                 func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	getFollowingFunc                 func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error)
+	countFollowersFunc               func(ctx context.Context, userID int64) (int64, error)
+	getFollowingIDsOverThresholdFunc func(ctx context.Context, userID int64, minFollowers int64) ([]int64, error)
+	getFollowedAmongFunc             func(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error)
+	createFunc                       func(ctx context.Context, follow *model.Follow) error
+	deleteFunc                       func(ctx context.Context, followerID, followingID int64) error
+	existsFunc                       func(ctx context.Context, followerID, followingID int64) (bool, error)
+	createRequestFunc                func(ctx context.Context, requesterID, targetID int64) error
+	requestExistsFunc                func(ctx context.Context, requesterID, targetID int64) (bool, error)
+	deleteRequestFunc                func(ctx context.Context, requesterID, targetID int64) error
+	getRequestsFunc                  func(ctx context.Context, targetID int64, page model.PageQuery) (*model.UserPage, error)
+	approveRequestFunc               func(ctx context.Context, requesterID, targetID int64) error
 }
 
-func (m *mockFollowRepo) GetFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+func (m *mockFollowRepo) GetFollowers(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
 	if m.getFollowersFunc != nil {
-		return m.getFollowersFunc(ctx, userID, limit, offset)
+		return m.getFollowersFunc(ctx, userID, page)
 	}
-	return []*model.User{}, nil
+	return &model.UserPage{}, nil
+}
+func (m *mockFollowRepo) Create(ctx context.Context, follow *model.Follow) error {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, follow)
+	}
+	return nil
+}
+func (m *mockFollowRepo) Delete(ctx context.Context, followerID, followingID int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, followerID, followingID)
+	}
+	return nil
 }
-func (m *mockFollowRepo) Create(ctx context.Context, follow *model.Follow) error          { return nil }
-func (m *mockFollowRepo) Delete(ctx context.Context, followerID, followingID int64) error { return nil }
 func (m *mockFollowRepo) Exists(ctx context.Context, followerID, followingID int64) (bool, error) {
+	if m.existsFunc != nil {
+		return m.existsFunc(ctx, followerID, followingID)
+	}
+	return false, nil
+}
+func (m *mockFollowRepo) CreateRequest(ctx context.Context, requesterID, targetID int64) error {
+	if m.createRequestFunc != nil {
+		return m.createRequestFunc(ctx, requesterID, targetID)
+	}
+	return nil
+}
+func (m *mockFollowRepo) RequestExists(ctx context.Context, requesterID, targetID int64) (bool, error) {
+	if m.requestExistsFunc != nil {
+		return m.requestExistsFunc(ctx, requesterID, targetID)
+	}
 	return false, nil
 }
-func (m *mockFollowRepo) GetFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
+func (m *mockFollowRepo) DeleteRequest(ctx context.Context, requesterID, targetID int64) error {
+	if m.deleteRequestFunc != nil {
+		return m.deleteRequestFunc(ctx, requesterID, targetID)
+	}
+	return nil
+}
+func (m *mockFollowRepo) GetRequests(ctx context.Context, targetID int64, page model.PageQuery) (*model.UserPage, error) {
+	if m.getRequestsFunc != nil {
+		return m.getRequestsFunc(ctx, targetID, page)
+	}
+	return &model.UserPage{}, nil
+}
+func (m *mockFollowRepo) ApproveRequest(ctx context.Context, requesterID, targetID int64) error {
+	if m.approveRequestFunc != nil {
+		return m.approveRequestFunc(ctx, requesterID, targetID)
+	}
+	return nil
+}
+func (m *mockFollowRepo) GetFollowing(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+	if m.getFollowingFunc != nil {
+		return m.getFollowingFunc(ctx, userID, page)
+	}
+	return &model.UserPage{}, nil
+}
+func (m *mockFollowRepo) CountFollowers(ctx context.Context, userID int64) (int64, error) {
+	if m.countFollowersFunc != nil {
+		return m.countFollowersFunc(ctx, userID)
+	}
+	return 0, nil
+}
+func (m *mockFollowRepo) GetFollowingIDsOverThreshold(ctx context.Context, userID int64, minFollowers int64) ([]int64, error) {
+	if m.getFollowingIDsOverThresholdFunc != nil {
+		return m.getFollowingIDsOverThresholdFunc(ctx, userID, minFollowers)
+	}
+	return nil, nil
+}
+func (m *mockFollowRepo) GetFollowedAmong(ctx context.Context, followerID int64, userIDs []int64) ([]int64, error) {
+	if m.getFollowedAmongFunc != nil {
+		return m.getFollowedAmongFunc(ctx, followerID, userIDs)
+	}
 	return nil, nil
 }
 
@@ -36,10 +115,10 @@ func TestTweetService_CreateTweet(t *testing.T) {
 			return &model.User{ID: id, Username: "testuser"}, nil
 		}}
 		timelineRepo := &mockTimelineRepo{}
-		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, limit, offset int) ([]*model.User, error) {
-			return []*model.User{{ID: 2}}, nil
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
 		}}
-		service := NewTweetService(tweetRepo, userRepo, timelineRepo, followRepo, maxLen)
+		service := NewTweetService(tweetRepo, userRepo, timelineRepo, followRepo, nil, nil, nil, nil, nil, nil, nil, maxLen, time.Hour)
 		resp, err := service.CreateTweet(ctx, 1, "hola")
 		if err != nil || resp.Content != "hola" || resp.UserID != 1 {
 			t.Errorf("esperaba creación exitosa, obtuve err: %v, resp: %+v", err, resp)
@@ -47,7 +126,7 @@ func TestTweetService_CreateTweet(t *testing.T) {
 	})
 
 	t.Run("contenido vacío", func(t *testing.T) {
-		service := NewTweetService(&mockTweetRepo{}, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, maxLen)
+		service := NewTweetService(&mockTweetRepo{}, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, maxLen, time.Hour)
 		_, err := service.CreateTweet(ctx, 1, "   ")
 		if err == nil {
 			t.Error("esperaba error por contenido vacío")
@@ -55,7 +134,7 @@ func TestTweetService_CreateTweet(t *testing.T) {
 	})
 
 	t.Run("contenido demasiado largo", func(t *testing.T) {
-		service := NewTweetService(&mockTweetRepo{}, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, maxLen)
+		service := NewTweetService(&mockTweetRepo{}, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, maxLen, time.Hour)
 		_, err := service.CreateTweet(ctx, 1, "demasiado largo!")
 		if err == nil {
 			t.Error("esperaba error por contenido largo")
@@ -66,7 +145,7 @@ func TestTweetService_CreateTweet(t *testing.T) {
 		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
 			return nil, errors.New("no existe")
 		}}
-		service := NewTweetService(&mockTweetRepo{}, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, maxLen)
+		service := NewTweetService(&mockTweetRepo{}, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, maxLen, time.Hour)
 		_, err := service.CreateTweet(ctx, 1, "hola")
 		if err == nil {
 			t.Error("esperaba error por usuario no existe")
@@ -78,10 +157,391 @@ func TestTweetService_CreateTweet(t *testing.T) {
 		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
 			return &model.User{ID: id, Username: "testuser"}, nil
 		}}
-		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, maxLen)
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, maxLen, time.Hour)
 		_, err := service.CreateTweet(ctx, 1, "hola")
 		if err == nil || err.Error() != "error creating tweet: fallo repo" {
 			t.Errorf("esperaba error del repo, obtuve: %v", err)
 		}
 	})
 }
+
+func TestTweetService_DeleteTweet(t *testing.T) {
+	ctx := context.Background()
+	getByID := func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 1}}, nil
+	}
+
+	t.Run("eliminación exitosa remueve de timelines", func(t *testing.T) {
+		deleted := false
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID,
+			deleteFunc:  func(ctx context.Context, id int64) error { deleted = true; return nil },
+		}
+		var removedFrom []int64
+		timelineRepo := &mockTimelineRepo{removeFromTimelineFunc: func(ctx context.Context, userID int64, tweetID int64) error {
+			removedFrom = append(removedFrom, userID)
+			return nil
+		}}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 2}, {ID: 3}}}, nil
+		}}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, timelineRepo, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		err := service.DeleteTweet(ctx, 1, 10)
+		if err != nil || !deleted || len(removedFrom) != 2 {
+			t.Errorf("esperaba eliminación exitosa, obtuve err: %v, deleted: %v, removidos: %v", err, deleted, removedFrom)
+		}
+	})
+
+	t.Run("usuario no es el autor", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID,
+			deleteFunc: func(ctx context.Context, id int64) error {
+				t.Error("no debería eliminar el tweet")
+				return nil
+			},
+		}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		err := service.DeleteTweet(ctx, 2, 10)
+		if !errors.Is(err, ErrNotTweetAuthor) {
+			t.Errorf("esperaba ErrNotTweetAuthor, obtuve: %v", err)
+		}
+	})
+
+	t.Run("tweet no existe", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return nil, fmt.Errorf("tweet %w: %d", repository.ErrNotFound, id)
+		}}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		err := service.DeleteTweet(ctx, 1, 10)
+		if !errors.Is(err, ErrTweetNotFound) {
+			t.Errorf("esperaba ErrTweetNotFound, obtuve: %v", err)
+		}
+	})
+
+	t.Run("error de la base de datos", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return nil, errors.New("conexión perdida")
+		}}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		err := service.DeleteTweet(ctx, 1, 10)
+		if err == nil || errors.Is(err, ErrTweetNotFound) {
+			t.Errorf("esperaba un error distinto de ErrTweetNotFound, obtuve: %v", err)
+		}
+	})
+}
+
+func TestTweetService_UpdateTweet(t *testing.T) {
+	ctx := context.Background()
+	getByID := func(createdAt time.Time) func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 1, Content: "original", CreatedAt: createdAt}}, nil
+		}
+	}
+	followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+		return &model.UserPage{Users: []*model.User{{ID: 2}}}, nil
+	}}
+
+	t.Run("edición exitosa invalida el tweet cacheado", func(t *testing.T) {
+		var updated string
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID(time.Now()),
+			updateFunc:  func(ctx context.Context, tweet *model.Tweet) error { updated = tweet.Content; return nil },
+		}
+		var invalidated []int64
+		tweetCache := &mockTweetCache{deleteFunc: func(ctx context.Context, ids ...int64) error {
+			invalidated = append(invalidated, ids...)
+			return nil
+		}}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, followRepo, nil, nil, tweetCache, nil, nil, nil, nil, 280, time.Hour)
+		resp, err := service.UpdateTweet(ctx, 1, 10, " editado ")
+		if err != nil || resp.Content != "editado" || updated != "editado" || len(invalidated) != 1 || invalidated[0] != 10 {
+			t.Errorf("esperaba edición exitosa, obtuve err: %v, resp: %+v, invalidados: %v", err, resp, invalidated)
+		}
+	})
+
+	t.Run("ventana de edición vencida", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: getByID(time.Now().Add(-2 * time.Hour))}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.UpdateTweet(ctx, 1, 10, "editado")
+		if !errors.Is(err, ErrEditWindowExpired) {
+			t.Errorf("esperaba ErrEditWindowExpired, obtuve: %v", err)
+		}
+	})
+
+	t.Run("usuario no es el autor", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{getByIDFunc: getByID(time.Now())}
+		service := NewTweetService(tweetRepo, &mockUserRepo{}, &mockTimelineRepo{}, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.UpdateTweet(ctx, 2, 10, "editado")
+		if !errors.Is(err, ErrNotTweetAuthor) {
+			t.Errorf("esperaba ErrNotTweetAuthor, obtuve: %v", err)
+		}
+	})
+}
+
+func TestTweetService_ReplyToTweet(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "testuser"}, nil
+	}}
+
+	t.Run("respuesta hereda la conversación del tweet respondido", func(t *testing.T) {
+		rootID := int64(5)
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2, ConversationID: &rootID}}, nil
+			},
+		}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		resp, err := service.ReplyToTweet(ctx, 1, 7, "respuesta")
+		if err != nil || resp.InReplyToTweetID == nil || *resp.InReplyToTweetID != 7 || resp.ConversationID == nil || *resp.ConversationID != 5 {
+			t.Errorf("esperaba respuesta en la conversación 5, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
+	t.Run("respuesta a un tweet raíz", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+			},
+		}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		resp, err := service.ReplyToTweet(ctx, 1, 7, "respuesta")
+		if err != nil || resp.ConversationID == nil || *resp.ConversationID != 7 {
+			t.Errorf("esperaba respuesta en la conversación 7, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
+	t.Run("tweet de una cuenta protegida que no sigue", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+			},
+			createFunc: func(ctx context.Context, tweet *model.Tweet) error {
+				t.Error("no esperaba que se cree la respuesta")
+				return nil
+			},
+		}
+		protectedRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "testuser", Protected: id == 2}, nil
+		}}
+		service := NewTweetService(tweetRepo, protectedRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.ReplyToTweet(ctx, 1, 7, "respuesta")
+		if !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+
+	t.Run("tweet respondido no existe", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				return nil, errors.New("no existe")
+			},
+		}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.ReplyToTweet(ctx, 1, 7, "respuesta")
+		if err == nil {
+			t.Error("esperaba error por tweet respondido inexistente")
+		}
+	})
+}
+
+func TestTweetService_GetThread(t *testing.T) {
+	ctx := context.Background()
+	rootID := int64(1)
+	tweetRepo := &mockTweetRepo{
+		getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+			if id == rootID {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: rootID, UserID: 2}}, nil
+			}
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3, InReplyToTweetID: &rootID, ConversationID: &rootID}}, nil
+		},
+		getRepliesFunc: func(ctx context.Context, viewerID, conversationID int64, limit, offset int) ([]*model.TweetWithUser, error) {
+			if conversationID != rootID {
+				t.Errorf("esperaba la conversación %d, obtuve %d", rootID, conversationID)
+			}
+			return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 2}}, {Tweet: model.Tweet{ID: 3}}}, nil
+		},
+	}
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "autor", Protected: id == 2}, nil
+	}}
+
+	t.Run("hilo visible", func(t *testing.T) {
+		followRepo := &mockFollowRepo{existsFunc: func(ctx context.Context, followerID, followingID int64) (bool, error) {
+			return followerID == 5 && followingID == 2, nil
+		}}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+
+		thread, err := service.GetThread(ctx, 5, 3, 20, 0)
+		if err != nil || thread.Root.ID != rootID || len(thread.Replies) != 2 {
+			t.Errorf("esperaba hilo con raíz %d y 2 respuestas, obtuve err: %v, thread: %+v", rootID, err, thread)
+		}
+	})
+
+	t.Run("raíz de una cuenta protegida que no sigue", func(t *testing.T) {
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+
+		_, err := service.GetThread(ctx, 0, 3, 20, 0)
+		if !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+
+	t.Run("raíz borrada", func(t *testing.T) {
+		deletedRootRepo := &mockTweetRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+				if id == rootID {
+					return nil, fmt.Errorf("tweet %w: %d", repository.ErrNotFound, id)
+				}
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3, InReplyToTweetID: &rootID, ConversationID: &rootID}}, nil
+			},
+			getRepliesFunc: tweetRepo.getRepliesFunc,
+		}
+		service := NewTweetService(deletedRootRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+
+		thread, err := service.GetThread(ctx, 0, 3, 20, 0)
+		if err != nil || thread.Root != nil || len(thread.Replies) != 2 {
+			t.Errorf("esperaba el hilo sin raíz y con 2 respuestas, obtuve err: %v, thread: %+v", err, thread)
+		}
+	})
+}
+
+func TestTweetService_GetTweetHistory(t *testing.T) {
+	ctx := context.Background()
+	tweetRepo := &mockTweetRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2}}, nil
+	}}
+
+	t.Run("autor con la cuenta protegida que no sigue", func(t *testing.T) {
+		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "autor", Protected: true}, nil
+		}}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+
+		if _, err := service.GetTweetHistory(ctx, 1, 7); !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+		if _, err := service.GetTweetHistory(ctx, 2, 7); err != nil {
+			t.Errorf("el autor debería ver su historial, obtuve: %v", err)
+		}
+	})
+
+	t.Run("el autor bloqueó al visitante", func(t *testing.T) {
+		userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "autor"}, nil
+		}}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, newMockBlockRepo([2]int64{2, 1}), nil, 280, time.Hour)
+
+		if _, err := service.GetTweetHistory(ctx, 1, 7); !errors.Is(err, ErrBlocked) {
+			t.Errorf("esperaba ErrBlocked, obtuve: %v", err)
+		}
+	})
+}
+
+func TestTweetService_Retweet(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "testuser"}, nil
+	}}
+	originalID := int64(7)
+	getByID := func(ctx context.Context, id int64) (*model.TweetWithUser, error) {
+		if id == originalID {
+			return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 2, Content: "original"}, Username: "autor"}, nil
+		}
+		// Cualquier otro ID es un retweet del original
+		return &model.TweetWithUser{Tweet: model.Tweet{ID: id, UserID: 3, RetweetOfTweetID: &originalID}}, nil
+	}
+
+	t.Run("retweet exitoso embebe al autor original", func(t *testing.T) {
+		timelineRepo := &mockTimelineRepo{}
+		followRepo := &mockFollowRepo{getFollowersFunc: func(ctx context.Context, userID int64, page model.PageQuery) (*model.UserPage, error) {
+			return &model.UserPage{Users: []*model.User{{ID: 4}}}, nil
+		}}
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID,
+			createFunc: func(ctx context.Context, tweet *model.Tweet) error {
+				tweet.ID = 100
+				return nil
+			},
+		}
+		service := NewTweetService(tweetRepo, userRepo, timelineRepo, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		resp, err := service.Retweet(ctx, 1, originalID)
+		if err != nil || resp.RetweetedTweet == nil || resp.RetweetedTweet.Username != "autor" || resp.RetweetedTweet.ID != originalID {
+			t.Errorf("esperaba retweet con el original embebido, obtuve err: %v, resp: %+v", err, resp)
+		}
+	})
+
+	t.Run("retuitear un retweet referencia al original", func(t *testing.T) {
+		var created *model.Tweet
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID,
+			createFunc:  func(ctx context.Context, tweet *model.Tweet) error { created = tweet; return nil },
+		}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.Retweet(ctx, 1, 50)
+		if err != nil || created == nil || created.RetweetOfTweetID == nil || *created.RetweetOfTweetID != originalID {
+			t.Errorf("esperaba retweet del original %d, obtuve err: %v, tweet: %+v", originalID, err, created)
+		}
+	})
+
+	t.Run("tweet ya retuiteado", func(t *testing.T) {
+		tweetRepo := &mockTweetRepo{
+			getByIDFunc: getByID,
+			getRetweetFunc: func(ctx context.Context, userID, tweetID int64) (*model.TweetWithUser, error) {
+				return &model.TweetWithUser{Tweet: model.Tweet{ID: 100}}, nil
+			},
+		}
+		service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.Retweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrAlreadyRetweeted) {
+			t.Errorf("esperaba ErrAlreadyRetweeted, obtuve: %v", err)
+		}
+	})
+
+	t.Run("tweet de cuenta protegida", func(t *testing.T) {
+		protectedRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+			return &model.User{ID: id, Username: "autor", Protected: id == 2}, nil
+		}}
+		service := NewTweetService(&mockTweetRepo{getByIDFunc: getByID}, protectedRepo, &mockTimelineRepo{}, &mockFollowRepo{}, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+		_, err := service.Retweet(ctx, 1, originalID)
+		if !errors.Is(err, ErrProtectedAccount) {
+			t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+		}
+	})
+}
+
+func TestTweetService_GetUserTweetsProtected(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &mockUserRepo{getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+		return &model.User{ID: id, Username: "privado", Protected: true}, nil
+	}}
+	tweetRepo := &mockTweetRepo{getByUserIDFunc: func(ctx context.Context, userID int64, page model.PageQuery) ([]*model.TweetWithUser, error) {
+		return []*model.TweetWithUser{{Tweet: model.Tweet{ID: 1, UserID: userID}}}, nil
+	}}
+	followRepo := &mockFollowRepo{existsFunc: func(ctx context.Context, followerID, followingID int64) (bool, error) {
+		return followerID == 3, nil
+	}}
+	service := NewTweetService(tweetRepo, userRepo, &mockTimelineRepo{}, followRepo, nil, nil, nil, nil, nil, nil, nil, 280, time.Hour)
+
+	cases := []struct {
+		name     string
+		viewerID int64
+		allowed  bool
+	}{
+		{"el dueño ve sus tweets", 2, true},
+		{"un seguidor aprobado ve los tweets", 3, true},
+		{"un no seguidor no los ve", 4, false},
+		{"un visitante anónimo no los ve", 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := service.GetUserTweets(ctx, tc.viewerID, 2, model.PageQuery{Limit: 20})
+			if tc.allowed && (err != nil || len(page.Tweets) != 1) {
+				t.Errorf("esperaba los tweets, obtuve err: %v, page: %+v", err, page)
+			}
+			if !tc.allowed && !errors.Is(err, ErrProtectedAccount) {
+				t.Errorf("esperaba ErrProtectedAccount, obtuve: %v", err)
+			}
+		})
+	}
+}
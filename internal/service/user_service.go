@@ -5,16 +5,24 @@ import (
 	"fmt"
 	"microx/internal/model"
 	"microx/internal/repository"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo   repository.UserRepository
+	tweetRepo  repository.TweetRepository
+	tweetCache repository.TweetCacheRepository
 }
 
 // NewUserService crea una nueva instancia del servicio de usuarios
-func NewUserService(userRepo repository.UserRepository) UserService {
+func NewUserService(userRepo repository.UserRepository, tweetRepo repository.TweetRepository, tweetCache repository.TweetCacheRepository) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		tweetRepo:  tweetRepo,
+		tweetCache: tweetCache,
 	}
 }
 
@@ -45,6 +53,133 @@ func (s *userService) GetUser(ctx context.Context, userID int64) (*model.User, e
 	return user, nil
 }
 
+func (s *userService) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	if username == "" {
+		return nil, fmt.Errorf("invalid username")
+	}
+
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetUsers obtiene varios usuarios en el orden en que se pidieron. Los IDs repetidos se devuelven
+// una sola vez y los que no existen se omiten.
+func (s *userService) GetUsers(ctx context.Context, userIDs []int64) ([]*model.User, error) {
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users: %w", err)
+	}
+
+	byID := make(map[int64]*model.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	ordered := make([]*model.User, 0, len(users))
+	for _, id := range userIDs {
+		if user, ok := byID[id]; ok {
+			ordered = append(ordered, user)
+			delete(byID, id)
+		}
+	}
+
+	return ordered, nil
+}
+
+// UpdateProfile valida y guarda los campos del perfil que vienen en la solicitud. Los valores se
+// guardan sin espacios al principio ni al final.
+func (s *userService) UpdateProfile(ctx context.Context, userID int64, req *model.UpdateProfileRequest) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	displayName, avatarURL := user.DisplayName, user.AvatarURL
+
+	fields := []struct {
+		name      string
+		value     *string
+		target    *string
+		maxLength int
+		multiline bool
+		isURL     bool
+	}{
+		{"display_name", req.DisplayName, &user.DisplayName, model.MaxDisplayNameLength, false, false},
+		{"bio", req.Bio, &user.Bio, model.MaxBioLength, true, false},
+		{"location", req.Location, &user.Location, model.MaxLocationLength, false, false},
+		{"website", req.Website, &user.Website, model.MaxWebsiteLength, false, true},
+		{"avatar_url", req.AvatarURL, &user.AvatarURL, model.MaxAvatarURLLength, false, true},
+	}
+
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+
+		value := strings.TrimSpace(*field.value)
+		if utf8.RuneCountInString(value) > field.maxLength {
+			return nil, fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidProfile, field.name, field.maxLength)
+		}
+		// La bio admite saltos de línea; ningún campo admite otros caracteres de control
+		if strings.IndexFunc(value, func(r rune) bool { return unicode.IsControl(r) && !(field.multiline && r == '\n') }) >= 0 {
+			return nil, fmt.Errorf("%w: %s cannot contain control characters", ErrInvalidProfile, field.name)
+		}
+		if field.isURL && value != "" && !isWebURL(value) {
+			return nil, fmt.Errorf("%w: %s must be an http or https URL", ErrInvalidProfile, field.name)
+		}
+		*field.target = value
+	}
+
+	if req.Protected != nil {
+		user.Protected = *req.Protected
+	}
+
+	err = s.userRepo.UpdateProfile(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("error updating profile: %w", err)
+	}
+
+	// Los tweets cacheados llevan el nombre visible y el avatar del autor
+	if user.DisplayName != displayName || user.AvatarURL != avatarURL {
+		s.invalidateCachedTweets(ctx, userID)
+	}
+
+	return user, nil
+}
+
+// invalidateCachedTweets elimina de la caché los tweets del usuario para que se vuelvan a hidratar
+// con su perfil actual. Si falla, el perfil anterior se sigue viendo hasta que venza la caché.
+func (s *userService) invalidateCachedTweets(ctx context.Context, userID int64) {
+	if s.tweetCache == nil || s.tweetRepo == nil {
+		return
+	}
+
+	tweetIDs, err := s.tweetRepo.GetIDsByUserID(ctx, userID)
+	if err != nil {
+		fmt.Printf("Warning: error getting tweets of user %d: %v\n", userID, err)
+		return
+	}
+
+	if len(tweetIDs) > 0 {
+		if err := s.tweetCache.Delete(ctx, tweetIDs...); err != nil {
+			fmt.Printf("Warning: error removing tweets of user %d from cache: %v\n", userID, err)
+		}
+	}
+}
+
+// isWebURL indica si value es una URL absoluta http o https
+func isWebURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
 func (s *userService) GetUserStats(ctx context.Context, userID int64) (*model.UserStats, error) {
 	// Validación básica
 	if userID <= 0 {
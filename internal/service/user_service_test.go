@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"microx/internal/model"
+	"strings"
 	"testing"
 )
 
@@ -12,7 +14,7 @@ func TestUserService_CreateUser(t *testing.T) {
 		repo := &mockUserRepo{
 			createFunc: func(ctx context.Context, user *model.User) error { return nil },
 		}
-		service := NewUserService(repo)
+		service := NewUserService(repo, nil, nil)
 		user, err := service.CreateUser(context.Background(), "usuario", "mail@mail.com")
 		if err != nil || user.Username != "usuario" || user.Email != "mail@mail.com" {
 			t.Errorf("esperaba creación exitosa, obtuve err: %v, user: %+v", err, user)
@@ -20,7 +22,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	})
 
 	t.Run("username vacío", func(t *testing.T) {
-		service := NewUserService(&mockUserRepo{})
+		service := NewUserService(&mockUserRepo{}, nil, nil)
 		_, err := service.CreateUser(context.Background(), "", "mail@mail.com")
 		if err == nil {
 			t.Error("esperaba error por username vacío")
@@ -28,7 +30,7 @@ func TestUserService_CreateUser(t *testing.T) {
 	})
 
 	t.Run("email vacío", func(t *testing.T) {
-		service := NewUserService(&mockUserRepo{})
+		service := NewUserService(&mockUserRepo{}, nil, nil)
 		_, err := service.CreateUser(context.Background(), "usuario", "")
 		if err == nil {
 			t.Error("esperaba error por email vacío")
@@ -39,10 +41,154 @@ func TestUserService_CreateUser(t *testing.T) {
 		repo := &mockUserRepo{
 			createFunc: func(ctx context.Context, user *model.User) error { return errors.New("fallo repo") },
 		}
-		service := NewUserService(repo)
+		service := NewUserService(repo, nil, nil)
 		_, err := service.CreateUser(context.Background(), "usuario", "mail@mail.com")
 		if err == nil || err.Error() != "fallo repo" {
 			t.Errorf("esperaba error del repo, obtuve: %v", err)
 		}
 	})
 }
+
+func TestUserService_GetUserByUsername(t *testing.T) {
+	var queried string
+	repo := &mockUserRepo{getByUsernameFunc: func(ctx context.Context, username string) (*model.User, error) {
+		queried = username
+		return &model.User{ID: 2, Username: "rocio"}, nil
+	}}
+	service := NewUserService(repo, nil, nil)
+
+	user, err := service.GetUserByUsername(context.Background(), "@rocio")
+	if err != nil || user.ID != 2 {
+		t.Errorf("esperaba el usuario 2, obtuve err: %v, user: %+v", err, user)
+	}
+	if queried != "rocio" {
+		t.Errorf("esperaba consultar sin @, obtuve %q", queried)
+	}
+
+	if _, err := service.GetUserByUsername(context.Background(), " @ "); err == nil {
+		t.Error("esperaba error por username vacío")
+	}
+}
+
+func TestUserService_GetUsers(t *testing.T) {
+	repo := &mockUserRepo{getByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.User, error) {
+		return []*model.User{{ID: 1, Username: "jose"}, {ID: 3, Username: "yanina"}}, nil
+	}}
+	service := NewUserService(repo, nil, nil)
+
+	users, err := service.GetUsers(context.Background(), []int64{3, 99, 1, 3})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	// Se respeta el orden pedido, sin repetidos ni inexistentes
+	if len(users) != 2 || users[0].ID != 3 || users[1].ID != 1 {
+		t.Errorf("esperaba los usuarios 3 y 1, obtuve %+v", users)
+	}
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	newRepo := func(saved **model.User) *mockUserRepo {
+		return &mockUserRepo{
+			getByIDFunc: func(ctx context.Context, id int64) (*model.User, error) {
+				return &model.User{ID: id, Username: "jose", DisplayName: "José", Bio: "bio anterior"}, nil
+			},
+			updateProfileFunc: func(ctx context.Context, user *model.User) error {
+				*saved = user
+				return nil
+			},
+		}
+	}
+	str := func(s string) *string { return &s }
+
+	t.Run("actualiza solo los campos enviados", func(t *testing.T) {
+		var saved *model.User
+		service := NewUserService(newRepo(&saved), nil, nil)
+		user, err := service.UpdateProfile(ctx, 1, &model.UpdateProfileRequest{
+			Bio:     str("  Programo en Go\ny ando en moto  "),
+			Website: str("https://example.com/jose"),
+		})
+		if err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+
+		if saved == nil || saved != user {
+			t.Fatal("esperaba guardar el perfil actualizado")
+		}
+		if user.DisplayName != "José" || user.Bio != "Programo en Go\ny ando en moto" || user.Website != "https://example.com/jose" {
+			t.Errorf("perfil inesperado: %+v", user)
+		}
+	})
+
+	t.Run("un string vacío borra el campo", func(t *testing.T) {
+		var saved *model.User
+		service := NewUserService(newRepo(&saved), nil, nil)
+		user, err := service.UpdateProfile(ctx, 1, &model.UpdateProfileRequest{Bio: str("")})
+		if err != nil || user.Bio != "" {
+			t.Errorf("esperaba borrar la bio, obtuve err: %v, user: %+v", err, user)
+		}
+	})
+
+	t.Run("cambiar el nombre visible invalida los tweets cacheados", func(t *testing.T) {
+		var saved *model.User
+		var deleted []int64
+		tweetRepo := &mockTweetRepo{getIDsByUserFunc: func(ctx context.Context, userID int64) ([]int64, error) {
+			return []int64{10, 11}, nil
+		}}
+		tweetCache := &mockTweetCache{deleteFunc: func(ctx context.Context, ids ...int64) error {
+			deleted = append(deleted, ids...)
+			return nil
+		}}
+		service := NewUserService(newRepo(&saved), tweetRepo, tweetCache)
+
+		if _, err := service.UpdateProfile(ctx, 1, &model.UpdateProfileRequest{DisplayName: str("Pepe")}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(deleted) != 2 {
+			t.Errorf("esperaba invalidar los tweets 10 y 11, obtuve %v", deleted)
+		}
+
+		deleted = nil
+		if _, err := service.UpdateProfile(ctx, 1, &model.UpdateProfileRequest{Bio: str("otra bio")}); err != nil {
+			t.Fatalf("error inesperado: %v", err)
+		}
+		if len(deleted) != 0 {
+			t.Errorf("no esperaba invalidar tweets al cambiar la bio, obtuve %v", deleted)
+		}
+	})
+
+	invalid := map[string]*model.UpdateProfileRequest{
+		"nombre demasiado largo":    {DisplayName: str(strings.Repeat("a", model.MaxDisplayNameLength+1))},
+		"bio demasiado larga":       {Bio: str(strings.Repeat("é", model.MaxBioLength+1))},
+		"nombre con salto de línea": {DisplayName: str("José\nPérez")},
+		"website sin esquema":       {Website: str("example.com")},
+		"avatar con otro esquema":   {AvatarURL: str("javascript:alert(1)")},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			var saved *model.User
+			service := NewUserService(newRepo(&saved), nil, nil)
+			if _, err := service.UpdateProfile(ctx, 1, req); !errors.Is(err, ErrInvalidProfile) {
+				t.Errorf("esperaba ErrInvalidProfile, obtuve: %v", err)
+			}
+			if saved != nil {
+				t.Error("no esperaba guardar el perfil")
+			}
+		})
+	}
+}
+
+func TestUser_JSONHidesEmail(t *testing.T) {
+	user := &model.User{ID: 1, Username: "jose", Email: "jose@example.com", PasswordHash: "hash"}
+
+	public, _ := json.Marshal(user)
+	if strings.Contains(string(public), "jose@example.com") || strings.Contains(string(public), "hash") {
+		t.Errorf("el perfil público no debe incluir email ni contraseña: %s", public)
+	}
+
+	account, _ := json.Marshal(model.NewAccount(user))
+	if !strings.Contains(string(account), `"email":"jose@example.com"`) || !strings.Contains(string(account), `"username":"jose"`) {
+		t.Errorf("esperaba la cuenta con el email y el perfil: %s", account)
+	}
+}
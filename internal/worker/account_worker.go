@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"microx/internal/service"
+)
+
+// AccountWorkerConfig contiene las opciones de las tareas de fondo de cuentas
+type AccountWorkerConfig struct {
+	// GracePeriod es el tiempo que una cuenta dada de baja se conserva antes de borrarla definitivamente
+	GracePeriod time.Duration
+	// PurgeInterval es cada cuánto se borran las cuentas y las exportaciones vencidas
+	PurgeInterval time.Duration
+	// ExportPollInterval es cada cuánto se buscan exportaciones pendientes cuando no hay ninguna
+	ExportPollInterval time.Duration
+}
+
+// AccountWorker genera las exportaciones de datos pendientes y borra definitivamente las cuentas
+// cuyo período de gracia venció
+type AccountWorker struct {
+	accounts service.AccountService
+	cfg      AccountWorkerConfig
+}
+
+// NewAccountWorker crea una nueva instancia del worker de cuentas
+func NewAccountWorker(accounts service.AccountService, cfg AccountWorkerConfig) *AccountWorker {
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = time.Hour
+	}
+	if cfg.ExportPollInterval <= 0 {
+		cfg.ExportPollInterval = 5 * time.Second
+	}
+
+	return &AccountWorker{
+		accounts: accounts,
+		cfg:      cfg,
+	}
+}
+
+// Run inicia las tareas y bloquea hasta que se cancele el contexto
+func (w *AccountWorker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		w.processExports(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		w.purge(ctx)
+	}()
+
+	log.Printf("🗑️  Account worker started (grace period %s)", w.cfg.GracePeriod)
+	wg.Wait()
+}
+
+// processExports genera las exportaciones pendientes una tras otra; cuando no queda ninguna,
+// espera ExportPollInterval antes de volver a buscar
+func (w *AccountWorker) processExports(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.accounts.ProcessNextExport(ctx)
+		if err != nil {
+			log.Printf("Warning: error processing data export: %v", err)
+		}
+		if !processed {
+			sleep(ctx, w.cfg.ExportPollInterval)
+		}
+	}
+}
+
+// purge borra cada PurgeInterval las cuentas con el período de gracia vencido y las
+// exportaciones expiradas
+func (w *AccountWorker) purge(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		w.purgeOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *AccountWorker) purgeOnce(ctx context.Context, now time.Time) {
+	purged, err := w.accounts.PurgeDeletedAccounts(ctx, now.Add(-w.cfg.GracePeriod))
+	if err != nil {
+		log.Printf("Warning: error purging deleted accounts: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted accounts", purged)
+	}
+
+	if _, err := w.accounts.PurgeExpiredExports(ctx, now); err != nil {
+		log.Printf("Warning: error purging expired data exports: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"microx/internal/model"
+	"microx/internal/repository"
+	"microx/internal/service"
+)
+
+// FanoutWorkerConfig contiene las opciones del pool de workers de fan-out
+type FanoutWorkerConfig struct {
+	// Concurrency es la cantidad de workers que procesan jobs en paralelo
+	Concurrency int
+	// MaxAttempts es la cantidad de intentos antes de descartar un job (dead-letter)
+	MaxAttempts int
+	// BaseBackoff es la espera antes del primer reintento; se duplica en cada intento
+	BaseBackoff time.Duration
+	// ConsumerName identifica a esta instancia dentro del grupo de consumidores
+	ConsumerName string
+}
+
+const (
+	// fanoutBatchSize es la cantidad de jobs que lee cada worker por vez
+	fanoutBatchSize = 10
+	// fanoutBlockTimeout es el tiempo máximo que un worker espera por jobs nuevos
+	fanoutBlockTimeout = 5 * time.Second
+	// fanoutMaintenanceInterval es cada cuánto se encolan los reintentos vencidos y se
+	// reasignan los jobs abandonados
+	fanoutMaintenanceInterval = time.Second
+	// fanoutClaimIdle es el tiempo sin confirmar tras el cual un job se considera abandonado
+	fanoutClaimIdle = time.Minute
+)
+
+// FanoutWorker procesa los jobs de la cola de fan-out con un pool de goroutines
+type FanoutWorker struct {
+	queue  repository.FanoutQueueRepository
+	fanout service.FanoutService
+	cfg    FanoutWorkerConfig
+}
+
+// NewFanoutWorker crea una nueva instancia del pool de workers de fan-out
+func NewFanoutWorker(queue repository.FanoutQueueRepository, fanout service.FanoutService, cfg FanoutWorkerConfig) *FanoutWorker {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.ConsumerName == "" {
+		cfg.ConsumerName = "worker"
+	}
+
+	return &FanoutWorker{
+		queue:  queue,
+		fanout: fanout,
+		cfg:    cfg,
+	}
+}
+
+// Run inicia los workers y bloquea hasta que se cancele el contexto
+func (w *FanoutWorker) Run(ctx context.Context) error {
+	if err := w.queue.EnsureGroup(ctx); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Concurrency; i++ {
+		wg.Add(1)
+		consumer := fmt.Sprintf("%s-%d", w.cfg.ConsumerName, i)
+		go func() {
+			defer wg.Done()
+			w.consume(ctx, consumer)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.maintain(ctx, w.cfg.ConsumerName+"-claimer")
+	}()
+
+	log.Printf("📬 Fan-out worker started with %d workers", w.cfg.Concurrency)
+	wg.Wait()
+	return nil
+}
+
+// consume lee y procesa jobs nuevos hasta que se cancele el contexto
+func (w *FanoutWorker) consume(ctx context.Context, consumer string) {
+	for ctx.Err() == nil {
+		jobs, err := w.queue.Consume(ctx, consumer, fanoutBatchSize, fanoutBlockTimeout)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Warning: error reading fanout jobs: %v", err)
+				sleep(ctx, fanoutMaintenanceInterval)
+			}
+			continue
+		}
+
+		for _, job := range jobs {
+			w.handle(ctx, job)
+		}
+	}
+}
+
+// maintain encola los reintentos vencidos y procesa los jobs abandonados por otros consumidores
+func (w *FanoutWorker) maintain(ctx context.Context, consumer string) {
+	ticker := time.NewTicker(fanoutMaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := w.queue.PromoteDelayed(ctx, now); err != nil {
+				log.Printf("Warning: error promoting delayed fanout jobs: %v", err)
+			}
+
+			jobs, err := w.queue.ClaimStale(ctx, consumer, fanoutClaimIdle, fanoutBatchSize)
+			if err != nil {
+				log.Printf("Warning: error claiming stale fanout jobs: %v", err)
+				continue
+			}
+			for _, job := range jobs {
+				w.handle(ctx, job)
+			}
+		}
+	}
+}
+
+// handle procesa un job y lo confirma, lo reprograma con backoff exponencial o lo descarta
+// si agotó sus intentos
+func (w *FanoutWorker) handle(ctx context.Context, job *model.FanoutJob) {
+	err := w.fanout.ProcessJob(ctx, job)
+	if err == nil {
+		if err := w.queue.Ack(ctx, job); err != nil {
+			log.Printf("Warning: error acknowledging fanout job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	job.Attempts++
+	if job.Attempts >= w.cfg.MaxAttempts {
+		log.Printf("Fanout job for tweet %d dead-lettered after %d attempts: %v", job.TweetID, job.Attempts, err)
+		if err := w.queue.DeadLetter(ctx, job, err.Error()); err != nil {
+			log.Printf("Warning: error dead-lettering fanout job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	delay := w.backoff(job.Attempts)
+	log.Printf("Fanout job for tweet %d failed (attempt %d), retrying in %s: %v", job.TweetID, job.Attempts, delay, err)
+	if err := w.queue.Retry(ctx, job, delay); err != nil {
+		log.Printf("Warning: error scheduling fanout retry %s: %v", job.ID, err)
+	}
+}
+
+// backoff calcula la espera antes del reintento n (1, 2, ...): BaseBackoff * 2^(n-1)
+func (w *FanoutWorker) backoff(attempt int) time.Duration {
+	return w.cfg.BaseBackoff << (attempt - 1)
+}
+
+// sleep espera la duración dada o hasta que se cancele el contexto
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,14 @@
+-- Historial de ediciones de tweets
+-- Cada fila guarda una versión anterior del contenido de un tweet
+
+USE microx;
+
+-- Tabla de revisiones de tweets
+CREATE TABLE IF NOT EXISTS tweet_revisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tweet_id BIGINT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    INDEX idx_tweet_created (tweet_id, created_at DESC)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
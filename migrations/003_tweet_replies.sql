@@ -0,0 +1,11 @@
+-- Respuestas y conversaciones
+-- in_reply_to_tweet_id apunta al tweet respondido y conversation_id al tweet raíz del hilo
+
+USE microx;
+
+ALTER TABLE tweets
+    ADD COLUMN in_reply_to_tweet_id BIGINT NULL AFTER content,
+    ADD COLUMN conversation_id BIGINT NULL AFTER in_reply_to_tweet_id,
+    ADD CONSTRAINT fk_tweets_in_reply_to FOREIGN KEY (in_reply_to_tweet_id) REFERENCES tweets(id) ON DELETE SET NULL,
+    ADD INDEX idx_in_reply_to (in_reply_to_tweet_id),
+    ADD INDEX idx_conversation_created (conversation_id, created_at);
@@ -0,0 +1,13 @@
+-- Retweets y citas
+-- Un retweet es un tweet sin contenido propio que referencia al original (retweet_of_tweet_id);
+-- una cita es un tweet con contenido propio que referencia al tweet citado (quoted_tweet_id)
+
+USE microx;
+
+ALTER TABLE tweets
+    ADD COLUMN retweet_of_tweet_id BIGINT NULL AFTER conversation_id,
+    ADD COLUMN quoted_tweet_id BIGINT NULL AFTER retweet_of_tweet_id,
+    ADD CONSTRAINT fk_tweets_retweet_of FOREIGN KEY (retweet_of_tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_tweets_quoted FOREIGN KEY (quoted_tweet_id) REFERENCES tweets(id) ON DELETE SET NULL,
+    ADD UNIQUE KEY unique_retweet (user_id, retweet_of_tweet_id),
+    ADD INDEX idx_quoted (quoted_tweet_id);
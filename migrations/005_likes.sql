@@ -0,0 +1,24 @@
+-- Likes
+-- tweet_stats mantiene el contador desnormalizado de likes, actualizado en la misma
+-- transacción que la tabla likes para que sea consistente ante likes concurrentes
+
+USE microx;
+
+-- Tabla de likes
+CREATE TABLE IF NOT EXISTS likes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    tweet_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_like (user_id, tweet_id),
+    INDEX idx_tweet_created (tweet_id, created_at DESC)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Tabla de contadores por tweet
+CREATE TABLE IF NOT EXISTS tweet_stats (
+    tweet_id BIGINT PRIMARY KEY,
+    like_count BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
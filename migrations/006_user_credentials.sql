@@ -0,0 +1,7 @@
+-- Credenciales de usuario
+-- Los usuarios creados antes de esta migración quedan sin contraseña y no pueden iniciar sesión
+
+USE microx;
+
+ALTER TABLE users
+    ADD COLUMN password_hash VARCHAR(255) NOT NULL DEFAULT '' AFTER email;
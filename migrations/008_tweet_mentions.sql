@@ -0,0 +1,16 @@
+-- Menciones
+-- Cada aparición de @username que corresponde a un usuario existente, con su posición en el
+-- contenido (en caracteres) para devolverla como entidad del tweet
+
+USE microx;
+
+CREATE TABLE IF NOT EXISTS tweet_mentions (
+    tweet_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    start_offset INT NOT NULL,
+    end_offset INT NOT NULL,
+    PRIMARY KEY (tweet_id, start_offset),
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_tweet (user_id, tweet_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
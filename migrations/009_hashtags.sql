@@ -0,0 +1,20 @@
+-- Hashtags
+-- Los hashtags se guardan normalizados (en minúsculas) y con collation binaria, para que solo
+-- coincidan etiquetas idénticas; tweet_hashtags indexa los tweets de cada hashtag
+
+USE microx;
+
+CREATE TABLE IF NOT EXISTS hashtags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tag VARCHAR(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS tweet_hashtags (
+    tweet_id BIGINT NOT NULL,
+    hashtag_id BIGINT NOT NULL,
+    PRIMARY KEY (tweet_id, hashtag_id),
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    FOREIGN KEY (hashtag_id) REFERENCES hashtags(id) ON DELETE CASCADE,
+    INDEX idx_hashtag_tweet (hashtag_id, tweet_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
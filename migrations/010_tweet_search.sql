@@ -0,0 +1,7 @@
+-- Búsqueda de tweets
+-- Índice FULLTEXT sobre el contenido para las búsquedas con MATCH ... AGAINST en modo booleano
+
+USE microx;
+
+ALTER TABLE tweets
+    ADD FULLTEXT INDEX ft_tweets_content (content);
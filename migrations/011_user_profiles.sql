@@ -0,0 +1,11 @@
+-- Perfiles de usuario
+-- Campos editables del perfil público; vacíos hasta que el usuario los completa
+
+USE microx;
+
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(50) NOT NULL DEFAULT '' AFTER password_hash,
+    ADD COLUMN bio VARCHAR(160) NOT NULL DEFAULT '' AFTER display_name,
+    ADD COLUMN location VARCHAR(30) NOT NULL DEFAULT '' AFTER bio,
+    ADD COLUMN website VARCHAR(100) NOT NULL DEFAULT '' AFTER location,
+    ADD COLUMN avatar_url VARCHAR(255) NOT NULL DEFAULT '' AFTER website;
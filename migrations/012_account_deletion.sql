@@ -0,0 +1,25 @@
+-- Baja de cuentas y exportación de datos
+-- deleted_at marca las cuentas dadas de baja, que se ocultan de inmediato y se borran
+-- definitivamente al vencer el período de gracia. data_exports registra los archivos con los
+-- datos de cada usuario, que se generan en segundo plano.
+
+USE microx;
+
+ALTER TABLE users
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER avatar_url,
+    ADD INDEX idx_deleted_at (deleted_at);
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP NULL DEFAULT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_created (user_id, created_at),
+    INDEX idx_status_created (status, created_at),
+    INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,19 @@
+-- Cuentas protegidas y pedidos de follow
+-- Seguir a una cuenta protegida crea un pedido pendiente en follow_requests; el follow recién se
+-- crea cuando la cuenta lo aprueba. Los tweets de las cuentas protegidas solo los ven sus seguidores.
+
+USE microx;
+
+ALTER TABLE users
+    ADD COLUMN protected BOOLEAN NOT NULL DEFAULT FALSE AFTER avatar_url;
+
+CREATE TABLE IF NOT EXISTS follow_requests (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    requester_id BIGINT NOT NULL,
+    target_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_follow_request (requester_id, target_id),
+    INDEX idx_target_created (target_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+-- Bloqueos entre usuarios
+-- Bloquear a un usuario elimina los follows y pedidos de follow entre ambos, impide que se vuelvan
+-- a seguir, mencionar o responder, y oculta los tweets de quien bloquea al bloqueado.
+
+USE microx;
+
+CREATE TABLE IF NOT EXISTS blocks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    blocker_id BIGINT NOT NULL,
+    blocked_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_block (blocker_id, blocked_id),
+    INDEX idx_blocked_blocker (blocked_id, blocker_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
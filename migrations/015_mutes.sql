@@ -0,0 +1,26 @@
+-- Cuentas y palabras silenciadas
+-- Silenciar no modifica los follows: solo oculta del timeline del usuario los tweets de las cuentas
+-- silenciadas (hasta expires_at, si se indicó) y los que contienen alguna de sus palabras silenciadas.
+
+USE microx;
+
+CREATE TABLE IF NOT EXISTS muted_users (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    muted_user_id BIGINT NOT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (muted_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_muted_user (user_id, muted_user_id),
+    INDEX idx_user_expires (user_id, expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS muted_words (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    phrase VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_muted_word (user_id, phrase)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
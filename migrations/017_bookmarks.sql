@@ -0,0 +1,16 @@
+-- Tweets guardados
+-- Los guardados son privados: solo los ve quien los guardó. Se eliminan en cascada con el tweet y,
+-- al bloquear, se eliminan los que el bloqueado tenía de tweets de quien lo bloquea.
+
+USE microx;
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    tweet_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    UNIQUE KEY unique_bookmark (user_id, tweet_id),
+    INDEX idx_user_created (user_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;